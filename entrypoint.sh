@@ -1,8 +0,0 @@
-#!/bin/sh
-set -e
-
-echo "Running database migrations..."
-goose -dir ./src/news/migrations/postgres postgres "$DB_URL" up
-
-echo "Starting application..."
-exec "$@"
\ No newline at end of file
@@ -37,13 +37,19 @@ func main() {
 			os.Exit(1)
 		}
 	}()
+	go func() {
+		if err := application.GRPCServer.Start(); err != nil {
+			logger.Log.Error("Failed to start gRPC server", "error", err)
+			os.Exit(1)
+		}
+	}()
 	logger.Log.Info("Application started successfully", "env", cfg.Env, "port", cfg.HTTP.Port)
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	if err := application.HTTPServer.Stop(ctx); err != nil {
+	if err := application.Stop(ctx); err != nil {
 		logger.Log.Error("Failed to stop application gracefully", "error", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+)
+
+func runCache(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "flush":
+		if len(args) != 1 {
+			return errUsage
+		}
+		newsService, _, err := newNewsService(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		deleted, err := newsService.FlushCache(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d cache keys deleted\n", deleted)
+	case "warm":
+		flags := flag.NewFlagSet("cache warm", flag.ExitOnError)
+		limit := flags.Int("limit", 200, "how many of the latest visible news to cache")
+		_ = flags.Parse(args[1:])
+
+		newsService, _, err := newNewsService(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		warmed, err := newsService.WarmCache(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d news cached\n", warmed)
+	default:
+		return errUsage
+	}
+	return nil
+}
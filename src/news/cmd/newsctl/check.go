@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/migrate"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+	"github.com/zhavkk/news-service/src/news/migrations"
+)
+
+// runCheck проверяет конфиг, а с -connect ещё и доступность Postgres и Redis
+// и наличие неприменённых миграций.
+func runCheck(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	connect := flags.Bool("connect", false, "also connect to Postgres and Redis")
+	_ = flags.Parse(args)
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config:\n%w", err)
+	}
+	fmt.Println("config: ok")
+	if !*connect {
+		return nil
+	}
+
+	db, err := storage.NewStorage(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if err := db.GetPool().Ping(ctx); err != nil {
+		return fmt.Errorf("postgres: %w", err)
+	}
+	fmt.Println("postgres: ok")
+
+	migrator, err := migrate.New(db.GetPool(), migrations.Postgres, "postgres", cfg.Migrations.LockTimeout)
+	if err != nil {
+		return err
+	}
+	state, err := migrator.State(ctx)
+	if err != nil {
+		return fmt.Errorf("migrations: %w", err)
+	}
+	if state.TooNew() {
+		return fmt.Errorf("migrations: %w: applied %d, known %d", migrate.ErrSchemaTooNew, state.Current, state.Latest)
+	}
+	if state.Pending > 0 {
+		return fmt.Errorf("migrations: %d pending, run newsctl migrate up", state.Pending)
+	}
+	fmt.Println("migrations: ok")
+
+	redis, err := storage.NewRedisClient(ctx, &cfg.Redis)
+	if err != nil {
+		return fmt.Errorf("redis: %w", err)
+	}
+	defer redis.GetRedis().Close()
+	fmt.Println("redis: ok")
+	return nil
+}
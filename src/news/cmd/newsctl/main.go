@@ -0,0 +1,73 @@
+// Команда newsctl — служебные операции с данными сервиса новостей из командной строки.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+const usage = `Usage: newsctl [-config path] <command> [arguments]
+
+Commands:
+  migrate up|down|status                               apply, roll back or list database migrations
+  cache flush                                          drop cached news, lists and feeds from Redis
+  cache warm [-limit N]                                cache the latest visible news
+  news export [-format ndjson|csv] [-category c] [-search q] [-o file]
+                                                       write the news archive to stdout or a file
+  news import -format ndjson|csv [-dry-run] <file|->   import news, upserting by external_id
+  news purge-expired [-older-than 720h] [-dry-run]     delete news that ended more than older-than ago
+  seed [-count N] [-seed N]                            create fake news for local development
+  check [-connect]                                     validate the config, optionally ping Postgres and Redis
+`
+
+// errUsage возвращают команды, вызванные с неизвестной подкомандой.
+var errUsage = errors.New("usage")
+
+// command выполняет одну команду; args — аргументы после её имени.
+type command func(ctx context.Context, cfg *config.Config, args []string) error
+
+var commands = map[string]command{
+	"migrate": runMigrate,
+	"cache":   runCache,
+	"news":    runNews,
+	"seed":    runSeed,
+	"check":   runCheck,
+}
+
+func main() {
+	flags := flag.NewFlagSet("newsctl", flag.ExitOnError)
+	flags.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	configPath := flags.String("config", "src/news/config/config.yml", "path to the config file")
+	_ = flags.Parse(os.Args[1:])
+
+	args := flags.Args()
+	if len(args) == 0 || commands[args[0]] == nil {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	cfg := config.MustLoad(*configPath)
+	// Логи идут в stderr, stdout остаётся для вывода команд (например, экспорта).
+	logger.InitWriter(cfg.Env, os.Stderr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	err := commands[args[0]](ctx, cfg, args[1:])
+	if errors.Is(err, errUsage) {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "newsctl:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// newNewsService собирает NewsService так же, как сервер. Задачи, которые он
+// ставит (например, генерация вариантов изображений), выполнит очередь сервера.
+func newNewsService(ctx context.Context, cfg *config.Config) (*service.NewsService, *storage.TxManager, error) {
+	txManager, err := storage.NewTxManager(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	redis, err := storage.NewRedisClient(ctx, &cfg.Redis)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db := txManager.GetDatabase()
+	jobQueue, err := service.NewJobQueue(postgres.NewJobRepository(db), txManager, cfg.Jobs)
+	if err != nil {
+		return nil, nil, err
+	}
+	newsService := service.NewNewsService(
+		postgres.NewNewsRepository(db),
+		postgres.NewMediaRepository(db),
+		postgres.NewOutboxRepository(db),
+		jobQueue,
+		txManager,
+		redis,
+		cfg.Redis.CacheTTL,
+	)
+	return newsService, txManager, nil
+}
+
+func runNews(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	switch args[0] {
+	case "export":
+		return runNewsExport(ctx, cfg, args[1:])
+	case "import":
+		return runNewsImport(ctx, cfg, args[1:])
+	case "purge-expired":
+		return runNewsPurgeExpired(ctx, cfg, args[1:])
+	default:
+		return errUsage
+	}
+}
+
+// runNewsExport пишет архив в том же формате, что и GET /news/export.
+func runNewsExport(ctx context.Context, cfg *config.Config, args []string) (err error) {
+	flags := flag.NewFlagSet("news export", flag.ExitOnError)
+	format := flags.String("format", dto.ArchiveFormatNDJSON, "file format: ndjson or csv")
+	category := flags.String("category", "", "export only this category")
+	search := flags.String("search", "", "export only news matching the query")
+	all := flags.Bool("all", false, "include news outside their start_time..end_time window")
+	output := flags.String("o", "-", "output file, - for stdout")
+	_ = flags.Parse(args)
+
+	req := dto.NewsExportRequest{
+		Format:          *format,
+		Search:          *search,
+		Category:        *category,
+		SortBy:          "created_at",
+		SortDir:         "desc",
+		CheckVisibility: !*all,
+	}
+	if req.Format != dto.ArchiveFormatNDJSON && req.Format != dto.ArchiveFormatCSV {
+		return fmt.Errorf("news export: unknown format %q", req.Format)
+	}
+
+	txManager, err := storage.NewTxManager(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	exportService := service.NewExportService(postgres.NewNewsRepository(txManager.GetDatabase()), txManager, cfg.Export)
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		w = file
+	}
+
+	return exportService.ExportNews(ctx, req, w)
+}
+
+// runNewsPurgeExpired удаляет новости, показ которых закончился больше чем
+// older-than назад, и печатает их число.
+func runNewsPurgeExpired(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("news purge-expired", flag.ExitOnError)
+	olderThan := flags.Duration("older-than", 0, "keep news that ended less than this long ago")
+	dryRun := flags.Bool("dry-run", false, "only count the news that would be deleted")
+	_ = flags.Parse(args)
+
+	if *olderThan < 0 {
+		return errors.New("news purge-expired: -older-than must not be negative")
+	}
+
+	newsService, _, err := newNewsService(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	purged, err := newsService.PurgeExpired(ctx, time.Now().Add(-*olderThan), *dryRun)
+	if err != nil {
+		return err
+	}
+	if *dryRun {
+		fmt.Printf("%d expired news would be deleted\n", purged)
+	} else {
+		fmt.Printf("%d expired news deleted\n", purged)
+	}
+	return nil
+}
+
+// runNewsImport выполняет импорт так же, как POST /news/import, но синхронно,
+// и печатает итоговую задачу с отчётом об ошибках в stdout.
+func runNewsImport(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("news import", flag.ExitOnError)
+	format := flags.String("format", dto.ArchiveFormatNDJSON, "file format: ndjson or csv")
+	dryRun := flags.Bool("dry-run", false, "validate the file without saving anything")
+	_ = flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("news import: expected exactly one file argument (use - for stdin)")
+	}
+
+	req := dto.NewsImportRequest{Format: *format, DryRun: *dryRun}
+	if req.Format != dto.ArchiveFormatNDJSON && req.Format != dto.ArchiveFormatCSV {
+		return fmt.Errorf("news import: unknown format %q", req.Format)
+	}
+
+	var (
+		input io.Reader = os.Stdin
+		size  int64
+	)
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		input, size = file, info.Size()
+	}
+
+	newsService, txManager, err := newNewsService(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	repo := postgres.NewImportRepository(txManager.GetDatabase())
+	importService := service.NewImportService(newsService, repo, txManager, nil, cfg.Import)
+
+	resp, err := importService.ImportNews(ctx, req, input, size)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(resp); err != nil {
+		return err
+	}
+
+	if resp.Status != string(models.ImportSucceeded) {
+		return fmt.Errorf("import failed: %s", resp.Error)
+	}
+	if resp.Failed > 0 {
+		return fmt.Errorf("%d of %d records failed", resp.Failed, resp.Processed)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+)
+
+var (
+	seedCategories = []string{"politics", "economy", "sport", "science", "culture", "technology"}
+	seedSubjects   = []string{"Городской совет", "Сборная", "Исследователи", "Министерство", "Стартап", "Музей"}
+	seedVerbs      = []string{"представил", "обсудил", "отложил", "запустил", "поддержал", "пересмотрел"}
+	seedObjects    = []string{"новый проект", "бюджет на год", "итоги сезона", "план реформы", "выставку", "открытые данные"}
+	seedWords      = strings.Fields("сегодня вчера город страна проект решение работа данные год время " +
+		"вопрос встреча итог рост план участники эксперты результат система развитие")
+)
+
+// runSeed создаёт случайные новости для локальной разработки. С одинаковым
+// -seed генерируются одинаковые новости.
+func runSeed(ctx context.Context, cfg *config.Config, args []string) error {
+	flags := flag.NewFlagSet("seed", flag.ExitOnError)
+	count := flags.Int("count", 50, "how many news to create")
+	seed := flags.Uint64("seed", 0, "random seed, 0 picks one from the clock")
+	_ = flags.Parse(args)
+
+	if *seed == 0 {
+		*seed = uint64(time.Now().UnixNano())
+	}
+	rnd := rand.New(rand.NewPCG(*seed, *seed))
+
+	newsService, _, err := newNewsService(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	for i := range *count {
+		news, err := newsService.CreateNews(ctx, fakeNews(rnd, time.Now()))
+		if err != nil {
+			return fmt.Errorf("news %d of %d: %w", i+1, *count, err)
+		}
+		fmt.Printf("created news %s %q\n", news.ID, news.Title)
+	}
+	fmt.Printf("%d news created with seed %d\n", *count, *seed)
+	return nil
+}
+
+// fakeNews возвращает новость из нескольких абзацев и ссылки. Около трети новостей
+// уже закончились, остальные видны сейчас или появятся в ближайшие дни.
+func fakeNews(rnd *rand.Rand, now time.Time) dto.CreateNewsRequest {
+	start := now.Add(time.Duration(rnd.IntN(14*24)-10*24) * time.Hour)
+	end := start.Add(time.Duration(1+rnd.IntN(14*24)) * time.Hour)
+
+	req := dto.CreateNewsRequest{
+		Title:     fmt.Sprintf("%s %s %s", pick(rnd, seedSubjects), pick(rnd, seedVerbs), pick(rnd, seedObjects)),
+		Category:  pick(rnd, seedCategories),
+		Live:      rnd.IntN(10) == 0,
+		StartTime: start,
+		EndTime:   end,
+	}
+
+	paragraphs := 1 + rnd.IntN(4)
+	for i := range paragraphs {
+		req.Content = append(req.Content, dto.CreateContentBlock{
+			Type:     "text",
+			Content:  fakeParagraph(rnd),
+			Position: i + 1,
+		})
+	}
+	req.Content = append(req.Content, dto.CreateContentBlock{
+		Type:     "link",
+		Content:  fmt.Sprintf("https://example.com/%s/%d", req.Category, rnd.IntN(100000)),
+		Position: paragraphs + 1,
+	})
+	return req
+}
+
+func fakeParagraph(rnd *rand.Rand) string {
+	words := make([]string, 20+rnd.IntN(40))
+	for i := range words {
+		words[i] = pick(rnd, seedWords)
+	}
+	first, size := utf8.DecodeRuneInString(words[0])
+	words[0] = string(unicode.ToUpper(first)) + words[0][size:]
+	return strings.Join(words, " ") + "."
+}
+
+func pick(rnd *rand.Rand, values []string) string {
+	return values[rnd.IntN(len(values))]
+}
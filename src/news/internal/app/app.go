@@ -2,9 +2,13 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
+	grpcapp "github.com/zhavkk/news-service/src/news/internal/app/grpc"
 	httpapp "github.com/zhavkk/news-service/src/news/internal/app/http"
 	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/events"
 	"github.com/zhavkk/news-service/src/news/internal/logger"
 	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
 	"github.com/zhavkk/news-service/src/news/internal/service"
@@ -12,15 +16,42 @@ import (
 )
 
 type App struct {
-	HTTPServer *httpapp.HTTPApp
+	HTTPServer  *httpapp.HTTPApp
+	GRPCServer  *grpcapp.GRPCApp
+	Scheduler   *service.Scheduler
+	OutboxRelay *service.OutboxRelay
+	Webhooks    *service.WebhookDispatcher
+	NewsStream  *service.NewsStream
+	Jobs        *service.JobQueue
+
+	db    *storage.Storage
+	redis *storage.RedisClient
 }
 
-func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
+// NewApp собирает приложение и запускает фоновые воркеры. Воркеры запускаются
+// последним шагом, поэтому при ошибке инициализации не остаётся ни запущенных
+// горутин, ни открытых соединений.
+func NewApp(ctx context.Context, cfg *config.Config) (_ *App, err error) {
 	logger.Log.Info("Initializing application with config", "env", cfg.Env, "port", cfg.HTTP.Port)
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	txManager, err := storage.NewTxManager(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			_ = txManager.GetDatabase().Close()
+		}
+	}()
+
+	migrator, err := migrateSchema(ctx, cfg.Migrations, txManager.GetDatabase())
+	if err != nil {
+		logger.Log.Error("Failed to migrate database schema", "error", err)
+		return nil, err
+	}
 
 	newsRepo := postgres.NewNewsRepository(txManager.GetDatabase())
 
@@ -29,13 +60,143 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 		logger.Log.Error("Failed to initialize Redis client", "error", err)
 		return nil, err
 	}
+	defer func() {
+		if err != nil {
+			_ = redis.Close()
+		}
+	}()
+
+	mediaFiles, err := storage.NewMediaStorage(ctx, &cfg.Media)
+	if err != nil {
+		logger.Log.Error("Failed to initialize media storage", "error", err)
+		return nil, err
+	}
+
+	mediaRepo := postgres.NewMediaRepository(txManager.GetDatabase())
+
+	// Очередь создаётся до сервисов, которые ставят в неё задачи; обработчики
+	// регистрируются до запуска воркеров.
+	jobQueue, err := service.NewJobQueue(postgres.NewJobRepository(txManager.GetDatabase()), txManager, cfg.Jobs)
+	if err != nil {
+		logger.Log.Error("Failed to initialize job queue", "error", err)
+		return nil, err
+	}
+
+	outboxRepo := postgres.NewOutboxRepository(txManager.GetDatabase())
+
+	eventSink, eventFeed, err := newEventBus(cfg.Outbox, redis)
+	if err != nil {
+		logger.Log.Error("Failed to initialize event sink", "error", err)
+		return nil, err
+	}
+	newsStream := service.NewNewsStream(eventFeed, cfg.Stream)
+
+	webhookRepo := postgres.NewWebhookRepository(txManager.GetDatabase())
+	webhookService := service.NewWebhookService(webhookRepo, txManager)
+	webhookDispatcher := service.NewWebhookDispatcher(webhookRepo, txManager, cfg.Webhooks)
+
+	// Вебхуки идут первыми: их доставки создаются в транзакции relay и дедуплицируются,
+	// поэтому повтор пачки после ошибки внешнего приёмника безопасен.
+	outboxRelay := service.NewOutboxRelay(outboxRepo, txManager, events.MultiSink{webhookService, eventSink}, cfg.Outbox)
+
+	newsService := service.NewNewsService(newsRepo, mediaRepo, outboxRepo, jobQueue, txManager, redis, cfg.Redis.CacheTTL)
+	liveBlogService := service.NewLiveBlogService(postgres.NewLiveEntryRepository(txManager.GetDatabase()), mediaRepo, outboxRepo, jobQueue, txManager)
+	mediaService := service.NewMediaService(mediaRepo, txManager, mediaFiles, jobQueue, &cfg.Media)
+	feedService := service.NewFeedService(newsRepo, txManager, redis, cfg.Site, cfg.Feeds)
+	exportService := service.NewExportService(newsRepo, txManager, cfg.Export)
+	sitemapService := service.NewSitemapService(postgres.NewSitemapRepository(txManager.GetDatabase()), cfg.Site)
+
+	importService := service.NewImportService(newsService, postgres.NewImportRepository(txManager.GetDatabase()), txManager, jobQueue, cfg.Import)
+	variantGenerator := service.NewVariantGenerator(mediaRepo, txManager, mediaFiles, redis, &cfg.Media.Variants)
+	linkChecker := service.NewLinkChecker(newsRepo, &http.Client{})
+
+	jobQueue.Register(service.JobKindNewsImport, importService.RunImportJob)
+	jobQueue.Register(service.JobKindMediaVariants, variantGenerator.RunVariantsJob)
+	jobQueue.Register(service.JobKindNewsPurgeExpired, newsService.RunPurgeExpiredJob)
+	jobQueue.Register(service.JobKindNewsReindex, newsService.RunReindexJob)
+	jobQueue.Register(service.JobKindNewsLinkCheck, linkChecker.RunLinkCheckJob)
+
+	var scheduler *service.Scheduler
+	if cfg.Scheduler.Enabled {
+		scheduleRepo := postgres.NewScheduleRepository(txManager.GetDatabase())
+		scheduler = service.NewScheduler(scheduleRepo, outboxRepo, txManager, txManager.GetDatabase(), redis, cfg.Scheduler)
+	}
 
-	newsService := service.NewNewsService(newsRepo, txManager, redis, cfg.Redis.CacheTTL)
+	httpServer := httpapp.New(cfg, httpapp.Services{
+		News:     newsService,
+		Media:    mediaService,
+		Feeds:    feedService,
+		Sitemaps: sitemapService,
+		Webhooks: webhookService,
+		Stream:   newsStream,
+		Export:   exportService,
+		Import:   importService,
+		Jobs:     jobQueue,
+		LiveBlog: liveBlogService,
+		GraphQL:  newsService,
+		Health:   service.NewHealthService(txManager.GetDatabase().GetPool(), redis, migrator),
+	})
+	grpcServer := grpcapp.New(cfg, newsService)
 
-	httpServer := httpapp.New(cfg, newsService)
+	a := &App{
+		HTTPServer:  httpServer,
+		GRPCServer:  grpcServer,
+		Scheduler:   scheduler,
+		OutboxRelay: outboxRelay,
+		Webhooks:    webhookDispatcher,
+		NewsStream:  newsStream,
+		Jobs:        jobQueue,
+		db:          txManager.GetDatabase(),
+		redis:       redis,
+	}
+	a.start(ctx)
 	logger.Log.Info("Application initialized successfully", "env", cfg.Env, "port", cfg.HTTP.Port)
 
-	return &App{
-		HTTPServer: httpServer,
-	}, nil
+	return a, nil
+}
+
+// start запускает фоновые воркеры. Живая лента запускается до relay, чтобы
+// не пропустить первые события.
+func (a *App) start(ctx context.Context) {
+	a.NewsStream.Start(ctx)
+	a.Webhooks.Start(ctx)
+	a.OutboxRelay.Start(ctx)
+	a.Jobs.Start(ctx)
+	if a.Scheduler != nil {
+		a.Scheduler.Start(ctx)
+	}
+}
+
+// newEventBus возвращает приёмник для outbox relay и источник для живой ленты —
+// это один и тот же Redis Stream или шина в памяти.
+func newEventBus(cfg config.OutboxConfig, redis *storage.RedisClient) (service.EventSink, service.EventFeed, error) {
+	switch cfg.Sink {
+	case "redis":
+		stream := events.NewRedisStream(redis.GetRedis(), cfg.Stream, cfg.StreamMaxLen)
+		return stream, stream, nil
+	case "memory":
+		bus := events.NewMemoryBus()
+		return bus, bus, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown outbox sink %q", cfg.Sink)
+	}
+}
+
+// Stop останавливает HTTP- и gRPC-серверы, затем фоновые воркеры и закрывает
+// соединения с базой и Redis. Живая лента закрывается первой, чтобы открытые
+// потоки не держали HTTP-сервер. Очередь задач дожидается выполняющихся задач
+// не дольше jobs.drain_timeout.
+func (a *App) Stop(ctx context.Context) error {
+	a.NewsStream.Stop()
+	err := a.HTTPServer.Stop(ctx)
+	a.GRPCServer.Stop(ctx)
+	if a.Scheduler != nil {
+		a.Scheduler.Stop()
+	}
+	a.OutboxRelay.Stop()
+	a.Webhooks.Stop()
+	a.Jobs.Stop()
+	_ = a.redis.Close()
+	_ = a.db.Close()
+	return err
 }
@@ -0,0 +1,79 @@
+package grpcapp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	newsv1 "github.com/zhavkk/news-service/src/news/api/news/v1"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	grpchandlers "github.com/zhavkk/news-service/src/news/internal/handlers/grpc"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type GRPCApp struct {
+	server *grpc.Server
+	health *health.Server
+	port   int
+
+	shutdownTimeout time.Duration
+}
+
+func New(cfg *config.Config, newsService grpchandlers.NewsService) *GRPCApp {
+	server := grpc.NewServer()
+
+	grpchandlers.NewNewsServer(newsService).Register(server)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(newsv1.NewsService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	if cfg.GRPC.Reflection {
+		reflection.Register(server)
+	}
+
+	return &GRPCApp{
+		server: server,
+		health: healthServer,
+		port:   cfg.GRPC.Port,
+
+		shutdownTimeout: cfg.GRPC.ShutdownTimeout,
+	}
+}
+
+func (a *GRPCApp) Start() error {
+	logger.Log.Info("Starting gRPC server", "port", a.port)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", a.port))
+	if err != nil {
+		return err
+	}
+	return a.server.Serve(lis)
+}
+
+// Stop сначала переводит health-проверку в NOT_SERVING, чтобы балансировщик
+// перестал слать запросы, затем дожидается текущих вызовов. Вызовы и потоки,
+// не завершившиеся за shutdown_timeout (или до отмены ctx), обрываются.
+func (a *GRPCApp) Stop(ctx context.Context) {
+	logger.Log.Info("Stopping gRPC server", "port", a.port)
+	a.health.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		a.server.Stop()
+	case <-time.After(a.shutdownTimeout):
+		a.server.Stop()
+	}
+}
@@ -2,15 +2,23 @@ package httpapp
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swagger "github.com/swaggo/fiber-swagger"
+	"github.com/valyala/fasthttp"
 	_ "github.com/zhavkk/news-service/src/news/docs"
 	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
 
+	graphqlhandlers "github.com/zhavkk/news-service/src/news/internal/handlers/graphql"
 	v1 "github.com/zhavkk/news-service/src/news/internal/handlers/v1"
 	"github.com/zhavkk/news-service/src/news/internal/logger"
 )
@@ -20,18 +28,53 @@ type HTTPApp struct {
 	port     int
 }
 
-func New(cfg *config.Config, newsService v1.NewsService) *HTTPApp {
+// multipartOverhead — запас BodyLimit на заголовки и границы multipart-формы.
+const multipartOverhead = 1 << 20
+
+// importPath — загрузка файла импорта. Её тело читается потоком с собственными
+// пределом размера и таймаутом чтения, остальные запросы ограничены BodyLimit.
+const importPath = "/api/v1/news/import"
+
+// Services — сервисы, которые обслуживает HTTP API.
+type Services struct {
+	News     v1.NewsService
+	Media    v1.MediaService
+	Feeds    v1.FeedService
+	Sitemaps v1.SitemapService
+	Webhooks v1.WebhookService
+	Stream   v1.StreamService
+	Export   v1.ExportService
+	Import   v1.ImportService
+	Jobs     v1.JobService
+	LiveBlog v1.LiveBlogService
+	GraphQL  graphqlhandlers.NewsService
+	Health   v1.HealthService
+}
+
+func New(cfg *config.Config, services Services) *HTTPApp {
 
+	bodyLimit := int(cfg.Media.MaxUploadSize) + multipartOverhead
 	app := fiber.New(fiber.Config{
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 5 * time.Second,
-		IdleTimeout:  120 * time.Second,
-		AppName:      "News Service",
+		ReadTimeout:                  5 * time.Second,
+		WriteTimeout:                 5 * time.Second,
+		IdleTimeout:                  120 * time.Second,
+		BodyLimit:                    bodyLimit,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+		AppName:                      "News Service",
 	})
+	// Таймаут чтения задаётся после заголовков, поэтому файл импорта можно
+	// загружать дольше ReadTimeout.
+	app.Server().HeaderReceived = func(header *fasthttp.RequestHeader) fasthttp.RequestConfig {
+		if isImportUpload(string(header.Method()), string(header.RequestURI())) {
+			return fasthttp.RequestConfig{ReadTimeout: cfg.Import.ReadTimeout}
+		}
+		return fasthttp.RequestConfig{}
+	}
 
-	setupMiddlewares(app)
+	setupMiddlewares(app, bodyLimit)
 
-	setupRoutes(app, newsService)
+	setupRoutes(app, cfg, services)
 	return &HTTPApp{
 		fiberApp: app,
 		port:     cfg.HTTP.Port,
@@ -52,19 +95,117 @@ func (a *HTTPApp) App() *fiber.App {
 	return a.fiberApp
 }
 
-func setupMiddlewares(app *fiber.App) {
+func setupMiddlewares(app *fiber.App, bodyLimit int) {
 	app.Use(requestid.New())
+	app.Use(limitBody(bodyLimit))
 
 	// cors and etc
 
 }
 
-func setupRoutes(app *fiber.App, newsService v1.NewsService) {
+func setupRoutes(app *fiber.App, cfg *config.Config, services Services) {
 	app.Get("/swagger/*", swagger.WrapHandler)
+	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+
+	healthHandler := v1.NewHealthHandler(services.Health)
+	healthHandler.RegisterRoutes(app)
 
 	api := app.Group("/api")
 	v1Group := api.Group("/v1")
 
-	newsHandler := v1.NewHandler(newsService)
+	streamHandler := v1.NewStreamHandler(services.Stream)
+	streamHandler.RegisterRoutes(v1Group)
+
+	wsHandler := v1.NewWebSocketHandler(services.Stream, cfg.WebSocket)
+	wsHandler.RegisterRoutes(v1Group)
+
+	exportHandler := v1.NewExportHandler(services.Export, cfg.Export.WriteTimeout)
+	exportHandler.RegisterRoutes(v1Group)
+
+	importHandler := v1.NewImportHandler(services.Import)
+	importHandler.RegisterRoutes(v1Group)
+
+	newsHandler := v1.NewHandler(services.News)
 	newsHandler.RegisterRoutes(v1Group)
+
+	liveBlogHandler := v1.NewLiveBlogHandler(services.LiveBlog)
+	liveBlogHandler.RegisterRoutes(v1Group)
+
+	mediaHandler := v1.NewMediaHandler(services.Media)
+	mediaHandler.RegisterRoutes(v1Group)
+	mediaHandler.RegisterFileRoutes(app)
+
+	feedHandler := v1.NewFeedHandler(services.Feeds)
+	feedHandler.RegisterRoutes(app)
+
+	sitemapHandler := v1.NewSitemapHandler(services.Sitemaps)
+	sitemapHandler.RegisterRoutes(app)
+
+	graphqlHandler := graphqlhandlers.NewGraphQLHandler(services.GraphQL, cfg.GraphQL)
+	graphqlHandler.RegisterRoutes(app)
+
+	admin := v1Group.Group("/admin", adminAuth(cfg.Admin.Token))
+
+	webhookHandler := v1.NewWebhookHandler(services.Webhooks)
+	webhookHandler.RegisterRoutes(admin)
+
+	jobHandler := v1.NewJobHandler(services.Jobs)
+	jobHandler.RegisterRoutes(admin)
+}
+
+// adminAuth проверяет bearer-токен администратора. Без настроенного токена
+// админский API открыт — так удобнее локально, в проде ADMIN_TOKEN обязателен.
+func adminAuth(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Next()
+		}
+
+		got, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   "invalid or missing admin token",
+			})
+		}
+
+		return c.Next()
+	}
+}
+
+// limitBody дочитывает тело запроса, не превышающее limit, и отвечает 413 на
+// большее. С StreamRequestBody fasthttp сам предел не проверяет; тело импорта
+// остаётся потоком, его размер проверяет сервис импорта.
+func limitBody(limit int) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if isImportUpload(c.Method(), c.OriginalURL()) {
+			return c.Next()
+		}
+		// Недочитанное тело осталось бы в соединении вместо следующего запроса.
+		if c.Request().Header.ContentLength() > limit {
+			c.Context().SetConnectionClose()
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		if stream := c.Context().RequestBodyStream(); stream != nil {
+			body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+			if err != nil {
+				c.Context().SetConnectionClose()
+				return fiber.ErrBadRequest
+			}
+			if len(body) > limit {
+				c.Context().SetConnectionClose()
+				return fiber.ErrRequestEntityTooLarge
+			}
+			c.Request().SetBody(body)
+		}
+
+		return c.Next()
+	}
+}
+
+func isImportUpload(method, uri string) bool {
+	path, _, _ := strings.Cut(uri, "?")
+	return method == fiber.MethodPost && strings.EqualFold(strings.TrimSuffix(path, "/"), importPath)
 }
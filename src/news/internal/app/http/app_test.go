@@ -0,0 +1,92 @@
+package httpapp_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	httpapp "github.com/zhavkk/news-service/src/news/internal/app/http"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	v1 "github.com/zhavkk/news-service/src/news/internal/handlers/v1"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+// fakeImportService запоминает, сколько байт файла импорта дочитано из запроса.
+type fakeImportService struct {
+	read int
+	size int64
+}
+
+func (s *fakeImportService) StartImport(ctx context.Context, req dto.NewsImportRequest, r io.Reader, size int64) (*dto.ImportJobResponse, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	s.read, s.size = len(data), size
+	return &dto.ImportJobResponse{ID: "1", Status: "pending"}, nil
+}
+
+func (s *fakeImportService) GetImportJob(ctx context.Context, req dto.ImportJobIDRequest) (*dto.ImportJobResponse, error) {
+	return nil, nil
+}
+
+// fakeMediaService запоминает размер загруженного файла.
+type fakeMediaService struct {
+	v1.MediaService
+	size int64
+}
+
+func (s *fakeMediaService) UploadMedia(ctx context.Context, req dto.UploadMediaRequest) (*dto.MediaResponse, error) {
+	s.size = req.Size
+	return &dto.MediaResponse{}, nil
+}
+
+// Файл импорта не ограничен media.max_upload_size, остальные тела — ограничены.
+func TestHTTPApp_ImportBodyLimit(t *testing.T) {
+	logger.Init("local")
+
+	imports := &fakeImportService{}
+	media := &fakeMediaService{}
+	cfg := &config.Config{
+		Media:  config.MediaConfig{MaxUploadSize: 1 << 10},
+		Import: config.ImportConfig{MaxFileSize: 1 << 30, ReadTimeout: time.Minute},
+	}
+	app := httpapp.New(cfg, httpapp.Services{Import: imports, Media: media}).App()
+
+	file := bytes.Repeat([]byte("{}\n"), 1<<20)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/news/import?format=ndjson", bytes.NewReader(file))
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, len(file), imports.read)
+	assert.Equal(t, int64(len(file)), imports.size)
+
+	// Тела остальных запросов по-прежнему дочитываются, в том числе multipart.
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("file", "photo.png")
+	require.NoError(t, err)
+	_, err = part.Write(file[:512])
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/media", &form)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	resp, err = app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int64(512), media.size)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/news", bytes.NewReader(file))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = app.Test(req, -1)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/migrate"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+	"github.com/zhavkk/news-service/src/news/migrations"
+)
+
+// migrateSchema при включённом AutoApply применяет встроенные миграции и в любом
+// случае проверяет, что схема базы не новее бинарника: старая версия сервиса
+// может испортить данные, записанные по новой схеме.
+func migrateSchema(ctx context.Context, cfg config.MigrationsConfig, db *storage.Storage) (*migrate.Migrator, error) {
+	const op = "app.migrateSchema"
+
+	migrator, err := migrate.New(db.GetPool(), migrations.Postgres, "postgres", cfg.LockTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.AutoApply {
+		// Реплики применяют миграции по очереди: Up ждёт блокировку migrate.LockKey.
+		applied, err := migrator.Up(ctx)
+		for _, migration := range applied {
+			logger.Log.Info(op, "Migration applied", migration.Version, "name", migration.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state, err := migrator.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.TooNew() {
+		return nil, fmt.Errorf("%w: applied %d, known %d", migrate.ErrSchemaTooNew, state.Current, state.Latest)
+	}
+	if state.Pending > 0 {
+		logger.Log.Warn(op, "Database has pending migrations", state.Pending, "current", state.Current, "latest", state.Latest)
+	}
+	return migrator, nil
+}
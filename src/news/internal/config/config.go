@@ -12,8 +12,26 @@ import (
 type Config struct {
 	Env   string      `yaml:"env" env:"ENV" env-default:"local"`
 	HTTP  HTTPConfig  `yaml:"http"`
+	GRPC  GRPCConfig  `yaml:"grpc"`
 	DBURL string      `yaml:"db_url"`
 	Redis RedisConfig `yaml:"redis"`
+	Media MediaConfig `yaml:"media"`
+	Site  SiteConfig  `yaml:"site"`
+	Feeds FeedsConfig `yaml:"feeds"`
+
+	Replicas   ReplicasConfig   `yaml:"replicas"`
+	Tx         TxConfig         `yaml:"transactions"`
+	Migrations MigrationsConfig `yaml:"migrations"`
+	Scheduler  SchedulerConfig  `yaml:"scheduler"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	Webhooks   WebhooksConfig   `yaml:"webhooks"`
+	Admin      AdminConfig      `yaml:"admin"`
+	Stream     StreamConfig     `yaml:"stream"`
+	WebSocket  WebSocketConfig  `yaml:"websocket"`
+	GraphQL    GraphQLConfig    `yaml:"graphql"`
+	Export     ExportConfig     `yaml:"export"`
+	Import     ImportConfig     `yaml:"import"`
+	Jobs       JobsConfig       `yaml:"jobs"`
 }
 
 type HTTPConfig struct {
@@ -23,6 +41,178 @@ type HTTPConfig struct {
 	IdleTimeout  time.Duration `yaml:"idle_timeout" env-default:"120s"`
 }
 
+type GRPCConfig struct {
+	Port int `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+	// Reflection включает сервис рефлексии для grpcurl и подобных клиентов.
+	Reflection bool `yaml:"reflection" env-default:"true"`
+	// ShutdownTimeout — сколько ждать текущих вызовов при остановке.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env-default:"10s"`
+}
+
+type SiteConfig struct {
+	Title       string `yaml:"title" env-default:"News Service"`
+	Description string `yaml:"description" env-default:"Latest news"`
+	BaseURL     string `yaml:"base_url" env:"SITE_BASE_URL" env-default:"http://localhost:8080"`
+	// NewsPath — шаблон пути страницы новости относительно BaseURL,
+	// {id} и {slug} заменяются на id и slug новости.
+	NewsPath string `yaml:"news_path" env-default:"/api/v1/news/{id}"`
+	Language string `yaml:"language" env-default:"ru"`
+}
+
+type FeedsConfig struct {
+	Limit    int           `yaml:"limit" env-default:"50"`
+	CacheTTL time.Duration `yaml:"cache_ttl" env-default:"10m"`
+}
+
+type ReplicasConfig struct {
+	// URLs — строки подключения к репликам Postgres для чтения. Без реплик все
+	// запросы идут в основную базу.
+	URLs []string `yaml:"urls" env:"DB_REPLICA_URLS" env-separator:","`
+	// MaxLag — реплика, отставшая больше чем на MaxLag, не используется до
+	// следующей проверки.
+	MaxLag        time.Duration `yaml:"max_lag" env-default:"5s"`
+	CheckInterval time.Duration `yaml:"check_interval" env-default:"1s"`
+}
+
+// TxConfig — повторы транзакций, прерванных конфликтом сериализации (40001),
+// взаимоблокировкой (40P01) или обрывом соединения до COMMIT.
+type TxConfig struct {
+	// MaxAttempts — сколько раз выполнять транзакцию, 1 отключает повторы.
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"5"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"10ms"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1s"`
+}
+
+type MigrationsConfig struct {
+	// AutoApply — применять встроенные миграции при запуске (по умолчанию включено,
+	// образ больше не мигрирует базу в entrypoint). Реплики применяют их по очереди
+	// под advisory-блокировкой.
+	AutoApply bool `yaml:"auto_apply" env:"MIGRATIONS_AUTO_APPLY" env-default:"true"`
+	// LockTimeout — сколько ждать блокировку, пока миграции применяет другая реплика.
+	LockTimeout time.Duration `yaml:"lock_timeout" env-default:"5m"`
+}
+
+type SchedulerConfig struct {
+	Enabled bool `yaml:"enabled" env:"SCHEDULER_ENABLED" env-default:"true"`
+	// MaxSleep ограничивает ожидание следующей границы: новости, созданные
+	// на других репликах, планировщик увидит не позже чем через MaxSleep.
+	MaxSleep   time.Duration `yaml:"max_sleep" env-default:"30s"`
+	LockRetry  time.Duration `yaml:"lock_retry" env-default:"15s"`
+	MaxCatchUp time.Duration `yaml:"max_catch_up" env-default:"24h"`
+}
+
+type OutboxConfig struct {
+	// Sink — куда relay публикует события: redis (Redis Streams) или memory.
+	Sink         string        `yaml:"sink" env:"OUTBOX_SINK" env-default:"redis"`
+	Stream       string        `yaml:"stream" env-default:"news:events"`
+	StreamMaxLen int64         `yaml:"stream_max_len" env-default:"100000"`
+	BatchSize    int           `yaml:"batch_size" env-default:"100"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"10"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"1s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"5m"`
+	// Retention — сколько хранить доставленные события, 0 — не удалять.
+	Retention time.Duration `yaml:"retention" env-default:"168h"`
+}
+
+type WebhooksConfig struct {
+	Workers int `yaml:"workers" env-default:"4"`
+	// BatchSize — сколько доставок отправляется за один проход; забираются они
+	// по workers штук.
+	BatchSize    int           `yaml:"batch_size" env-default:"50"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	Timeout      time.Duration `yaml:"timeout" env-default:"10s"`
+	// Lease — на сколько доставка скрывается от других воркеров на время отправки.
+	Lease        time.Duration `yaml:"lease" env-default:"1m"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"8"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"10s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1h"`
+}
+
+type StreamConfig struct {
+	// ReplaySize — сколько последних событий хранится для догона по Last-Event-ID.
+	ReplaySize int `yaml:"replay_size" env-default:"1000"`
+	// BufferSize — очередь событий одного клиента; переполнивший её клиент отключается.
+	BufferSize int           `yaml:"buffer_size" env-default:"64"`
+	Heartbeat  time.Duration `yaml:"heartbeat" env-default:"15s"`
+	Retry      time.Duration `yaml:"retry" env-default:"3s"`
+}
+
+type WebSocketConfig struct {
+	// Token — токен клиентов WebSocket. Пустой токен отключает проверку.
+	Token string `yaml:"token" env:"WS_TOKEN"`
+	// SlowConsumer — что делать с клиентом, не успевающим читать: drop пропускает
+	// события, disconnect отключает его.
+	SlowConsumer   string        `yaml:"slow_consumer" env-default:"drop"`
+	MaxTopics      int           `yaml:"max_topics" env-default:"100"`
+	MaxMessageSize int64         `yaml:"max_message_size" env-default:"4096"`
+	PingInterval   time.Duration `yaml:"ping_interval" env-default:"30s"`
+}
+
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth" env-default:"8"`
+	MaxComplexity int `yaml:"max_complexity" env-default:"5000"`
+	// MaxQueryLength — максимальная длина текста запроса в байтах.
+	MaxQueryLength int  `yaml:"max_query_length" env-default:"16384"`
+	Introspection  bool `yaml:"introspection" env:"GRAPHQL_INTROSPECTION" env-default:"true"`
+}
+
+type ExportConfig struct {
+	// FetchSize — сколько строк читается из курсора Postgres за один FETCH.
+	FetchSize int `yaml:"fetch_size" env-default:"500"`
+	// WriteTimeout — дедлайн записи очередной строки клиенту; медленный клиент
+	// отключается, чтобы не держать транзакцию бесконечно.
+	WriteTimeout time.Duration `yaml:"write_timeout" env-default:"30s"`
+}
+
+type ImportConfig struct {
+	// ChunkSize — сколько записей файла сохраняется в одной транзакции.
+	ChunkSize int `yaml:"chunk_size" env-default:"100"`
+	// MaxRecordSize — предельный размер одной записи (строки NDJSON) в байтах.
+	MaxRecordSize int `yaml:"max_record_size" env-default:"1048576"`
+	// MaxErrors — сколько ошибок записей сохраняется в отчёт задачи; счётчик
+	// failed учитывает все ошибки.
+	MaxErrors int `yaml:"max_errors" env-default:"1000"`
+	// MaxFileSize — предельный размер файла POST /news/import в байтах. Маршрут
+	// не ограничен media.max_upload_size: тело читается потоком.
+	MaxFileSize int64 `yaml:"max_file_size" env-default:"1073741824"`
+	// ReadTimeout — сколько можно загружать файл импорта вместо общего таймаута
+	// чтения HTTP-сервера.
+	ReadTimeout time.Duration `yaml:"read_timeout" env-default:"10m"`
+}
+
+type JobsConfig struct {
+	Workers      int           `yaml:"workers" env-default:"4"`
+	PollInterval time.Duration `yaml:"poll_interval" env-default:"1s"`
+	// Lease — сколько задача может выполняться. По истечении её контекст отменяется,
+	// а задачу, чей воркер пропал, забирает другой воркер.
+	Lease        time.Duration `yaml:"lease" env-default:"10m"`
+	MaxAttempts  int           `yaml:"max_attempts" env-default:"5"`
+	RetryBackoff time.Duration `yaml:"retry_backoff" env-default:"10s"`
+	MaxBackoff   time.Duration `yaml:"max_backoff" env-default:"1h"`
+	// DrainTimeout — сколько при остановке ждать выполняющиеся задачи, прежде чем
+	// отменить их контекст.
+	DrainTimeout time.Duration   `yaml:"drain_timeout" env-default:"30s"`
+	Cron         []CronJobConfig `yaml:"cron"`
+	// RetiredCron — имена расписаний, которые нужно удалить из базы. Расписание,
+	// просто убранное из cron, остаётся: реплики со старым конфигом ещё ставят
+	// по нему задачи.
+	RetiredCron []string `yaml:"retired_cron"`
+}
+
+// CronJobConfig — задача, которая ставится в очередь по расписанию crontab.
+type CronJobConfig struct {
+	Name     string                 `yaml:"name"`
+	Kind     string                 `yaml:"kind"`
+	Schedule string                 `yaml:"schedule"`
+	Payload  map[string]interface{} `yaml:"payload"`
+}
+
+type AdminConfig struct {
+	// Token — bearer-токен для /api/v1/admin. Пустой токен отключает проверку.
+	Token string `yaml:"token" env:"ADMIN_TOKEN"`
+}
+
 type RedisConfig struct {
 	Host         string        `yaml:"host"`
 	Port         int           `yaml:"port"`
@@ -34,6 +224,30 @@ type RedisConfig struct {
 	CacheTTL     time.Duration `yaml:"cache_ttl" env-default:"5m"`
 }
 
+type MediaConfig struct {
+	Backend       string        `yaml:"backend" env:"MEDIA_BACKEND" env-default:"local"`
+	MaxUploadSize int64         `yaml:"max_upload_size" env-default:"10485760"`
+	MaxPixels     int64         `yaml:"max_pixels" env-default:"40000000"`
+	LocalDir      string        `yaml:"local_dir" env-default:"./data/media"`
+	S3            MediaS3Config `yaml:"s3"`
+	Variants      VariantConfig `yaml:"variants"`
+}
+
+type VariantConfig struct {
+	Widths      []int    `yaml:"widths" env-default:"320,640,1280"`
+	Formats     []string `yaml:"formats" env-default:"jpeg,webp"`
+	JPEGQuality int      `yaml:"jpeg_quality" env-default:"82"`
+}
+
+type MediaS3Config struct {
+	Endpoint  string `yaml:"endpoint" env:"MEDIA_S3_ENDPOINT"`
+	Region    string `yaml:"region"`
+	Bucket    string `yaml:"bucket" env:"MEDIA_S3_BUCKET"`
+	AccessKey string `yaml:"access_key" env:"MEDIA_S3_ACCESS_KEY"`
+	SecretKey string `yaml:"secret_key" env:"MEDIA_S3_SECRET_KEY"`
+	UseSSL    bool   `yaml:"use_ssl"`
+}
+
 func (c RedisConfig) RedisAddr() string {
 	return fmt.Sprintf("%s:%d", c.Host, c.Port)
 }
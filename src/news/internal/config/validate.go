@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zhavkk/news-service/src/news/internal/cron"
+)
+
+// Validate проверяет то, что не выражается тегами cleanenv: обязательные поля,
+// допустимые значения перечислений и расписания cron. Возвращает все найденные
+// ошибки сразу.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.DBURL == "" {
+		errs = append(errs, errors.New("db_url is required"))
+	}
+	if c.Redis.Host == "" {
+		errs = append(errs, errors.New("redis.host is required"))
+	}
+	for name, port := range map[string]int{"http.port": c.HTTP.Port, "grpc.port": c.GRPC.Port, "redis.port": c.Redis.Port} {
+		if port <= 0 || port > 65535 {
+			errs = append(errs, fmt.Errorf("%s %d is out of range", name, port))
+		}
+	}
+
+	if len(c.Replicas.URLs) > 0 && (c.Replicas.MaxLag <= 0 || c.Replicas.CheckInterval <= 0) {
+		errs = append(errs, errors.New("replicas.max_lag and replicas.check_interval must be positive"))
+	}
+
+	switch c.Outbox.Sink {
+	case "redis", "memory":
+	default:
+		errs = append(errs, fmt.Errorf("unknown outbox sink %q", c.Outbox.Sink))
+	}
+	switch c.WebSocket.SlowConsumer {
+	case "drop", "disconnect":
+	default:
+		errs = append(errs, fmt.Errorf("unknown websocket slow_consumer policy %q", c.WebSocket.SlowConsumer))
+	}
+	switch c.Media.Backend {
+	case "local", "":
+	case "s3":
+		if c.Media.S3.Bucket == "" {
+			errs = append(errs, errors.New("media.s3.bucket is required for the s3 backend"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown media backend %q", c.Media.Backend))
+	}
+	if c.Media.MaxUploadSize <= 0 || c.Media.MaxPixels <= 0 {
+		errs = append(errs, errors.New("media.max_upload_size and media.max_pixels must be positive"))
+	}
+	if c.Import.MaxFileSize <= 0 || c.Import.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("import.max_file_size and import.read_timeout must be positive"))
+	}
+	if c.Export.FetchSize <= 0 {
+		errs = append(errs, fmt.Errorf("export.fetch_size %d must be positive", c.Export.FetchSize))
+	}
+
+	names := make(map[string]bool, len(c.Jobs.Cron))
+	for _, entry := range c.Jobs.Cron {
+		if entry.Name == "" || entry.Kind == "" {
+			errs = append(errs, fmt.Errorf("cron job %q: name and kind are required", entry.Name))
+		}
+		if names[entry.Name] {
+			errs = append(errs, fmt.Errorf("cron job %q is defined twice", entry.Name))
+		}
+		names[entry.Name] = true
+		if _, err := cron.Parse(entry.Schedule); err != nil {
+			errs = append(errs, fmt.Errorf("cron job %q: %w", entry.Name, err))
+		}
+	}
+	for _, name := range c.Jobs.RetiredCron {
+		if names[name] {
+			errs = append(errs, fmt.Errorf("cron job %q is both scheduled and retired", name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
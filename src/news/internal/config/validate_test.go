@@ -0,0 +1,52 @@
+package config_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *config.Config {
+		return &config.Config{
+			DBURL:     "postgres://localhost/news",
+			HTTP:      config.HTTPConfig{Port: 8080},
+			GRPC:      config.GRPCConfig{Port: 9090},
+			Redis:     config.RedisConfig{Host: "localhost", Port: 6379},
+			Outbox:    config.OutboxConfig{Sink: "redis"},
+			WebSocket: config.WebSocketConfig{SlowConsumer: "drop"},
+			Media:     config.MediaConfig{Backend: "local", MaxUploadSize: 1 << 20, MaxPixels: 1 << 24},
+			Export:    config.ExportConfig{FetchSize: 500},
+			Import:    config.ImportConfig{MaxFileSize: 1 << 30, ReadTimeout: time.Minute},
+			Jobs: config.JobsConfig{Cron: []config.CronJobConfig{
+				{Name: "cleanup", Kind: "jobs.cleanup", Schedule: "@daily"},
+			}},
+		}
+	}
+
+	assert.NoError(t, valid().Validate())
+	assert.NoError(t, config.MustLoad("../../config/config.yml").Validate())
+
+	cfg := valid()
+	cfg.DBURL = ""
+	cfg.Outbox.Sink = "kafka"
+	cfg.Media.Backend = "s3"
+	cfg.Media.MaxPixels = 0
+	cfg.Export.FetchSize = 0
+	cfg.Import.ReadTimeout = 0
+	cfg.Jobs.Cron = append(cfg.Jobs.Cron, config.CronJobConfig{Name: "cleanup", Kind: "x", Schedule: "61 * * * *"})
+	cfg.Jobs.RetiredCron = []string{"cleanup"}
+
+	err := cfg.Validate()
+	assert.ErrorContains(t, err, "db_url is required")
+	assert.ErrorContains(t, err, `unknown outbox sink "kafka"`)
+	assert.ErrorContains(t, err, "media.s3.bucket is required")
+	assert.ErrorContains(t, err, "media.max_pixels must be positive")
+	assert.ErrorContains(t, err, "export.fetch_size 0 must be positive")
+	assert.ErrorContains(t, err, "import.max_file_size and import.read_timeout must be positive")
+	assert.ErrorContains(t, err, `cron job "cleanup" is defined twice`)
+	assert.ErrorContains(t, err, `cron job "cleanup": `)
+	assert.ErrorContains(t, err, `cron job "cleanup" is both scheduled and retired`)
+}
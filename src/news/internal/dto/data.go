@@ -1,20 +1,23 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 )
 
 type CreateNewsRequest struct {
 	Title     string               `json:"title" validate:"required,min=3,max=255"`
 	Category  string               `json:"category" validate:"required,min=2,max=100"`
+	Live      bool                 `json:"live"`
 	Content   []CreateContentBlock `json:"content" validate:"required,dive"`
 	StartTime time.Time            `json:"start_time" validate:"required"`
 	EndTime   time.Time            `json:"end_time" validate:"required,gtfield=StartTime"`
 }
 
 type CreateContentBlock struct {
-	Type     string `json:"type" validate:"required,oneof=text link"`
+	Type     string `json:"type" validate:"required,oneof=text link image"`
 	Content  string `json:"content" validate:"required"`
+	MediaID  string `json:"media_id,omitempty" validate:"omitempty,numeric"`
 	Position int    `json:"position" validate:"required,min=0"`
 }
 
@@ -22,6 +25,7 @@ type UpdateNewsRequest struct {
 	ID        string               `json:"id" validate:"required"`
 	Title     string               `json:"title" validate:"omitempty,min=3,max=255"`
 	Category  string               `json:"category" validate:"omitempty,min=2,max=100"`
+	Live      *bool                `json:"live"`
 	Content   []CreateContentBlock `json:"content" validate:"omitempty,dive"`
 	StartTime *time.Time           `json:"start_time" validate:"omitempty"`
 	EndTime   *time.Time           `json:"end_time" validate:"omitempty,gtfield=StartTime"`
@@ -35,6 +39,10 @@ type NewsListRequest struct {
 	SortBy          string `query:"sort_by" default:"created_at" validate:"oneof=created_at start_time end_time title category"`
 	SortDir         string `query:"sort_dir" default:"desc" validate:"oneof=asc desc"`
 	CheckVisibility bool   `query:"check_visibility" default:"true"`
+	// Fields — поля новостей через запятую; пустое значение означает все поля.
+	Fields string `query:"fields" validate:"max=256"`
+	// Include=content добавляет блоки контента к выбранным в Fields полям.
+	Include string `query:"include" validate:"omitempty,oneof=content"`
 }
 
 type NewsListResponse struct {
@@ -42,23 +50,105 @@ type NewsListResponse struct {
 	TotalCount int64          `json:"total_count"`
 	Page       int            `json:"page"`
 	Limit      int            `json:"limit"`
+	// Fields — поля, которые попадут в JSON элементов; пустой список означает все поля.
+	Fields []string `json:"-"`
+}
+
+// MarshalJSON оставляет в элементах только поля из Fields.
+func (r NewsListResponse) MarshalJSON() ([]byte, error) {
+	type plain NewsListResponse
+	if len(r.Fields) == 0 {
+		return json.Marshal(plain(r))
+	}
+
+	items := make([]map[string]interface{}, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = item.project(r.Fields)
+	}
+
+	return json.Marshal(struct {
+		Items      []map[string]interface{} `json:"items"`
+		TotalCount int64                    `json:"total_count"`
+		Page       int                      `json:"page"`
+		Limit      int                      `json:"limit"`
+	}{items, r.TotalCount, r.Page, r.Limit})
+}
+
+// NewsConnectionRequest — постраничный список с курсором вместо номера страницы.
+type NewsConnectionRequest struct {
+	First           int    `validate:"min=1,max=100"`
+	After           string `validate:"max=512"`
+	Search          string
+	Category        string
+	SortBy          string `validate:"oneof=created_at start_time end_time title category"`
+	SortDir         string `validate:"oneof=asc desc"`
+	CheckVisibility bool
+	// IncludeContent загружает блоки контента, IncludeTotal считает общее число новостей.
+	IncludeContent bool
+	IncludeTotal   bool
+}
+
+type NewsConnectionResponse struct {
+	Items       []NewsResponse
+	EndCursor   string
+	HasNextPage bool
+	TotalCount  int64
+}
+
+type CategoryResponse struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
 }
 
 type NewsResponse struct {
 	ID        string                 `json:"id"`
 	Title     string                 `json:"title"`
+	Slug      string                 `json:"slug"`
 	Category  string                 `json:"category"`
+	Live      bool                   `json:"live"`
 	Content   []ContentBlockResponse `json:"content"`
 	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
 	StartTime time.Time              `json:"start_time"`
 	EndTime   time.Time              `json:"end_time"`
 }
 
+func (n NewsResponse) project(fields []string) map[string]interface{} {
+	m := map[string]interface{}{"id": n.ID}
+	for _, field := range fields {
+		switch field {
+		case "title":
+			m[field] = n.Title
+		case "slug":
+			m[field] = n.Slug
+		case "category":
+			m[field] = n.Category
+		case "live":
+			m[field] = n.Live
+		case "content":
+			m[field] = n.Content
+		case "created_at":
+			m[field] = n.CreatedAt
+		case "updated_at":
+			m[field] = n.UpdatedAt
+		case "start_time":
+			m[field] = n.StartTime
+		case "end_time":
+			m[field] = n.EndTime
+		}
+	}
+	return m
+}
+
 type ContentBlockResponse struct {
-	ID       string `json:"id"`
-	Type     string `json:"type"`
-	Content  string `json:"content"`
-	Position int    `json:"position"`
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Content  string                 `json:"content"`
+	MediaID  string                 `json:"media_id,omitempty"`
+	MediaURL string                 `json:"media_url,omitempty"`
+	Variants []MediaVariantResponse `json:"variants,omitempty"`
+	Srcset   string                 `json:"srcset,omitempty"`
+	Position int                    `json:"position"`
 }
 
 type UpdateNewsResponse struct {
@@ -67,11 +157,77 @@ type UpdateNewsResponse struct {
 	Message   string    `json:"message"`
 }
 
+// NewsBatchRequest — до 100 id новостей; повторы допускаются.
+type NewsBatchRequest struct {
+	IDs             []string `json:"ids" validate:"required,min=1,max=100,dive,number"`
+	CheckVisibility bool     `json:"-"`
+}
+
+// NewsBatchItem — результат по одному id: News заполнен, только если Found.
+type NewsBatchItem struct {
+	ID    string        `json:"id"`
+	Found bool          `json:"found"`
+	News  *NewsResponse `json:"news,omitempty"`
+}
+
+// NewsBatchResponse содержит элементы в порядке запрошенных id.
+type NewsBatchResponse struct {
+	Items []NewsBatchItem `json:"items"`
+}
+
+const (
+	BulkModeAtomic     = "atomic"
+	BulkModeBestEffort = "best_effort"
+
+	BulkStatusOK         = "ok"
+	BulkStatusFailed     = "failed"
+	BulkStatusRolledBack = "rolled_back"
+)
+
+// BulkNewsOperation — одна операция пакета: для create заполняется Create,
+// для update — ID и Update, для delete — только ID.
+type BulkNewsOperation struct {
+	Op     string             `json:"op" validate:"required,oneof=create update delete"`
+	ID     string             `json:"id,omitempty" validate:"omitempty,number"`
+	Create *CreateNewsRequest `json:"create,omitempty"`
+	Update *UpdateNewsRequest `json:"update,omitempty"`
+}
+
+// BulkNewsRequest — пакет операций. В режиме atomic все операции выполняются в одной
+// транзакции, в best_effort — транзакциями по ChunkSize операций.
+type BulkNewsRequest struct {
+	Mode       string              `json:"mode" validate:"oneof=atomic best_effort" default:"atomic"`
+	ChunkSize  int                 `json:"chunk_size" validate:"min=1,max=500" default:"100"`
+	Operations []BulkNewsOperation `json:"operations" validate:"required,min=1,max=1000,dive"`
+}
+
+// BulkNewsResult — результат операции с тем же индексом. Статус rolled_back означает,
+// что операция прошла, но её транзакция откатилась из-за другой операции.
+type BulkNewsResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkNewsResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkNewsResult `json:"results"`
+}
+
 type GetNewsByIDRequest struct {
 	ID              string `param:"id" validate:"required"`
 	CheckVisibility bool   `query:"check_visibility" default:"true"`
 }
 
+type GetNewsBySlugRequest struct {
+	Slug            string `param:"slug" validate:"required,max=200"`
+	CheckVisibility bool   `query:"check_visibility" default:"true"`
+}
+
 type DeleteNewsRequest struct {
 	ID string `param:"id" validate:"required"`
 }
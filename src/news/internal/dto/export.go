@@ -0,0 +1,17 @@
+package dto
+
+// Форматы выгрузки и импорта архива новостей.
+const (
+	ArchiveFormatNDJSON = "ndjson"
+	ArchiveFormatCSV    = "csv"
+)
+
+// NewsExportRequest — фильтры выгрузки архива, те же, что у списка новостей.
+type NewsExportRequest struct {
+	Format          string `query:"format" default:"ndjson" validate:"oneof=ndjson csv"`
+	Search          string `query:"search"`
+	Category        string `query:"category"`
+	SortBy          string `query:"sort_by" default:"created_at" validate:"oneof=created_at start_time end_time title category"`
+	SortDir         string `query:"sort_dir" default:"desc" validate:"oneof=asc desc"`
+	CheckVisibility bool   `query:"check_visibility" default:"true"`
+}
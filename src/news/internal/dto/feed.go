@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+const (
+	FeedFormatRSS  = "rss"
+	FeedFormatAtom = "atom"
+	FeedFormatJSON = "json"
+)
+
+type FeedRequest struct {
+	Format   string `validate:"required,oneof=rss atom json"`
+	Category string `query:"category" validate:"omitempty,max=100"`
+}
+
+type FeedResponse struct {
+	Body         []byte    `json:"body"`
+	ContentType  string    `json:"content_type"`
+	ETag         string    `json:"etag"`
+	LastModified time.Time `json:"last_modified"`
+}
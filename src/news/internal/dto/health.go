@@ -0,0 +1,21 @@
+package dto
+
+const (
+	ReadinessOK          = "ok"
+	ReadinessUnavailable = "unavailable"
+)
+
+type ReadinessResponse struct {
+	Status string `json:"status"`
+	// Checks — результат каждой проверки: ok или текст ошибки.
+	Checks     map[string]string `json:"checks"`
+	Migrations *MigrationStatus  `json:"migrations,omitempty"`
+}
+
+// MigrationStatus — последняя применённая к базе миграция, последняя известная
+// сервису и число неприменённых.
+type MigrationStatus struct {
+	Current int64 `json:"current"`
+	Latest  int64 `json:"latest"`
+	Pending int   `json:"pending"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+type NewsImportRequest struct {
+	Format string `query:"format" validate:"required,oneof=ndjson csv"`
+	// DryRun проверяет файл целиком, но ничего не сохраняет.
+	DryRun bool `query:"dry_run"`
+}
+
+// ImportNewsRecord — одна запись файла импорта: новость в формате запроса на
+// создание и её id во внешней системе, по которому запись создаётся или обновляется.
+type ImportNewsRecord struct {
+	ExternalID string `json:"external_id" validate:"required,max=255"`
+	CreateNewsRequest
+}
+
+type ImportJobIDRequest struct {
+	ID string `param:"id" validate:"required,numeric"`
+}
+
+type ImportErrorResponse struct {
+	Line       int    `json:"line"`
+	ExternalID string `json:"external_id,omitempty"`
+	Error      string `json:"error"`
+}
+
+type ImportJobResponse struct {
+	ID         string `json:"id"`
+	Format     string `json:"format"`
+	DryRun     bool   `json:"dry_run"`
+	Status     string `json:"status"`
+	BytesTotal int64  `json:"bytes_total"`
+	BytesRead  int64  `json:"bytes_read"`
+	Processed  int    `json:"processed"`
+	Created    int    `json:"created"`
+	Updated    int    `json:"updated"`
+	Failed     int    `json:"failed"`
+	// Error — причина, по которой задача прервана; ошибки отдельных записей — в Errors.
+	Error      string                `json:"error,omitempty"`
+	Errors     []ImportErrorResponse `json:"errors"`
+	CreatedAt  time.Time             `json:"created_at"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+	FinishedAt *time.Time            `json:"finished_at,omitempty"`
+}
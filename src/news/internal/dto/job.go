@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobListRequest struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending running succeeded failed canceled"`
+	Kind   string `query:"kind" validate:"max=100"`
+	Page   int    `query:"page" validate:"min=1" default:"1"`
+	Limit  int    `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type JobIDRequest struct {
+	ID string `param:"id" validate:"required,numeric"`
+}
+
+type JobResponse struct {
+	ID           string          `json:"id"`
+	Kind         string          `json:"kind"`
+	Payload      json.RawMessage `json:"payload" swaggertype:"object"`
+	Status       string          `json:"status"`
+	Attempts     int             `json:"attempts"`
+	MaxAttempts  int             `json:"max_attempts"`
+	RunAt        time.Time       `json:"run_at"`
+	LockedUntil  *time.Time      `json:"locked_until,omitempty"`
+	LastError    string          `json:"last_error,omitempty"`
+	ScheduleName string          `json:"schedule_name,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+	FinishedAt   *time.Time      `json:"finished_at,omitempty"`
+}
+
+type JobListResponse struct {
+	Items      []JobResponse `json:"items"`
+	TotalCount int64         `json:"total_count"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+type CreateLiveEntryRequest struct {
+	NewsID  string               `json:"-" validate:"required,numeric"`
+	Pinned  bool                 `json:"pinned"`
+	Content []CreateContentBlock `json:"content" validate:"required,min=1,dive"`
+}
+
+// UpdateLiveEntryRequest меняет только переданные поля. Content, если передан,
+// заменяет все блоки записи.
+type UpdateLiveEntryRequest struct {
+	NewsID  string               `json:"-" validate:"required,numeric"`
+	EntryID string               `json:"-" validate:"required,numeric"`
+	Pinned  *bool                `json:"pinned"`
+	Content []CreateContentBlock `json:"content" validate:"omitempty,min=1,dive"`
+}
+
+type LiveEntryIDRequest struct {
+	NewsID  string `param:"id" validate:"required,numeric"`
+	EntryID string `param:"entryId" validate:"required,numeric"`
+}
+
+type LiveEntryListRequest struct {
+	NewsID string `param:"id" validate:"required,numeric"`
+	// After — курсор: возвращаются записи, добавленные после записи с этим ID.
+	After  int64 `query:"after" validate:"min=0"`
+	Limit  int   `query:"limit" validate:"min=1,max=100" default:"50"`
+	Pinned bool  `query:"pinned"`
+}
+
+type LiveEntryResponse struct {
+	ID        string                 `json:"id"`
+	NewsID    string                 `json:"news_id"`
+	Pinned    bool                   `json:"pinned"`
+	Content   []ContentBlockResponse `json:"content"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt time.Time              `json:"updated_at"`
+}
+
+type LiveEntryListResponse struct {
+	Entries []LiveEntryResponse `json:"entries"`
+	// Cursor передаётся в after следующего запроса.
+	Cursor  string `json:"cursor"`
+	HasMore bool   `json:"has_more"`
+}
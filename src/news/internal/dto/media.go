@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"io"
+	"time"
+)
+
+type UploadMediaRequest struct {
+	FileName string    `json:"file_name"`
+	Size     int64     `json:"size"`
+	File     io.Reader `json:"-"`
+}
+
+type GetMediaRequest struct {
+	ID string `param:"id" validate:"required,numeric"`
+}
+
+type GetMediaVariantRequest struct {
+	ID     string `param:"id" validate:"required,numeric"`
+	Width  string `param:"width" validate:"required,numeric"`
+	Format string `param:"format" validate:"required,oneof=jpeg webp"`
+}
+
+type MediaResponse struct {
+	ID        string                 `json:"id"`
+	URL       string                 `json:"url"`
+	FileName  string                 `json:"file_name"`
+	MimeType  string                 `json:"mime_type"`
+	Size      int64                  `json:"size"`
+	Width     int                    `json:"width"`
+	Height    int                    `json:"height"`
+	Checksum  string                 `json:"checksum"`
+	Variants  []MediaVariantResponse `json:"variants,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type MediaVariantResponse struct {
+	URL      string `json:"url"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Format   string `json:"format"`
+	MimeType string `json:"mime_type"`
+	Size     int64  `json:"size"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"event_types" validate:"omitempty,dive,oneof=created updated deleted published expired entry_added entry_updated entry_deleted"`
+	Category   string   `json:"category" validate:"omitempty,max=100"`
+	// Secret — ключ подписи HMAC. Если не задан, генерируется сервисом.
+	Secret string `json:"secret" validate:"omitempty,min=16,max=255"`
+	Active *bool  `json:"active"`
+}
+
+type UpdateWebhookRequest struct {
+	ID         string    `json:"-" validate:"required,numeric"`
+	URL        *string   `json:"url" validate:"omitempty,url,max=2048"`
+	EventTypes *[]string `json:"event_types" validate:"omitempty,dive,oneof=created updated deleted published expired entry_added entry_updated entry_deleted"`
+	Category   *string   `json:"category" validate:"omitempty,max=100"`
+	Secret     *string   `json:"secret" validate:"omitempty,min=16,max=255"`
+	Active     *bool     `json:"active"`
+}
+
+type WebhookIDRequest struct {
+	ID string `param:"id" validate:"required,numeric"`
+}
+
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Category   string   `json:"category,omitempty"`
+	Active     bool     `json:"active"`
+	// Secret возвращается только при создании подписки.
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookListResponse struct {
+	Items []WebhookResponse `json:"items"`
+}
+
+type WebhookDeliveryListRequest struct {
+	SubscriptionID string `param:"id" validate:"required,numeric"`
+	Status         string `query:"status" validate:"omitempty,oneof=pending succeeded failed"`
+	Page           int    `query:"page" validate:"min=1" default:"1"`
+	Limit          int    `query:"limit" validate:"min=1,max=100" default:"20"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string                   `json:"id"`
+	SubscriptionID string                   `json:"subscription_id"`
+	EventID        string                   `json:"event_id"`
+	EventType      string                   `json:"event_type"`
+	Payload        json.RawMessage          `json:"payload" swaggertype:"object"`
+	Status         string                   `json:"status"`
+	Attempts       int                      `json:"attempts"`
+	NextAttemptAt  time.Time                `json:"next_attempt_at"`
+	LastStatusCode int                      `json:"last_status_code,omitempty"`
+	LastError      string                   `json:"last_error,omitempty"`
+	AttemptLog     []WebhookAttemptResponse `json:"attempt_log,omitempty"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
+
+type WebhookAttemptResponse struct {
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Items      []WebhookDeliveryResponse `json:"items"`
+	TotalCount int64                     `json:"total_count"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+}
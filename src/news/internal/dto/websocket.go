@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/zhavkk/news-service/src/news/internal/models"
+
+// WSCommand — сообщение клиента WebSocket.
+type WSCommand struct {
+	Action string   `json:"action" validate:"required,oneof=subscribe unsubscribe list ping"`
+	Topics []string `json:"topics,omitempty" validate:"omitempty,max=100"`
+}
+
+// WSMessage — сообщение сервера WebSocket. Type: event, subscribed, unsubscribed,
+// topics, dropped, pong или error.
+type WSMessage struct {
+	Type    string            `json:"type"`
+	ID      string            `json:"id,omitempty"`
+	Event   *models.NewsEvent `json:"event,omitempty"`
+	Topics  []string          `json:"topics,omitempty"`
+	Dropped int64             `json:"dropped,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
@@ -0,0 +1,84 @@
+// Package events содержит приёмники доменных событий, в которые outbox relay
+// публикует изменения новостей.
+package events
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// MemoryBus — приёмник событий в памяти процесса. Подходит для тестов
+// и для раздачи событий подписчикам внутри одной реплики.
+type MemoryBus struct {
+	mu   sync.RWMutex
+	subs map[chan models.NewsEvent]struct{}
+}
+
+func NewMemoryBus() *MemoryBus {
+	return &MemoryBus{
+		subs: make(map[chan models.NewsEvent]struct{}),
+	}
+}
+
+// Publish доставляет событие всем подписчикам. Если буфер подписчика заполнен,
+// Publish ждёт, пока тот его разберёт, или пока не отменён ctx.
+func (b *MemoryBus) Publish(ctx context.Context, event models.NewsEvent) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// Subscribe возвращает канал событий и функцию отписки, которая закрывает канал.
+func (b *MemoryBus) Subscribe(buffer int) (<-chan models.NewsEvent, func()) {
+	ch := make(chan models.NewsEvent, buffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subs, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// followBuffer — буфер подписки Follow на шину.
+const followBuffer = 256
+
+// Follow передаёт в emit события шины, пока не отменён ctx. Истории у шины нет,
+// поэтому backlog не используется, а ID записи — ID события в outbox.
+func (b *MemoryBus) Follow(ctx context.Context, backlog int64, emit func(Envelope)) error {
+	ch, unsubscribe := b.Subscribe(followBuffer)
+	defer func() {
+		// Publish может ждать места в нашем буфере, держа блокировку шины,
+		// поэтому канал вычитывается, пока отписка не закроет его.
+		go unsubscribe()
+		for range ch {
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-ch:
+			emit(Envelope{ID: strconv.FormatInt(event.ID, 10), Event: event})
+		}
+	}
+}
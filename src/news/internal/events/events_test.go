@@ -0,0 +1,63 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+func TestMemoryBus(t *testing.T) {
+	ctx := context.Background()
+	bus := events.NewMemoryBus()
+
+	first, unsubscribeFirst := bus.Subscribe(1)
+	second, unsubscribeSecond := bus.Subscribe(1)
+	defer unsubscribeSecond()
+
+	event := models.NewsEvent{ID: 1, Type: models.NewsCreated, NewsID: 10}
+	require.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok, "unsubscribe must close the channel")
+
+	require.NoError(t, bus.Publish(ctx, event))
+	assert.Equal(t, event, <-second)
+
+	// Буфер заполнен: Publish ждёт подписчика до отмены контекста.
+	require.NoError(t, bus.Publish(ctx, event))
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, bus.Publish(ctx, event), context.DeadlineExceeded)
+}
+
+func TestRedisStream(t *testing.T) {
+	ctx := context.Background()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	sink := events.NewRedisStream(client, "news:events", 100)
+	event := models.NewsEvent{ID: 7, Type: models.NewsUpdated, NewsID: 3, Category: "Спорт"}
+	require.NoError(t, sink.Publish(ctx, event))
+
+	messages, err := client.XRange(ctx, "news:events", "-", "+").Result()
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "7", messages[0].Values["id"])
+	assert.Equal(t, "updated", messages[0].Values["type"])
+
+	var got models.NewsEvent
+	require.NoError(t, json.Unmarshal([]byte(messages[0].Values["payload"].(string)), &got))
+	assert.Equal(t, event, got)
+}
@@ -0,0 +1,188 @@
+package events
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// Envelope — событие вместе с его позицией в ленте. ID непрозрачен для клиентов:
+// это ID записи Redis Stream или, для шины в памяти, ID события в outbox.
+type Envelope struct {
+	ID    string
+	Event models.NewsEvent
+}
+
+// Filter отбирает события для подписчика. nil пропускает все события.
+type Filter func(event models.NewsEvent) bool
+
+// Hub раздаёт события множеству подписчиков и хранит последние события для
+// догона после переподключения. Broadcast никогда не ждёт подписчиков: тот,
+// чей буфер переполнен, отключается и должен переподключиться с последним ID.
+type Hub struct {
+	mu         sync.Mutex
+	replay     []Envelope
+	replaySize int
+	bufferSize int
+	subs       map[*Subscription]struct{}
+	closed     bool
+}
+
+func NewHub(replaySize, bufferSize int) *Hub {
+	return &Hub{
+		replaySize: max(replaySize, 0),
+		bufferSize: max(bufferSize, 1),
+		subs:       make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscription — подписка на события хаба. Канал Events закрывается при Close,
+// при отключении медленного подписчика и при остановке хаба.
+type Subscription struct {
+	hub    *Hub
+	ch     chan Envelope
+	filter Filter
+	// lossy — при переполнении буфера пропускать события, а не отключать подписчика.
+	lossy   bool
+	dropped atomic.Int64
+	lagged  atomic.Bool
+}
+
+func (s *Subscription) Events() <-chan Envelope {
+	return s.ch
+}
+
+// TakeDropped возвращает число событий, пропущенных с прошлого вызова.
+func (s *Subscription) TakeDropped() int64 {
+	return s.dropped.Swap(0)
+}
+
+// Lagged сообщает, что подписка закрыта из-за переполнения буфера.
+func (s *Subscription) Lagged() bool {
+	return s.lagged.Load()
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
+
+// Broadcast запоминает событие в буфере догона и рассылает его подписчикам.
+func (h *Hub) Broadcast(env Envelope) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return
+	}
+
+	if h.replaySize > 0 {
+		h.replay = append(h.replay, env)
+		if len(h.replay) > h.replaySize {
+			h.replay = h.replay[len(h.replay)-h.replaySize:]
+		}
+	}
+
+	for sub := range h.subs {
+		if sub.filter != nil && !sub.filter(env.Event) {
+			continue
+		}
+		select {
+		case sub.ch <- env:
+		default:
+			if sub.lossy {
+				sub.dropped.Add(1)
+				continue
+			}
+			sub.lagged.Store(true)
+			h.remove(sub)
+		}
+	}
+}
+
+// Subscribe регистрирует подписчика и возвращает события после lastID из буфера
+// догона. resumed равен false, если lastID задан, но в буфере его уже нет — клиент
+// пропустил события и должен перечитать состояние целиком. Для остановленного
+// хаба возвращается nil.
+func (h *Hub) Subscribe(lastID string, filter Filter) (sub *Subscription, backlog []Envelope, resumed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, false
+	}
+
+	resumed = lastID == ""
+	if !resumed {
+		for i := len(h.replay) - 1; i >= 0; i-- {
+			if h.replay[i].ID != lastID {
+				continue
+			}
+			for _, env := range h.replay[i+1:] {
+				if filter == nil || filter(env.Event) {
+					backlog = append(backlog, env)
+				}
+			}
+			resumed = true
+			break
+		}
+	}
+
+	sub = &Subscription{
+		hub:    h,
+		ch:     make(chan Envelope, h.bufferSize),
+		filter: filter,
+	}
+	h.subs[sub] = struct{}{}
+
+	return sub, backlog, resumed
+}
+
+// SubscribeLossy регистрирует подписчика без догона, которому при переполнении
+// буфера события не доставляются, вместо того чтобы отключать его.
+func (h *Hub) SubscribeLossy(filter Filter) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil
+	}
+
+	sub := &Subscription{
+		hub:    h,
+		ch:     make(chan Envelope, h.bufferSize),
+		filter: filter,
+		lossy:  true,
+	}
+	h.subs[sub] = struct{}{}
+
+	return sub
+}
+
+// Len возвращает число активных подписчиков.
+func (h *Hub) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Close отключает всех подписчиков; новые подписки после этого не принимаются.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub)
+	}
+}
+
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.ch)
+}
@@ -0,0 +1,157 @@
+package events_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+func envelope(id string, category string) events.Envelope {
+	return events.Envelope{ID: id, Event: models.NewsEvent{Type: models.NewsCreated, Category: category}}
+}
+
+func ids(envs []events.Envelope) []string {
+	out := make([]string, len(envs))
+	for i, env := range envs {
+		out[i] = env.ID
+	}
+	return out
+}
+
+func TestHub_Replay(t *testing.T) {
+	hub := events.NewHub(3, 8)
+	for _, id := range []string{"1", "2", "3", "4"} {
+		hub.Broadcast(envelope(id, "A"))
+	}
+
+	sub, backlog, resumed := hub.Subscribe("2", nil)
+	require.NotNil(t, sub)
+	assert.True(t, resumed)
+	assert.Equal(t, []string{"3", "4"}, ids(backlog))
+	sub.Close()
+
+	// "1" уже вытеснен из буфера догона.
+	sub, backlog, resumed = hub.Subscribe("1", nil)
+	assert.False(t, resumed)
+	assert.Empty(t, backlog)
+	sub.Close()
+
+	sub, backlog, resumed = hub.Subscribe("", nil)
+	assert.True(t, resumed)
+	assert.Empty(t, backlog)
+	sub.Close()
+}
+
+func TestHub_FilterAndSlowSubscriber(t *testing.T) {
+	hub := events.NewHub(0, 2)
+
+	sport, _, _ := hub.Subscribe("", func(event models.NewsEvent) bool { return event.Category == "sport" })
+	slow, _, _ := hub.Subscribe("", nil)
+	assert.Equal(t, 2, hub.Len())
+
+	hub.Broadcast(envelope("1", "tech"))
+	hub.Broadcast(envelope("2", "sport"))
+	hub.Broadcast(envelope("3", "tech"))
+
+	got := <-sport.Events()
+	assert.Equal(t, "2", got.ID)
+
+	// Буфер slow рассчитан на два события, третье отключает его, не блокируя рассылку.
+	assert.Equal(t, []string{"1", "2"}, ids(drain(slow.Events())))
+	assert.Equal(t, 1, hub.Len())
+
+	hub.Close()
+	_, ok := <-sport.Events()
+	assert.False(t, ok)
+
+	sub, _, _ := hub.Subscribe("", nil)
+	assert.Nil(t, sub)
+}
+
+func drain(ch <-chan events.Envelope) []events.Envelope {
+	var out []events.Envelope
+	for env := range ch {
+		out = append(out, env)
+	}
+	return out
+}
+
+func TestRedisStream_Follow(t *testing.T) {
+	logger.Init("local")
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	stream := events.NewRedisStream(client, "news:events", 100)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	require.NoError(t, stream.Publish(ctx, models.NewsEvent{ID: 1, Type: models.NewsCreated, NewsID: 1}))
+	require.NoError(t, stream.Publish(ctx, models.NewsEvent{ID: 2, Type: models.NewsUpdated, NewsID: 1}))
+
+	received := make(chan events.Envelope, 10)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = stream.Follow(ctx, 1, func(env events.Envelope) { received <- env })
+	}()
+
+	first := <-received
+	assert.Equal(t, int64(2), first.Event.ID, "only the last backlog entry is replayed")
+	assert.NotEmpty(t, first.ID)
+
+	require.NoError(t, stream.Publish(ctx, models.NewsEvent{ID: 3, Type: models.NewsDeleted, NewsID: 1}))
+	select {
+	case env := <-received:
+		assert.Equal(t, models.NewsDeleted, env.Event.Type)
+		assert.NotEqual(t, first.ID, env.ID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("new entry was not delivered")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestTopics(t *testing.T) {
+	topics := events.NewTopics(3)
+
+	require.NoError(t, topics.Add("category:sport", "news:42"))
+	assert.True(t, topics.Match(models.NewsEvent{NewsID: 1, Category: "sport"}))
+	assert.True(t, topics.Match(models.NewsEvent{NewsID: 42, Category: "tech"}))
+	assert.False(t, topics.Match(models.NewsEvent{NewsID: 2, Category: "tech"}))
+
+	assert.ErrorIs(t, topics.Add("news:abc"), events.ErrInvalidTopic)
+	assert.ErrorIs(t, topics.Add("tag:elections"), events.ErrInvalidTopic)
+	assert.ErrorIs(t, topics.Add("category:a", "category:b"), events.ErrTooManyTopics)
+	assert.Equal(t, []string{"category:sport", "news:42"}, topics.List(), "failed add leaves topics unchanged")
+
+	require.NoError(t, topics.Remove("category:sport"))
+	require.NoError(t, topics.Add("all"))
+	assert.True(t, topics.Match(models.NewsEvent{NewsID: 2, Category: "tech"}))
+	assert.Equal(t, []string{"all", "news:42"}, topics.List())
+}
+
+func TestHub_LossySubscriber(t *testing.T) {
+	hub := events.NewHub(0, 1)
+	sub := hub.SubscribeLossy(nil)
+
+	hub.Broadcast(envelope("1", "A"))
+	hub.Broadcast(envelope("2", "A"))
+	hub.Broadcast(envelope("3", "A"))
+
+	got := <-sub.Events()
+	assert.Equal(t, "1", got.ID)
+	assert.Equal(t, int64(2), sub.TakeDropped())
+	assert.Zero(t, sub.TakeDropped())
+	assert.Equal(t, 1, hub.Len(), "lossy subscriber stays connected")
+	assert.False(t, sub.Lagged())
+}
@@ -0,0 +1,25 @@
+package events
+
+import (
+	"context"
+
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+type Sink interface {
+	Publish(ctx context.Context, event models.NewsEvent) error
+}
+
+// MultiSink публикует событие в несколько приёмников по очереди и останавливается
+// на первой ошибке. При повторной отправке события приёмники, уже получившие его,
+// получат его ещё раз, поэтому они должны быть идемпотентны по ID события.
+type MultiSink []Sink
+
+func (m MultiSink) Publish(ctx context.Context, event models.NewsEvent) error {
+	for _, sink := range m {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+const (
+	// followBlock — сколько XREAD ждёт новых записей; заодно ограничивает
+	// время реакции Follow на отмену контекста.
+	followBlock = 2 * time.Second
+	// followRetry — пауза перед повтором чтения после ошибки Redis.
+	followRetry = time.Second
+	followCount = 100
+)
+
+// RedisStream публикует события в Redis Stream. Длина стрима ограничивается
+// примерно maxLen записями.
+type RedisStream struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+func NewRedisStream(client *redis.Client, stream string, maxLen int64) *RedisStream {
+	return &RedisStream{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+func (s *RedisStream) Publish(ctx context.Context, event models.NewsEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	err = s.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: s.stream,
+		MaxLen: s.maxLen,
+		Approx: true,
+		Values: map[string]any{
+			"id":      event.ID,
+			"type":    string(event.Type),
+			"news_id": event.NewsID,
+			"payload": payload,
+		},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("xadd %s: %w", s.stream, err)
+	}
+
+	return nil
+}
+
+// Follow передаёт в emit последние backlog записей стрима, а затем новые записи,
+// пока не отменён ctx. Ошибки Redis не прерывают чтение: после паузы Follow
+// продолжает с последней полученной записи, так что события не теряются.
+func (s *RedisStream) Follow(ctx context.Context, backlog int64, emit func(Envelope)) error {
+	const op = "events.RedisStream.Follow"
+
+	lastID := ""
+	for ctx.Err() == nil {
+		var messages []redis.XMessage
+		var err error
+		if lastID == "" {
+			messages, lastID, err = s.recent(ctx, backlog)
+		} else {
+			messages, err = s.next(ctx, lastID)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			logger.Log.Warn(op, "Failed to read event stream", err, "stream", s.stream)
+			select {
+			case <-ctx.Done():
+			case <-time.After(followRetry):
+			}
+			continue
+		}
+
+		for _, message := range messages {
+			lastID = message.ID
+			env, err := decodeMessage(message)
+			if err != nil {
+				logger.Log.Warn(op, "Skipping malformed stream entry", err, "id", message.ID)
+				continue
+			}
+			emit(env)
+		}
+	}
+	return nil
+}
+
+// recent возвращает до backlog последних записей в хронологическом порядке
+// и ID, с которого продолжать чтение.
+func (s *RedisStream) recent(ctx context.Context, backlog int64) ([]redis.XMessage, string, error) {
+	messages, err := s.client.XRevRangeN(ctx, s.stream, "+", "-", max(backlog, 1)).Result()
+	if err != nil {
+		return nil, "", err
+	}
+	if len(messages) == 0 {
+		return nil, "0-0", nil
+	}
+
+	lastID := messages[0].ID
+	if backlog <= 0 {
+		return nil, lastID, nil
+	}
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+	return messages, lastID, nil
+}
+
+func (s *RedisStream) next(ctx context.Context, lastID string) ([]redis.XMessage, error) {
+	streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{s.stream, lastID},
+		Count:   followCount,
+		Block:   followBlock,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(streams) == 0 {
+		return nil, nil
+	}
+	return streams[0].Messages, nil
+}
+
+func decodeMessage(message redis.XMessage) (Envelope, error) {
+	payload, ok := message.Values["payload"].(string)
+	if !ok {
+		return Envelope{}, errors.New("payload field is missing")
+	}
+
+	var event models.NewsEvent
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return Envelope{}, err
+	}
+	return Envelope{ID: message.ID, Event: event}, nil
+}
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+var (
+	ErrInvalidTopic  = errors.New("invalid topic")
+	ErrTooManyTopics = errors.New("too many topics")
+)
+
+// TopicAll — тема со всеми событиями.
+const TopicAll = "all"
+
+// Topics — изменяемый набор тем подписки одного клиента. Поддерживаются темы
+// "all", "category:<название>" и "news:<id>".
+type Topics struct {
+	mu         sync.RWMutex
+	limit      int
+	all        bool
+	categories map[string]struct{}
+	news       map[int64]struct{}
+}
+
+// NewTopics создаёт пустой набор, в котором может быть не больше limit тем.
+func NewTopics(limit int) *Topics {
+	return &Topics{
+		limit:      limit,
+		categories: make(map[string]struct{}),
+		news:       make(map[int64]struct{}),
+	}
+}
+
+// Add добавляет темы. Если хотя бы одна тема некорректна или лимит будет
+// превышен, набор не меняется.
+func (t *Topics) Add(topics ...string) error {
+	parsed := make([]topic, 0, len(topics))
+	for _, raw := range topics {
+		p, err := parseTopic(raw)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, p)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	added := 0
+	for _, p := range parsed {
+		if !t.has(p) {
+			added++
+		}
+	}
+	if t.limit > 0 && t.len()+added > t.limit {
+		return fmt.Errorf("%w: at most %d per connection", ErrTooManyTopics, t.limit)
+	}
+
+	for _, p := range parsed {
+		switch {
+		case p.all:
+			t.all = true
+		case p.category != "":
+			t.categories[p.category] = struct{}{}
+		default:
+			t.news[p.newsID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Remove убирает темы; неизвестные темы игнорируются.
+func (t *Topics) Remove(topics ...string) error {
+	parsed := make([]topic, 0, len(topics))
+	for _, raw := range topics {
+		p, err := parseTopic(raw)
+		if err != nil {
+			return err
+		}
+		parsed = append(parsed, p)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, p := range parsed {
+		switch {
+		case p.all:
+			t.all = false
+		case p.category != "":
+			delete(t.categories, p.category)
+		default:
+			delete(t.news, p.newsID)
+		}
+	}
+	return nil
+}
+
+// Match проверяет, подписан ли клиент на событие.
+func (t *Topics) Match(event models.NewsEvent) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if t.all {
+		return true
+	}
+	if _, ok := t.news[event.NewsID]; ok {
+		return true
+	}
+	_, ok := t.categories[event.Category]
+	return ok
+}
+
+// List возвращает темы в отсортированном виде.
+func (t *Topics) List() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	list := make([]string, 0, t.len())
+	if t.all {
+		list = append(list, TopicAll)
+	}
+	for category := range t.categories {
+		list = append(list, "category:"+category)
+	}
+	for id := range t.news {
+		list = append(list, "news:"+strconv.FormatInt(id, 10))
+	}
+	slices.Sort(list)
+	return list
+}
+
+func (t *Topics) len() int {
+	n := len(t.categories) + len(t.news)
+	if t.all {
+		n++
+	}
+	return n
+}
+
+func (t *Topics) has(p topic) bool {
+	switch {
+	case p.all:
+		return t.all
+	case p.category != "":
+		_, ok := t.categories[p.category]
+		return ok
+	default:
+		_, ok := t.news[p.newsID]
+		return ok
+	}
+}
+
+type topic struct {
+	all      bool
+	category string
+	newsID   int64
+}
+
+func parseTopic(raw string) (topic, error) {
+	if raw == TopicAll {
+		return topic{all: true}, nil
+	}
+
+	kind, value, ok := strings.Cut(raw, ":")
+	if !ok || value == "" {
+		return topic{}, fmt.Errorf("%w: %q", ErrInvalidTopic, raw)
+	}
+
+	switch kind {
+	case "category":
+		return topic{category: value}, nil
+	case "news":
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			return topic{}, fmt.Errorf("%w: %q: news id must be a positive integer", ErrInvalidTopic, raw)
+		}
+		return topic{newsID: id}, nil
+	case "tag":
+		// У новостей пока нет тегов, подписка на тег никогда бы не сработала.
+		return topic{}, fmt.Errorf("%w: %q: tags are not supported", ErrInvalidTopic, raw)
+	default:
+		return topic{}, fmt.Errorf("%w: %q", ErrInvalidTopic, raw)
+	}
+}
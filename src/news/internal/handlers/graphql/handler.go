@@ -0,0 +1,104 @@
+package graphqlhandlers
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+type GraphQLHandler struct {
+	schema *graphql.Schema
+	limits queryLimits
+}
+
+func NewGraphQLHandler(newsService NewsService, cfg config.GraphQLConfig) *GraphQLHandler {
+	opts := []graphql.SchemaOpt{
+		graphql.UseStringDescriptions(),
+		graphql.MaxQueryLength(cfg.MaxQueryLength),
+	}
+	if !cfg.Introspection {
+		opts = append(opts, graphql.DisableIntrospection())
+	}
+
+	return &GraphQLHandler{
+		schema: graphql.MustParseSchema(schemaSDL, &Resolver{newsService: newsService}, opts...),
+		limits: queryLimits{
+			maxDepth:      cfg.MaxDepth,
+			maxComplexity: cfg.MaxComplexity,
+		},
+	}
+}
+
+func (h *GraphQLHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/graphql", h.Serve)
+	router.Post("/graphql", h.Serve)
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Serve выполняет запрос GraphQL. Запросы можно отправлять и GET-ом
+// (параметры query, operationName и variables в JSON), мутации — только POST.
+func (h *GraphQLHandler) Serve(c *fiber.Ctx) error {
+	var req graphqlRequest
+
+	if c.Method() == fiber.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if raw := c.Query("variables"); raw != "" {
+			if err := json.Unmarshal([]byte(raw), &req.Variables); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+					Status:  fiber.StatusBadRequest,
+					Message: "Invalid variables",
+					Error:   err.Error(),
+				})
+			}
+		}
+	} else if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if req.Query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Query is required",
+		})
+	}
+
+	operation, err := h.limits.check(req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		return c.JSON(&graphql.Response{
+			Errors: []*gqlerrors.QueryError{{
+				Message:    err.Error(),
+				Extensions: map[string]interface{}{"code": "QUERY_REJECTED"},
+			}},
+		})
+	}
+	// GET-запросы могут кешироваться и повторяться прокси, поэтому изменения только через POST.
+	if c.Method() == fiber.MethodGet && operation == ast.Mutation {
+		c.Set(fiber.HeaderAllow, fiber.MethodPost)
+		return c.Status(fiber.StatusMethodNotAllowed).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusMethodNotAllowed,
+			Message: "Mutations must be sent with POST",
+		})
+	}
+
+	resp := h.schema.Exec(c.Context(), req.Query, req.OperationName, req.Variables)
+	return c.JSON(resp)
+}
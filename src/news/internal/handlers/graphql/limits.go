@@ -0,0 +1,134 @@
+package graphqlhandlers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	// defaultListSize — значение first у newsList по умолчанию, как в схеме.
+	defaultListSize = 20
+	// nestedListEstimate — средний размер вложенных списков: блоков у новости
+	// и вариантов у изображения.
+	nestedListEstimate = 5
+)
+
+var (
+	errUnknownOperation = errors.New("unknown operation")
+	errQueryTooDeep     = errors.New("query is too deep")
+	errQueryTooComplex  = errors.New("query is too complex")
+)
+
+// queryLimits отклоняет слишком глубокие и слишком дорогие запросы до выполнения.
+//
+// Каждое поле стоит 1, поля внутри списка умножаются на число его элементов:
+// first для newsList и nestedListEstimate для content и variants. Интроспекция не
+// учитывается: её глубина задана спецификацией, а не клиентом.
+type queryLimits struct {
+	maxDepth      int
+	maxComplexity int
+}
+
+// check разбирает запрос и возвращает выбранную операцию. Синтаксические
+// ошибки не считаются нарушением лимитов: их вернёт исполнитель схемы.
+func (l queryLimits) check(query, operationName string, variables map[string]interface{}) (ast.Operation, error) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: query})
+	if err != nil {
+		return "", nil
+	}
+
+	op := doc.Operations.ForName(operationName)
+	if op == nil {
+		return "", fmt.Errorf("%w %q", errUnknownOperation, operationName)
+	}
+
+	w := &queryWalker{doc: doc, variables: variables, visiting: make(map[string]bool)}
+	complexity := w.cost(op.SelectionSet, 1)
+
+	if l.maxDepth > 0 && w.maxDepth > l.maxDepth {
+		return op.Operation, fmt.Errorf("%w: depth %d exceeds limit %d", errQueryTooDeep, w.maxDepth, l.maxDepth)
+	}
+	if l.maxComplexity > 0 && complexity > l.maxComplexity {
+		return op.Operation, fmt.Errorf("%w: complexity %d exceeds limit %d", errQueryTooComplex, complexity, l.maxComplexity)
+	}
+
+	return op.Operation, nil
+}
+
+type queryWalker struct {
+	doc       *ast.QueryDocument
+	variables map[string]interface{}
+	visiting  map[string]bool
+	maxDepth  int
+}
+
+func (w *queryWalker) cost(selections ast.SelectionSet, depth int) int {
+	total := 0
+
+	for _, selection := range selections {
+		switch sel := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			if depth > w.maxDepth {
+				w.maxDepth = depth
+			}
+			total += 1 + w.listSize(sel)*w.cost(sel.SelectionSet, depth+1)
+		case *ast.InlineFragment:
+			total += w.cost(sel.SelectionSet, depth)
+		case *ast.FragmentSpread:
+			fragment := w.doc.Fragments.ForName(sel.Name)
+			// Циклы во фрагментах отклонит валидация схемы.
+			if fragment == nil || w.visiting[sel.Name] {
+				continue
+			}
+			w.visiting[sel.Name] = true
+			total += w.cost(fragment.SelectionSet, depth)
+			delete(w.visiting, sel.Name)
+		}
+	}
+
+	return total
+}
+
+func (w *queryWalker) listSize(field *ast.Field) int {
+	switch field.Name {
+	case "newsList":
+		arg := field.Arguments.ForName("first")
+		if arg == nil {
+			return defaultListSize
+		}
+		return w.intValue(arg.Value, defaultListSize)
+	case "content", "variants":
+		return nestedListEstimate
+	default:
+		return 1
+	}
+}
+
+func (w *queryWalker) intValue(value *ast.Value, fallback int) int {
+	switch value.Kind {
+	case ast.IntValue:
+		if n, err := strconv.Atoi(value.Raw); err == nil && n > 0 {
+			return n
+		}
+	case ast.Variable:
+		switch n := w.variables[value.Raw].(type) {
+		case float64:
+			if n > 0 {
+				return int(n)
+			}
+		case int:
+			if n > 0 {
+				return n
+			}
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,69 @@
+package graphqlhandlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func TestQueryLimits_Depth(t *testing.T) {
+	limits := queryLimits{maxDepth: 3}
+
+	_, err := limits.check(`{ newsList { nodes { id } } }`, "", nil)
+	require.NoError(t, err)
+
+	_, err = limits.check(`{ newsList { nodes { content { variants { url } } } } }`, "", nil)
+	assert.ErrorIs(t, err, errQueryTooDeep)
+}
+
+func TestQueryLimits_Complexity(t *testing.T) {
+	limits := queryLimits{maxComplexity: 100}
+
+	// 1 (newsList) + 10 * (1 (nodes) + 1 * 2 (id, title))
+	_, err := limits.check(`{ newsList(first: 10) { nodes { id title } } }`, "", nil)
+	require.NoError(t, err)
+
+	_, err = limits.check(`{ newsList(first: 50) { nodes { id title } } }`, "", nil)
+	assert.ErrorIs(t, err, errQueryTooComplex)
+
+	query := `query($n: Int) { newsList(first: $n) { nodes { id title } } }`
+	_, err = limits.check(query, "", map[string]interface{}{"n": float64(10)})
+	require.NoError(t, err)
+	_, err = limits.check(query, "", map[string]interface{}{"n": float64(50)})
+	assert.ErrorIs(t, err, errQueryTooComplex)
+}
+
+func TestQueryLimits_Fragments(t *testing.T) {
+	limits := queryLimits{maxDepth: 3}
+
+	query := `
+		query { newsList { nodes { ...teaser } } }
+		fragment teaser on News { content { ... on ContentBlock { variants { url } } } }`
+	_, err := limits.check(query, "", nil)
+	assert.ErrorIs(t, err, errQueryTooDeep)
+}
+
+func TestQueryLimits_IgnoresIntrospection(t *testing.T) {
+	limits := queryLimits{maxDepth: 2, maxComplexity: 10}
+
+	_, err := limits.check(`{ __schema { types { fields { type { ofType { name } } } } } }`, "", nil)
+	assert.NoError(t, err)
+}
+
+func TestQueryLimits_Operation(t *testing.T) {
+	limits := queryLimits{}
+
+	query := `query A { categories { name } } mutation B { deleteNews(id: "1") }`
+	op, err := limits.check(query, "B", nil)
+	require.NoError(t, err)
+	assert.Equal(t, ast.Mutation, op)
+
+	_, err = limits.check(query, "C", nil)
+	assert.ErrorIs(t, err, errUnknownOperation)
+
+	// Синтаксические ошибки возвращает исполнитель схемы.
+	_, err = limits.check(`{ newsList {`, "", nil)
+	assert.NoError(t, err)
+}
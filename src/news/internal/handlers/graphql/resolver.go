@@ -0,0 +1,383 @@
+package graphqlhandlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+var validate = validator.New()
+
+type NewsService interface {
+	CreateNews(ctx context.Context, req dto.CreateNewsRequest) (*dto.NewsResponse, error)
+	UpdateNews(ctx context.Context, req dto.UpdateNewsRequest) (*dto.UpdateNewsResponse, error)
+	GetNewsByID(ctx context.Context, req dto.GetNewsByIDRequest) (*dto.NewsResponse, error)
+	GetNewsBySlug(ctx context.Context, req dto.GetNewsBySlugRequest) (*dto.NewsResponse, error)
+	DeleteNews(ctx context.Context, req dto.DeleteNewsRequest) (*dto.DeleteNewsResponse, error)
+	ListNewsConnection(ctx context.Context, req dto.NewsConnectionRequest) (*dto.NewsConnectionResponse, error)
+	ListCategories(ctx context.Context, checkVisibility bool) ([]dto.CategoryResponse, error)
+}
+
+type Resolver struct {
+	newsService NewsService
+}
+
+func (r *Resolver) News(ctx context.Context, args struct {
+	ID            *graphql.ID
+	Slug          *string
+	IncludeHidden bool
+}) (*newsResolver, error) {
+	var (
+		resp *dto.NewsResponse
+		err  error
+	)
+
+	switch {
+	case args.ID != nil:
+		req := dto.GetNewsByIDRequest{ID: string(*args.ID), CheckVisibility: !args.IncludeHidden}
+		if err := validate.Struct(req); err != nil {
+			return nil, badInput(err)
+		}
+		resp, err = r.newsService.GetNewsByID(ctx, req)
+	case args.Slug != nil:
+		req := dto.GetNewsBySlugRequest{Slug: *args.Slug, CheckVisibility: !args.IncludeHidden}
+		if err := validate.Struct(req); err != nil {
+			return nil, badInput(err)
+		}
+		resp, err = r.newsService.GetNewsBySlug(ctx, req)
+
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			req.Slug = moved.CurrentSlug
+			resp, err = r.newsService.GetNewsBySlug(ctx, req)
+		}
+	default:
+		return nil, &resolverError{code: codeBadInput, message: "id or slug is required"}
+	}
+
+	// Отсутствующая новость — это null, а не ошибка.
+	if errors.Is(err, postgres.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, newsError(err)
+	}
+
+	return &newsResolver{news: resp}, nil
+}
+
+func (r *Resolver) NewsList(ctx context.Context, args struct {
+	First         int32
+	After         *string
+	Search        *string
+	Category      *string
+	SortBy        string
+	SortDir       string
+	IncludeHidden bool
+}) (*newsConnectionResolver, error) {
+	req := dto.NewsConnectionRequest{
+		First:           int(args.First),
+		SortBy:          strings.ToLower(args.SortBy),
+		SortDir:         strings.ToLower(args.SortDir),
+		CheckVisibility: !args.IncludeHidden,
+		// Блоки всех новостей страницы загружаются одним запросом и только если их запросили.
+		IncludeContent: graphql.HasSelectedField(ctx, "nodes.content"),
+		IncludeTotal:   graphql.HasSelectedField(ctx, "totalCount"),
+	}
+	if args.After != nil {
+		req.After = *args.After
+	}
+	if args.Search != nil {
+		req.Search = *args.Search
+	}
+	if args.Category != nil {
+		req.Category = *args.Category
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, badInput(err)
+	}
+
+	resp, err := r.newsService.ListNewsConnection(ctx, req)
+	if err != nil {
+		return nil, newsError(err)
+	}
+
+	return &newsConnectionResolver{conn: resp}, nil
+}
+
+func (r *Resolver) Categories(ctx context.Context, args struct{ IncludeHidden bool }) ([]*categoryResolver, error) {
+	categories, err := r.newsService.ListCategories(ctx, !args.IncludeHidden)
+	if err != nil {
+		return nil, newsError(err)
+	}
+
+	result := make([]*categoryResolver, len(categories))
+	for i := range categories {
+		result[i] = &categoryResolver{category: categories[i]}
+	}
+	return result, nil
+}
+
+type contentBlockInput struct {
+	Type     string
+	Content  string
+	MediaID  *graphql.ID
+	Position int32
+}
+
+func (r *Resolver) CreateNews(ctx context.Context, args struct {
+	Input struct {
+		Title     string
+		Category  string
+		Live      bool
+		Content   []contentBlockInput
+		StartTime graphql.Time
+		EndTime   graphql.Time
+	}
+}) (*newsResolver, error) {
+	req := dto.CreateNewsRequest{
+		Title:     args.Input.Title,
+		Category:  args.Input.Category,
+		Live:      args.Input.Live,
+		Content:   blocksFromInput(args.Input.Content),
+		StartTime: args.Input.StartTime.Time,
+		EndTime:   args.Input.EndTime.Time,
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, badInput(err)
+	}
+
+	resp, err := r.newsService.CreateNews(ctx, req)
+	if err != nil {
+		return nil, newsError(err)
+	}
+
+	return &newsResolver{news: resp}, nil
+}
+
+func (r *Resolver) UpdateNews(ctx context.Context, args struct {
+	ID    graphql.ID
+	Input struct {
+		Title     *string
+		Category  *string
+		Live      *bool
+		Content   *[]contentBlockInput
+		StartTime *graphql.Time
+		EndTime   *graphql.Time
+	}
+}) (*newsResolver, error) {
+	req := dto.UpdateNewsRequest{
+		ID:   string(args.ID),
+		Live: args.Input.Live,
+	}
+	if args.Input.Title != nil {
+		req.Title = *args.Input.Title
+	}
+	if args.Input.Category != nil {
+		req.Category = *args.Input.Category
+	}
+	if args.Input.Content != nil {
+		req.Content = blocksFromInput(*args.Input.Content)
+	}
+	if args.Input.StartTime != nil {
+		req.StartTime = &args.Input.StartTime.Time
+	}
+	if args.Input.EndTime != nil {
+		req.EndTime = &args.Input.EndTime.Time
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, badInput(err)
+	}
+
+	if _, err := r.newsService.UpdateNews(ctx, req); err != nil {
+		return nil, newsError(err)
+	}
+
+	// Мутация возвращает новость целиком, даже если она сейчас скрыта.
+	resp, err := r.newsService.GetNewsByID(ctx, dto.GetNewsByIDRequest{ID: req.ID})
+	if err != nil {
+		return nil, newsError(err)
+	}
+
+	return &newsResolver{news: resp}, nil
+}
+
+func (r *Resolver) DeleteNews(ctx context.Context, args struct{ ID graphql.ID }) (graphql.ID, error) {
+	req := dto.DeleteNewsRequest{ID: string(args.ID)}
+	if err := validate.Struct(req); err != nil {
+		return "", badInput(err)
+	}
+
+	resp, err := r.newsService.DeleteNews(ctx, req)
+	if err != nil {
+		return "", newsError(err)
+	}
+
+	return graphql.ID(resp.ID), nil
+}
+
+func blocksFromInput(blocks []contentBlockInput) []dto.CreateContentBlock {
+	result := make([]dto.CreateContentBlock, len(blocks))
+	for i, block := range blocks {
+		result[i] = dto.CreateContentBlock{
+			Type:     strings.ToLower(block.Type),
+			Content:  block.Content,
+			Position: int(block.Position),
+		}
+		if block.MediaID != nil {
+			result[i].MediaID = string(*block.MediaID)
+		}
+	}
+	return result
+}
+
+type newsResolver struct {
+	news *dto.NewsResponse
+}
+
+func (r *newsResolver) ID() graphql.ID            { return graphql.ID(r.news.ID) }
+func (r *newsResolver) Title() string             { return r.news.Title }
+func (r *newsResolver) Slug() string              { return r.news.Slug }
+func (r *newsResolver) Category() string          { return r.news.Category }
+func (r *newsResolver) Live() bool                { return r.news.Live }
+func (r *newsResolver) CreatedAt() graphql.Time   { return graphql.Time{Time: r.news.CreatedAt} }
+func (r *newsResolver) UpdatedAt() graphql.Time   { return graphql.Time{Time: r.news.UpdatedAt} }
+func (r *newsResolver) StartTime() graphql.Time   { return graphql.Time{Time: r.news.StartTime} }
+func (r *newsResolver) EndTime() graphql.Time     { return graphql.Time{Time: r.news.EndTime} }
+func (r *newsResolver) Content() []*blockResolver { return blockResolvers(r.news.Content) }
+
+type blockResolver struct {
+	block dto.ContentBlockResponse
+}
+
+func blockResolvers(blocks []dto.ContentBlockResponse) []*blockResolver {
+	result := make([]*blockResolver, len(blocks))
+	for i := range blocks {
+		result[i] = &blockResolver{block: blocks[i]}
+	}
+	return result
+}
+
+func (r *blockResolver) ID() graphql.ID    { return graphql.ID(r.block.ID) }
+func (r *blockResolver) Type() string      { return strings.ToUpper(r.block.Type) }
+func (r *blockResolver) Content() string   { return r.block.Content }
+func (r *blockResolver) Position() int32   { return int32(r.block.Position) }
+func (r *blockResolver) MediaURL() *string { return optional(r.block.MediaURL) }
+func (r *blockResolver) Srcset() *string   { return optional(r.block.Srcset) }
+
+func (r *blockResolver) MediaID() *graphql.ID {
+	if r.block.MediaID == "" {
+		return nil
+	}
+	id := graphql.ID(r.block.MediaID)
+	return &id
+}
+
+func (r *blockResolver) Variants() []*variantResolver {
+	result := make([]*variantResolver, len(r.block.Variants))
+	for i := range r.block.Variants {
+		result[i] = &variantResolver{variant: r.block.Variants[i]}
+	}
+	return result
+}
+
+type variantResolver struct {
+	variant dto.MediaVariantResponse
+}
+
+func (r *variantResolver) URL() string      { return r.variant.URL }
+func (r *variantResolver) Width() int32     { return int32(r.variant.Width) }
+func (r *variantResolver) Height() int32    { return int32(r.variant.Height) }
+func (r *variantResolver) Format() string   { return r.variant.Format }
+func (r *variantResolver) MimeType() string { return r.variant.MimeType }
+func (r *variantResolver) Size() int32      { return int32(r.variant.Size) }
+
+type newsConnectionResolver struct {
+	conn *dto.NewsConnectionResponse
+}
+
+func (r *newsConnectionResolver) Nodes() []*newsResolver {
+	result := make([]*newsResolver, len(r.conn.Items))
+	for i := range r.conn.Items {
+		result[i] = &newsResolver{news: &r.conn.Items[i]}
+	}
+	return result
+}
+
+func (r *newsConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{conn: r.conn}
+}
+
+func (r *newsConnectionResolver) TotalCount() int32 {
+	return int32(r.conn.TotalCount)
+}
+
+type pageInfoResolver struct {
+	conn *dto.NewsConnectionResponse
+}
+
+func (r *pageInfoResolver) EndCursor() *string { return optional(r.conn.EndCursor) }
+func (r *pageInfoResolver) HasNextPage() bool  { return r.conn.HasNextPage }
+
+type categoryResolver struct {
+	category dto.CategoryResponse
+}
+
+func (r *categoryResolver) Name() string { return r.category.Name }
+func (r *categoryResolver) Count() int32 { return int32(r.category.Count) }
+
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+const (
+	codeBadInput = "BAD_USER_INPUT"
+	codeNotFound = "NOT_FOUND"
+	codeInternal = "INTERNAL_SERVER_ERROR"
+)
+
+// resolverError — ошибка с кодом в extensions.code, по которому клиент
+// отличает ошибку запроса от сбоя сервера.
+type resolverError struct {
+	code    string
+	message string
+}
+
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": e.code}
+}
+
+func badInput(err error) error {
+	return &resolverError{code: codeBadInput, message: err.Error()}
+}
+
+// newsError переводит ошибку сервиса в ошибку GraphQL; коды соответствуют
+// HTTP-статусам handlers/v1.
+func newsError(err error) error {
+	var numErr *strconv.NumError
+
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		return &resolverError{code: codeNotFound, message: "News not found"}
+	case errors.Is(err, service.ErrInvalidMediaReference), errors.Is(err, service.ErrInvalidCursor):
+		return badInput(err)
+	case errors.As(err, &numErr):
+		return &resolverError{code: codeBadInput, message: "Invalid news id: " + err.Error()}
+	}
+
+	return &resolverError{code: codeInternal, message: err.Error()}
+}
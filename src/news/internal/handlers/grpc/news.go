@@ -0,0 +1,337 @@
+package grpchandlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator"
+	newsv1 "github.com/zhavkk/news-service/src/news/api/news/v1"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var validate = validator.New()
+
+// streamPageSize — размер страницы StreamNews, если limit не задан.
+const streamPageSize = 100
+
+// NewsService — тот же сервис, что обслуживает handlers/v1, поэтому поведение
+// gRPC и REST API совпадает.
+type NewsService interface {
+	CreateNews(ctx context.Context, req dto.CreateNewsRequest) (*dto.NewsResponse, error)
+	UpdateNews(ctx context.Context, req dto.UpdateNewsRequest) (*dto.UpdateNewsResponse, error)
+	GetNewsByID(ctx context.Context, req dto.GetNewsByIDRequest) (*dto.NewsResponse, error)
+	GetNewsBySlug(ctx context.Context, req dto.GetNewsBySlugRequest) (*dto.NewsResponse, error)
+	DeleteNews(ctx context.Context, req dto.DeleteNewsRequest) (*dto.DeleteNewsResponse, error)
+	ListNews(ctx context.Context, req dto.NewsListRequest) (*dto.NewsListResponse, error)
+}
+
+type NewsServer struct {
+	newsv1.UnimplementedNewsServiceServer
+
+	newsService NewsService
+}
+
+func NewNewsServer(newsService NewsService) *NewsServer {
+	return &NewsServer{
+		newsService: newsService,
+	}
+}
+
+func (s *NewsServer) Register(server *grpc.Server) {
+	newsv1.RegisterNewsServiceServer(server, s)
+}
+
+func (s *NewsServer) CreateNews(ctx context.Context, in *newsv1.CreateNewsRequest) (*newsv1.News, error) {
+	req := dto.CreateNewsRequest{
+		Title:     in.GetTitle(),
+		Category:  in.GetCategory(),
+		Live:      in.GetLive(),
+		Content:   blocksFromProto(in.GetContent()),
+		StartTime: timeFromProto(in.GetStartTime()),
+		EndTime:   timeFromProto(in.GetEndTime()),
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.newsService.CreateNews(ctx, req)
+	if err != nil {
+		return nil, newsError(err, "Failed to create news")
+	}
+
+	return newsToProto(resp), nil
+}
+
+func (s *NewsServer) GetNews(ctx context.Context, in *newsv1.GetNewsRequest) (*newsv1.News, error) {
+	var (
+		resp *dto.NewsResponse
+		err  error
+	)
+
+	switch lookup := in.GetLookup().(type) {
+	case *newsv1.GetNewsRequest_Id:
+		req := dto.GetNewsByIDRequest{ID: lookup.Id, CheckVisibility: !in.GetIncludeHidden()}
+		if err := validate.Struct(req); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		resp, err = s.newsService.GetNewsByID(ctx, req)
+	case *newsv1.GetNewsRequest_Slug:
+		resp, err = s.getBySlug(ctx, lookup.Slug, !in.GetIncludeHidden())
+	default:
+		return nil, status.Error(codes.InvalidArgument, "id or slug is required")
+	}
+	if err != nil {
+		return nil, newsError(err, "Failed to get news")
+	}
+
+	return newsToProto(resp), nil
+}
+
+// getBySlug сам переходит по старому слагу: редиректа, как в REST, в gRPC нет.
+func (s *NewsServer) getBySlug(ctx context.Context, slug string, checkVisibility bool) (*dto.NewsResponse, error) {
+	req := dto.GetNewsBySlugRequest{Slug: slug, CheckVisibility: checkVisibility}
+	if err := validate.Struct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.newsService.GetNewsBySlug(ctx, req)
+
+	var moved *service.SlugMovedError
+	if errors.As(err, &moved) {
+		req.Slug = moved.CurrentSlug
+		resp, err = s.newsService.GetNewsBySlug(ctx, req)
+	}
+
+	return resp, err
+}
+
+func (s *NewsServer) UpdateNews(ctx context.Context, in *newsv1.UpdateNewsRequest) (*newsv1.UpdateNewsResponse, error) {
+	req := dto.UpdateNewsRequest{
+		ID:       in.GetId(),
+		Title:    in.GetTitle(),
+		Category: in.GetCategory(),
+		Live:     in.Live,
+		Content:  blocksFromProto(in.GetContent()),
+	}
+	if in.StartTime != nil {
+		startTime := timeFromProto(in.StartTime)
+		req.StartTime = &startTime
+	}
+	if in.EndTime != nil {
+		endTime := timeFromProto(in.EndTime)
+		req.EndTime = &endTime
+	}
+	if err := validate.Struct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.newsService.UpdateNews(ctx, req)
+	if err != nil {
+		return nil, newsError(err, "Failed to update news")
+	}
+
+	return &newsv1.UpdateNewsResponse{
+		Id:        resp.ID,
+		UpdatedAt: timestamppb.New(resp.UpdatedAt),
+		Message:   resp.Message,
+	}, nil
+}
+
+func (s *NewsServer) DeleteNews(ctx context.Context, in *newsv1.DeleteNewsRequest) (*newsv1.DeleteNewsResponse, error) {
+	req := dto.DeleteNewsRequest{ID: in.GetId()}
+	if err := validate.Struct(req); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := s.newsService.DeleteNews(ctx, req)
+	if err != nil {
+		return nil, newsError(err, "Failed to delete news")
+	}
+
+	return &newsv1.DeleteNewsResponse{
+		Id:      resp.ID,
+		Message: resp.Message,
+	}, nil
+}
+
+func (s *NewsServer) ListNews(ctx context.Context, in *newsv1.ListNewsRequest) (*newsv1.ListNewsResponse, error) {
+	req, err := listRequest(in, 10)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.newsService.ListNews(ctx, req)
+	if err != nil {
+		return nil, newsError(err, "Failed to list news")
+	}
+
+	items := make([]*newsv1.News, len(resp.Items))
+	for i := range resp.Items {
+		items[i] = newsToProto(&resp.Items[i])
+	}
+
+	return &newsv1.ListNewsResponse{
+		Items:      items,
+		TotalCount: resp.TotalCount,
+		Page:       int32(resp.Page),
+		Limit:      int32(resp.Limit),
+	}, nil
+}
+
+// StreamNews читает новости постранично и отправляет их по одной. Страницы
+// читаются по смещению, поэтому новости, созданные во время выгрузки, могут
+// попасть в неё дважды или не попасть вовсе.
+func (s *NewsServer) StreamNews(in *newsv1.ListNewsRequest, stream grpc.ServerStreamingServer[newsv1.News]) error {
+	const op = "grpc.NewsServer.StreamNews"
+
+	req, err := listRequest(in, streamPageSize)
+	if err != nil {
+		return err
+	}
+	req.Page = 1
+
+	ctx := stream.Context()
+	sent := 0
+	for {
+		resp, err := s.newsService.ListNews(ctx, req)
+		if err != nil {
+			return newsError(err, "Failed to list news")
+		}
+
+		for i := range resp.Items {
+			if err := stream.Send(newsToProto(&resp.Items[i])); err != nil {
+				return err
+			}
+			sent++
+		}
+
+		if len(resp.Items) < req.Limit || int64(req.Page*req.Limit) >= resp.TotalCount {
+			break
+		}
+		req.Page++
+	}
+
+	logger.Log.Info(op, "sent", sent)
+	return nil
+}
+
+// listRequest заполняет значения по умолчанию так же, как ListNews в handlers/v1.
+func listRequest(in *newsv1.ListNewsRequest, defaultLimit int) (dto.NewsListRequest, error) {
+	req := dto.NewsListRequest{
+		Page:            int(in.GetPage()),
+		Limit:           int(in.GetLimit()),
+		Search:          in.GetSearch(),
+		Category:        in.GetCategory(),
+		SortBy:          in.GetSortBy(),
+		SortDir:         in.GetSortDir(),
+		CheckVisibility: !in.GetIncludeHidden(),
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = defaultLimit
+	}
+	if req.SortBy == "" {
+		req.SortBy = "created_at"
+	}
+	if req.SortDir == "" {
+		req.SortDir = "desc"
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return req, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return req, nil
+}
+
+// newsError переводит ошибку сервиса в статус gRPC. Коды соответствуют
+// HTTP-статусам handlers/v1: 404 — NotFound, 400 — InvalidArgument.
+func newsError(err error, message string) error {
+	var numErr *strconv.NumError
+
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		return status.Error(codes.NotFound, "News not found")
+	case errors.Is(err, service.ErrInvalidMediaReference):
+		return status.Errorf(codes.InvalidArgument, "Invalid content block: %v", err)
+	case errors.As(err, &numErr):
+		return status.Errorf(codes.InvalidArgument, "Invalid news id: %v", err)
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return status.Errorf(codes.Internal, "%s: %v", message, err)
+}
+
+func blocksFromProto(blocks []*newsv1.ContentBlockInput) []dto.CreateContentBlock {
+	result := make([]dto.CreateContentBlock, len(blocks))
+	for i, block := range blocks {
+		result[i] = dto.CreateContentBlock{
+			Type:     block.GetType(),
+			Content:  block.GetContent(),
+			MediaID:  block.GetMediaId(),
+			Position: int(block.GetPosition()),
+		}
+	}
+	return result
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func newsToProto(news *dto.NewsResponse) *newsv1.News {
+	content := make([]*newsv1.ContentBlock, len(news.Content))
+	for i, block := range news.Content {
+		variants := make([]*newsv1.MediaVariant, len(block.Variants))
+		for j, variant := range block.Variants {
+			variants[j] = &newsv1.MediaVariant{
+				Url:      variant.URL,
+				Width:    int32(variant.Width),
+				Height:   int32(variant.Height),
+				Format:   variant.Format,
+				MimeType: variant.MimeType,
+				Size:     variant.Size,
+			}
+		}
+
+		content[i] = &newsv1.ContentBlock{
+			Id:       block.ID,
+			Type:     block.Type,
+			Content:  block.Content,
+			MediaId:  block.MediaID,
+			MediaUrl: block.MediaURL,
+			Variants: variants,
+			Srcset:   block.Srcset,
+			Position: int32(block.Position),
+		}
+	}
+
+	return &newsv1.News{
+		Id:        news.ID,
+		Title:     news.Title,
+		Slug:      news.Slug,
+		Category:  news.Category,
+		Live:      news.Live,
+		Content:   content,
+		CreatedAt: timestamppb.New(news.CreatedAt),
+		UpdatedAt: timestamppb.New(news.UpdatedAt),
+		StartTime: timestamppb.New(news.StartTime),
+		EndTime:   timestamppb.New(news.EndTime),
+	}
+}
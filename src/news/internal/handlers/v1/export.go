@@ -0,0 +1,127 @@
+package v1
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+var exportContentTypes = map[string]string{
+	dto.ArchiveFormatNDJSON: "application/x-ndjson",
+	dto.ArchiveFormatCSV:    "text/csv; charset=utf-8",
+}
+
+type ExportService interface {
+	ExportNews(ctx context.Context, req dto.NewsExportRequest, w io.Writer) error
+}
+
+type ExportHandler struct {
+	exportService ExportService
+	writeTimeout  time.Duration
+}
+
+func NewExportHandler(exportService ExportService, writeTimeout time.Duration) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		writeTimeout:  writeTimeout,
+	}
+}
+
+// RegisterRoutes регистрирует выгрузку архива. Должен вызываться раньше маршрутов
+// новостей, иначе запрос к /news/export перехватит /news/:id.
+func (h *ExportHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/news/export", h.Export)
+}
+
+func (h *ExportHandler) Export(c *fiber.Ctx) error {
+	const op = "v1.ExportHandler.Export"
+
+	var req dto.NewsExportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+	req.CheckVisibility = c.QueryBool("check_visibility", true)
+	if req.Format == "" {
+		req.Format = dto.ArchiveFormatNDJSON
+	}
+	if req.SortBy == "" {
+		req.SortBy = "created_at"
+	}
+	if req.SortDir == "" {
+		req.SortDir = "desc"
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Error:   err.Error(),
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, exportContentTypes[req.Format])
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="news.`+req.Format+`"`)
+	c.Set(fiber.HeaderVary, fiber.HeaderAcceptEncoding)
+	// Без Accept-Encoding AcceptsEncodings соглашается на что угодно, а сжатие
+	// нужно только клиентам, которые попросили о нём явно.
+	compress := c.Get(fiber.HeaderAcceptEncoding) != "" && c.AcceptsEncodings("gzip") == "gzip"
+	if compress {
+		c.Set(fiber.HeaderContentEncoding, "gzip")
+	}
+
+	// Ctx недоступен после выхода из обработчика, поэтому контекст запроса
+	// и соединение берутся заранее.
+	ctx := c.Context()
+	conn := c.Context().Conn()
+
+	c.Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		var w io.Writer = &deadlineWriter{w: bw, conn: conn, timeout: h.writeTimeout}
+
+		var gz *gzip.Writer
+		if compress {
+			gz = gzip.NewWriter(w)
+			w = gz
+		}
+
+		// Статус уже отправлен, поэтому ошибку посреди выгрузки остаётся только
+		// залогировать: клиент увидит оборванный файл.
+		if err := h.exportService.ExportNews(ctx, req, w); err != nil {
+			logger.Log.Error(op, "Export aborted", err)
+			return
+		}
+		if gz != nil {
+			if err := gz.Close(); err != nil {
+				logger.Log.Error(op, "Failed to finish gzip stream", err)
+				return
+			}
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(h.writeTimeout))
+		_ = bw.Flush()
+	})
+
+	return nil
+}
+
+// deadlineWriter продлевает дедлайн записи в соединение перед каждой записью:
+// WriteTimeout сервера рассчитан на обычные ответы, а выгрузка может идти долго.
+type deadlineWriter struct {
+	w       *bufio.Writer
+	conn    net.Conn
+	timeout time.Duration
+}
+
+func (d *deadlineWriter) Write(p []byte) (int, error) {
+	_ = d.conn.SetWriteDeadline(time.Now().Add(d.timeout))
+	return d.w.Write(p)
+}
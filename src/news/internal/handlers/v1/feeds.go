@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+)
+
+type FeedService interface {
+	GetFeed(ctx context.Context, req dto.FeedRequest) (*dto.FeedResponse, error)
+}
+
+type FeedHandler struct {
+	feedService FeedService
+}
+
+func NewFeedHandler(feedService FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// RegisterRoutes регистрирует ленты вне версионированного API: их адреса
+// раздаются партнёрам и не должны меняться вместе с версией API.
+func (h *FeedHandler) RegisterRoutes(router fiber.Router) {
+	feeds := router.Group("/feeds")
+
+	feeds.Get("/rss.xml", h.feed(dto.FeedFormatRSS))
+	feeds.Get("/atom.xml", h.feed(dto.FeedFormatAtom))
+	feeds.Get("/feed.json", h.feed(dto.FeedFormatJSON))
+}
+
+func (h *FeedHandler) feed(format string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx := c.Context()
+
+		req := dto.FeedRequest{
+			Format:   format,
+			Category: c.Query("category"),
+		}
+
+		if err := validate.Struct(req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Validation failed",
+				Error:   err.Error(),
+			})
+		}
+
+		resp, err := h.feedService.GetFeed(ctx, req)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusInternalServerError,
+				Message: "Failed to build feed",
+				Error:   err.Error(),
+			})
+		}
+
+		c.Set(fiber.HeaderETag, resp.ETag)
+		if !resp.LastModified.IsZero() {
+			c.Set(fiber.HeaderLastModified, resp.LastModified.UTC().Format(http.TimeFormat))
+		}
+		c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+		if notModified(c, resp.ETag, resp.LastModified) {
+			return c.SendStatus(fiber.StatusNotModified)
+		}
+
+		c.Set(fiber.HeaderContentType, resp.ContentType)
+		return c.Send(resp.Body)
+	}
+}
+
+// notModified проверяет условный GET: If-None-Match имеет приоритет над If-Modified-Since.
+func notModified(c *fiber.Ctx, etag string, lastModified time.Time) bool {
+	if inm := c.Get(fiber.HeaderIfNoneMatch); inm != "" {
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == etag || candidate == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := c.Get(fiber.HeaderIfModifiedSince); ims != "" {
+		since, err := http.ParseTime(ims)
+		return err == nil && !lastModified.IsZero() && !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
@@ -3,11 +3,16 @@ package v1
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
 
 	"github.com/go-playground/validator"
 	"github.com/gofiber/fiber/v2"
 	"github.com/zhavkk/news-service/src/news/internal/dto"
 	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
 )
 
 var validate = validator.New()
@@ -16,8 +21,11 @@ type NewsService interface {
 	CreateNews(ctx context.Context, req dto.CreateNewsRequest) (*dto.NewsResponse, error)
 	UpdateNews(ctx context.Context, req dto.UpdateNewsRequest) (*dto.UpdateNewsResponse, error)
 	GetNewsByID(ctx context.Context, req dto.GetNewsByIDRequest) (*dto.NewsResponse, error)
+	GetNewsBySlug(ctx context.Context, req dto.GetNewsBySlugRequest) (*dto.NewsResponse, error)
 	DeleteNews(ctx context.Context, req dto.DeleteNewsRequest) (*dto.DeleteNewsResponse, error)
 	ListNews(ctx context.Context, req dto.NewsListRequest) (*dto.NewsListResponse, error)
+	GetNewsBatch(ctx context.Context, req dto.NewsBatchRequest) (*dto.NewsBatchResponse, error)
+	BulkNews(ctx context.Context, req dto.BulkNewsRequest) (*dto.BulkNewsResponse, error)
 }
 
 type NewsHandler struct {
@@ -34,6 +42,10 @@ func (h *NewsHandler) RegisterRoutes(router fiber.Router) {
 	news := router.Group("/news")
 
 	news.Post("/", h.CreateNews)
+	news.Get("/by-slug/:slug", h.GetNewsBySlug)
+	news.Get("/batch", h.GetNewsBatch)
+	news.Post("/batch", h.GetNewsBatch)
+	news.Post("/bulk", h.BulkNews)
 	news.Get("/:id", h.GetNewsByID)
 	news.Put("/:id", h.UpdateNews)
 	news.Delete("/:id", h.DeleteNews)
@@ -62,6 +74,13 @@ func (h *NewsHandler) CreateNews(c *fiber.Ctx) error {
 
 	resp, err := h.newsService.CreateNews(ctx, req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidMediaReference) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid content block",
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to create news",
@@ -105,6 +124,13 @@ func (h *NewsHandler) UpdateNews(c *fiber.Ctx) error {
 				Error:   err.Error(),
 			})
 		}
+		if errors.Is(err, service.ErrInvalidMediaReference) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid content block",
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to update news",
@@ -151,6 +177,50 @@ func (h *NewsHandler) GetNewsByID(c *fiber.Ctx) error {
 	return c.JSON(resp)
 }
 
+func (h *NewsHandler) GetNewsBySlug(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	req := dto.GetNewsBySlugRequest{
+		Slug:            c.Params("slug"),
+		CheckVisibility: c.QueryBool("check_visibility", true),
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.newsService.GetNewsBySlug(ctx, req)
+	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			location := path.Dir(c.Path()) + "/" + url.PathEscape(moved.CurrentSlug)
+			if query := c.Request().URI().QueryString(); len(query) > 0 {
+				location += "?" + string(query)
+			}
+			return c.Redirect(location, fiber.StatusMovedPermanently)
+		}
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusNotFound,
+				Message: "News not found",
+				Error:   err.Error(),
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to get news",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.JSON(resp)
+}
+
 func (h *NewsHandler) DeleteNews(c *fiber.Ctx) error {
 	ctx := c.Context()
 	id := c.Params("id")
@@ -217,6 +287,13 @@ func (h *NewsHandler) ListNews(c *fiber.Ctx) error {
 
 	resp, err := h.newsService.ListNews(ctx, req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidFields) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid fields",
+				Error:   err.Error(),
+			})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
 			Status:  fiber.StatusInternalServerError,
 			Message: "Failed to list news",
@@ -226,3 +303,115 @@ func (h *NewsHandler) ListNews(c *fiber.Ctx) error {
 
 	return c.JSON(resp)
 }
+
+// GetNewsBatch принимает id через запятую в параметре ids (GET) или в теле запроса (POST).
+func (h *NewsHandler) GetNewsBatch(c *fiber.Ctx) error {
+	ctx := c.Context()
+	var req dto.NewsBatchRequest
+
+	if c.Method() == fiber.MethodPost {
+		if err := c.BodyParser(&req); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Invalid request body",
+				Error:   err.Error(),
+			})
+		}
+	} else if ids := c.Query("ids"); ids != "" {
+		for _, id := range strings.Split(ids, ",") {
+			req.IDs = append(req.IDs, strings.TrimSpace(id))
+		}
+	}
+	req.CheckVisibility = c.QueryBool("check_visibility", true)
+
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.newsService.GetNewsBatch(ctx, req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to get news",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *NewsHandler) BulkNews(c *fiber.Ctx) error {
+	ctx := c.Context()
+	var req dto.BulkNewsRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+	if req.Mode == "" {
+		req.Mode = dto.BulkModeAtomic
+	}
+	if req.ChunkSize == 0 {
+		req.ChunkSize = 100
+	}
+
+	for i := range req.Operations {
+		operation := &req.Operations[i]
+		if err := checkBulkOperation(operation); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Validation failed",
+				Error:   fmt.Sprintf("operation %d: %v", i, err),
+			})
+		}
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.newsService.BulkNews(ctx, req)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to apply bulk operations",
+			Error:   err.Error(),
+		})
+	}
+
+	if req.Mode == dto.BulkModeAtomic && resp.Failed > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(resp)
+	}
+	return c.JSON(resp)
+}
+
+// checkBulkOperation проверяет, что у операции заполнены нужные ей поля.
+func checkBulkOperation(operation *dto.BulkNewsOperation) error {
+	switch operation.Op {
+	case "create":
+		if operation.Create == nil {
+			return errors.New("create operation requires \"create\"")
+		}
+	case "update":
+		if operation.ID == "" || operation.Update == nil {
+			return errors.New("update operation requires \"id\" and \"update\"")
+		}
+		operation.Update.ID = operation.ID
+	case "delete":
+		if operation.ID == "" {
+			return errors.New("delete operation requires \"id\"")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+)
+
+type HealthService interface {
+	Readiness(ctx context.Context) *dto.ReadinessResponse
+}
+
+type HealthHandler struct {
+	healthService HealthService
+}
+
+func NewHealthHandler(healthService HealthService) *HealthHandler {
+	return &HealthHandler{
+		healthService: healthService,
+	}
+}
+
+// RegisterRoutes регистрирует проверки вне версионированного API: их адреса
+// прописаны в балансировщике и оркестраторе.
+func (h *HealthHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/readyz", h.Readiness)
+}
+
+// Readiness отвечает 200, если сервис готов принимать трафик, и 503 с
+// результатами проверок в остальных случаях.
+func (h *HealthHandler) Readiness(c *fiber.Ctx) error {
+	resp := h.healthService.Readiness(c.Context())
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	status := fiber.StatusOK
+	if resp.Status != dto.ReadinessOK {
+		status = fiber.StatusServiceUnavailable
+	}
+	return c.Status(status).JSON(resp)
+}
@@ -0,0 +1,138 @@
+package v1
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type ImportService interface {
+	StartImport(ctx context.Context, req dto.NewsImportRequest, r io.Reader, size int64) (*dto.ImportJobResponse, error)
+	GetImportJob(ctx context.Context, req dto.ImportJobIDRequest) (*dto.ImportJobResponse, error)
+}
+
+type ImportHandler struct {
+	importService ImportService
+}
+
+func NewImportHandler(importService ImportService) *ImportHandler {
+	return &ImportHandler{
+		importService: importService,
+	}
+}
+
+// RegisterRoutes регистрирует импорт. Должен вызываться раньше маршрутов
+// новостей, иначе запрос к /news/import/{id} перехватят маршруты /news/:id.
+func (h *ImportHandler) RegisterRoutes(router fiber.Router) {
+	router.Post("/news/import", h.StartImport)
+	router.Get("/news/import/:id", h.GetImportJob)
+}
+
+func (h *ImportHandler) StartImport(c *fiber.Ctx) error {
+	// При ошибке файл мог остаться недочитанным, а его остаток нельзя
+	// разбирать как следующий запрос соединения.
+	defer func() {
+		if c.Response().StatusCode() != fiber.StatusAccepted {
+			c.Context().SetConnectionClose()
+		}
+	}()
+
+	var req dto.NewsImportRequest
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+	if req.Format == "" {
+		req.Format = importFormatFromContentType(c.Get(fiber.HeaderContentType))
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+	if c.Request().Header.ContentLength() == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Empty import file",
+		})
+	}
+
+	// Сервер читает тела потоком (StreamRequestBody), так что файл идёт в
+	// задачу импорта по мере загрузки, не собираясь в памяти.
+	body := c.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(c.Body())
+	}
+
+	resp, err := h.importService.StartImport(c.Context(), req, body, int64(c.Request().Header.ContentLength()))
+	if err != nil {
+		if errors.Is(err, service.ErrImportFileTooLarge) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusRequestEntityTooLarge,
+				Message: "Import file is too large",
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, service.ErrEmptyImportFile) {
+			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusBadRequest,
+				Message: "Empty import file",
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to start import",
+			Error:   err.Error(),
+		})
+	}
+
+	c.Location("/api/v1/news/import/" + resp.ID)
+	return c.Status(fiber.StatusAccepted).JSON(resp)
+}
+
+func (h *ImportHandler) GetImportJob(c *fiber.Ctx) error {
+	req := dto.ImportJobIDRequest{ID: c.Params("id")}
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.importService.GetImportJob(c.Context(), req)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusNotFound,
+				Message: "Import job not found",
+				Error:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to get import job",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.JSON(resp)
+}
+
+// importFormatFromContentType позволяет не указывать format, если клиент
+// передал тип содержимого файла.
+func importFormatFromContentType(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "application/x-ndjson"):
+		return dto.ArchiveFormatNDJSON
+	case strings.HasPrefix(contentType, "text/csv"):
+		return dto.ArchiveFormatCSV
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,134 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+type JobService interface {
+	ListJobs(ctx context.Context, req dto.JobListRequest) (*dto.JobListResponse, error)
+	GetJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error)
+	RetryJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error)
+	CancelJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error)
+}
+
+type JobHandler struct {
+	jobService JobService
+}
+
+func NewJobHandler(jobService JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// RegisterRoutes регистрирует управление фоновыми задачами в админском API.
+func (h *JobHandler) RegisterRoutes(router fiber.Router) {
+	jobs := router.Group("/jobs")
+
+	jobs.Get("/", h.ListJobs)
+	jobs.Get("/:id", h.GetJob)
+	jobs.Post("/:id/retry", h.RetryJob)
+	jobs.Post("/:id/cancel", h.CancelJob)
+}
+
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	var req dto.JobListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.jobService.ListJobs(c.Context(), req)
+	if err != nil {
+		return jobError(c, err, "Failed to list jobs")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *JobHandler) GetJob(c *fiber.Ctx) error {
+	req := dto.JobIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.jobService.GetJob(c.Context(), req)
+	if err != nil {
+		return jobError(c, err, "Failed to get job")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *JobHandler) RetryJob(c *fiber.Ctx) error {
+	req := dto.JobIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.jobService.RetryJob(c.Context(), req)
+	if err != nil {
+		return jobError(c, err, "Failed to retry job")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *JobHandler) CancelJob(c *fiber.Ctx) error {
+	req := dto.JobIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.jobService.CancelJob(c.Context(), req)
+	if err != nil {
+		return jobError(c, err, "Failed to cancel job")
+	}
+
+	return c.JSON(resp)
+}
+
+func jobError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusNotFound,
+			Message: "Job not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, postgres.ErrInvalidJobState):
+		return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusConflict,
+			Message: message,
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+		Status:  fiber.StatusInternalServerError,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type LiveBlogService interface {
+	AppendEntry(ctx context.Context, req dto.CreateLiveEntryRequest) (*dto.LiveEntryResponse, error)
+	ListEntries(ctx context.Context, req dto.LiveEntryListRequest) (*dto.LiveEntryListResponse, error)
+	UpdateEntry(ctx context.Context, req dto.UpdateLiveEntryRequest) (*dto.LiveEntryResponse, error)
+	DeleteEntry(ctx context.Context, req dto.LiveEntryIDRequest) error
+}
+
+type LiveBlogHandler struct {
+	liveService LiveBlogService
+}
+
+func NewLiveBlogHandler(liveService LiveBlogService) *LiveBlogHandler {
+	return &LiveBlogHandler{
+		liveService: liveService,
+	}
+}
+
+func (h *LiveBlogHandler) RegisterRoutes(router fiber.Router) {
+	entries := router.Group("/news/:id/entries")
+
+	entries.Post("/", h.AppendEntry)
+	entries.Get("/", h.ListEntries)
+	entries.Put("/:entryId", h.UpdateEntry)
+	entries.Delete("/:entryId", h.DeleteEntry)
+}
+
+func (h *LiveBlogHandler) AppendEntry(c *fiber.Ctx) error {
+	var req dto.CreateLiveEntryRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+	req.NewsID = c.Params("id")
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.liveService.AppendEntry(c.Context(), req)
+	if err != nil {
+		return liveEntryError(c, err, "Failed to append live entry")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *LiveBlogHandler) ListEntries(c *fiber.Ctx) error {
+	var req dto.LiveEntryListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+	req.NewsID = c.Params("id")
+	if req.Limit == 0 {
+		req.Limit = 50
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.liveService.ListEntries(c.Context(), req)
+	if err != nil {
+		return liveEntryError(c, err, "Failed to list live entries")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *LiveBlogHandler) UpdateEntry(c *fiber.Ctx) error {
+	var req dto.UpdateLiveEntryRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+	req.NewsID = c.Params("id")
+	req.EntryID = c.Params("entryId")
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.liveService.UpdateEntry(c.Context(), req)
+	if err != nil {
+		return liveEntryError(c, err, "Failed to update live entry")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *LiveBlogHandler) DeleteEntry(c *fiber.Ctx) error {
+	req := dto.LiveEntryIDRequest{
+		NewsID:  c.Params("id"),
+		EntryID: c.Params("entryId"),
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	if err := h.liveService.DeleteEntry(c.Context(), req); err != nil {
+		return liveEntryError(c, err, "Failed to delete live entry")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func liveEntryError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusNotFound,
+			Message: "News or live entry not found",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrNewsNotLive):
+		return c.Status(fiber.StatusConflict).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusConflict,
+			Message: "News is not a live blog",
+			Error:   err.Error(),
+		})
+	case errors.Is(err, service.ErrInvalidMediaReference):
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid content block",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+		Status:  fiber.StatusInternalServerError,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
@@ -0,0 +1,183 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type MediaService interface {
+	UploadMedia(ctx context.Context, req dto.UploadMediaRequest) (*dto.MediaResponse, error)
+	GetMedia(ctx context.Context, req dto.GetMediaRequest) (*dto.MediaResponse, error)
+	OpenMedia(ctx context.Context, req dto.GetMediaRequest) (*dto.MediaResponse, io.ReadCloser, error)
+	OpenMediaVariant(ctx context.Context, req dto.GetMediaVariantRequest) (*dto.MediaVariantResponse, io.ReadCloser, error)
+}
+
+type MediaHandler struct {
+	mediaService MediaService
+}
+
+func NewMediaHandler(mediaService MediaService) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+	}
+}
+
+func (h *MediaHandler) RegisterRoutes(router fiber.Router) {
+	media := router.Group("/media")
+
+	media.Post("/", h.UploadMedia)
+	media.Get("/:id", h.GetMedia)
+}
+
+// RegisterFileRoutes регистрирует отдачу самих файлов вне версионированного API.
+func (h *MediaHandler) RegisterFileRoutes(router fiber.Router) {
+	router.Get("/media/:id", h.ServeMedia)
+	router.Get("/media/:id/variants/:width/:format", h.ServeMediaVariant)
+}
+
+func (h *MediaHandler) UploadMedia(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Missing file in multipart form",
+			Error:   err.Error(),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Failed to read uploaded file",
+			Error:   err.Error(),
+		})
+	}
+	defer file.Close()
+
+	resp, err := h.mediaService.UploadMedia(ctx, dto.UploadMediaRequest{
+		FileName: fileHeader.Filename,
+		Size:     fileHeader.Size,
+		File:     file,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrMediaTooLarge) {
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusRequestEntityTooLarge,
+				Message: "File is too large",
+				Error:   err.Error(),
+			})
+		}
+		if errors.Is(err, service.ErrUnsupportedMediaType) {
+			return c.Status(fiber.StatusUnsupportedMediaType).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusUnsupportedMediaType,
+				Message: "Unsupported file type",
+				Error:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to upload media",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *MediaHandler) GetMedia(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	req := dto.GetMediaRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Validation failed",
+			Error:   err.Error(),
+		})
+	}
+
+	resp, err := h.mediaService.GetMedia(ctx, req)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusNotFound,
+				Message: "Media not found",
+				Error:   err.Error(),
+			})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusInternalServerError,
+			Message: "Failed to get media",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *MediaHandler) ServeMedia(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	req := dto.GetMediaRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	meta, file, err := h.mediaService.OpenMedia(ctx, req)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	// Содержимое по id никогда не меняется, поэтому кешируем навсегда.
+	etag := `"` + meta.Checksum + `"`
+	c.Set(fiber.HeaderETag, etag)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+
+	if notModified(c, etag, meta.CreatedAt) {
+		_ = file.Close()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, meta.MimeType)
+	return c.SendStream(file, int(meta.Size))
+}
+
+func (h *MediaHandler) ServeMediaVariant(c *fiber.Ctx) error {
+	ctx := c.Context()
+
+	req := dto.GetMediaVariantRequest{
+		ID:     c.Params("id"),
+		Width:  c.Params("width"),
+		Format: c.Params("format"),
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return c.SendStatus(fiber.StatusBadRequest)
+	}
+
+	meta, file, err := h.mediaService.OpenMediaVariant(ctx, req)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+
+	c.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	c.Set(fiber.HeaderContentType, meta.MimeType)
+	return c.SendStream(file, int(meta.Size))
+}
@@ -0,0 +1,70 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+type SitemapService interface {
+	WriteIndex(ctx context.Context, w io.Writer) error
+	WriteChunk(ctx context.Context, w io.Writer, chunk int) error
+	WriteGoogleNews(ctx context.Context, w io.Writer) error
+}
+
+type SitemapHandler struct {
+	sitemapService SitemapService
+}
+
+func NewSitemapHandler(sitemapService SitemapService) *SitemapHandler {
+	return &SitemapHandler{
+		sitemapService: sitemapService,
+	}
+}
+
+func (h *SitemapHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/sitemap.xml", h.Index)
+	router.Get("/sitemaps/google-news.xml", h.GoogleNews)
+	router.Get("/sitemaps/news-:chunk.xml", h.Chunk)
+}
+
+func (h *SitemapHandler) Index(c *fiber.Ctx) error {
+	return h.write(c, func(ctx context.Context, w io.Writer) error {
+		return h.sitemapService.WriteIndex(ctx, w)
+	})
+}
+
+func (h *SitemapHandler) GoogleNews(c *fiber.Ctx) error {
+	return h.write(c, func(ctx context.Context, w io.Writer) error {
+		return h.sitemapService.WriteGoogleNews(ctx, w)
+	})
+}
+
+func (h *SitemapHandler) Chunk(c *fiber.Ctx) error {
+	chunk, err := strconv.Atoi(c.Params("chunk"))
+	if err != nil {
+		return c.SendStatus(fiber.StatusNotFound)
+	}
+
+	return h.write(c, func(ctx context.Context, w io.Writer) error {
+		return h.sitemapService.WriteChunk(ctx, w, chunk)
+	})
+}
+
+func (h *SitemapHandler) write(c *fiber.Ctx, render func(ctx context.Context, w io.Writer) error) error {
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationXMLCharsetUTF8)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=600")
+
+	if err := render(c.Context(), c); err != nil {
+		c.Response().ResetBody()
+		if errors.Is(err, postgres.ErrNotFound) {
+			return c.SendStatus(fiber.StatusNotFound)
+		}
+		return c.SendStatus(fiber.StatusInternalServerError)
+	}
+	return nil
+}
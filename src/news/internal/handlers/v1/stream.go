@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/metrics"
+)
+
+type StreamService interface {
+	Subscribe(lastEventID, category string) (*events.Subscription, []events.Envelope, bool)
+	Watch(topics *events.Topics, dropSlow bool) *events.Subscription
+	Heartbeat() time.Duration
+	Retry() time.Duration
+}
+
+type StreamHandler struct {
+	streamService StreamService
+}
+
+func NewStreamHandler(streamService StreamService) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+	}
+}
+
+// RegisterRoutes регистрирует SSE-поток. Должен вызываться раньше маршрутов
+// новостей, иначе запрос к /news/stream перехватит /news/:id.
+func (h *StreamHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/news/stream", h.Stream)
+}
+
+func (h *StreamHandler) Stream(c *fiber.Ctx) error {
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		// При первом подключении EventSource не может задать заголовок,
+		// поэтому ID принимается и в параметре запроса.
+		lastEventID = c.Query("last_event_id")
+	}
+
+	sub, backlog, resumed := h.streamService.Subscribe(lastEventID, c.Query("category"))
+	if sub == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusServiceUnavailable,
+			Message: "News stream is not available",
+			Error:   "server is shutting down",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	heartbeat := h.streamService.Heartbeat()
+	retry := h.streamService.Retry()
+	// Ctx недоступен после выхода из обработчика, поэтому соединение берётся заранее.
+	conn := c.Context().Conn()
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		metrics.StreamConnections.WithLabelValues(metrics.TransportSSE).Inc()
+		defer metrics.StreamConnections.WithLabelValues(metrics.TransportSSE).Dec()
+
+		// WriteTimeout сервера рассчитан на обычные ответы, для потока дедлайн
+		// продлевается перед каждой записью.
+		flush := func() bool {
+			_ = conn.SetWriteDeadline(time.Now().Add(heartbeat + time.Second))
+			return w.Flush() == nil
+		}
+
+		fmt.Fprintf(w, "retry: %d\n\n", retry.Milliseconds())
+		if !resumed {
+			w.WriteString("event: reset\ndata: {}\n\n")
+		}
+		for _, env := range backlog {
+			writeEvent(w, env)
+		}
+		if !flush() {
+			return
+		}
+
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case env, ok := <-sub.Events():
+				if !ok {
+					// Клиент не успевал читать или сервер останавливается: клиент
+					// переподключится и догонит пропущенное по Last-Event-ID.
+					if sub.Lagged() {
+						metrics.StreamSlowDisconnects.WithLabelValues(metrics.TransportSSE).Inc()
+					}
+					return
+				}
+				writeEvent(w, env)
+				metrics.StreamMessagesSent.WithLabelValues(metrics.TransportSSE).Inc()
+			case <-ticker.C:
+				w.WriteString(": ping\n\n")
+			}
+			if !flush() {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+func writeEvent(w *bufio.Writer, env events.Envelope) {
+	data, err := json.Marshal(env.Event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", env.ID, env.Event.Type, data)
+}
@@ -0,0 +1,214 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req dto.CreateWebhookRequest) (*dto.WebhookResponse, error)
+	ListWebhooks(ctx context.Context) (*dto.WebhookListResponse, error)
+	GetWebhook(ctx context.Context, req dto.WebhookIDRequest) (*dto.WebhookResponse, error)
+	UpdateWebhook(ctx context.Context, req dto.UpdateWebhookRequest) (*dto.WebhookResponse, error)
+	DeleteWebhook(ctx context.Context, req dto.WebhookIDRequest) error
+	ListDeliveries(ctx context.Context, req dto.WebhookDeliveryListRequest) (*dto.WebhookDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, req dto.WebhookIDRequest) (*dto.WebhookDeliveryResponse, error)
+	Redeliver(ctx context.Context, req dto.WebhookIDRequest) (*dto.WebhookDeliveryResponse, error)
+}
+
+type WebhookHandler struct {
+	webhookService WebhookService
+}
+
+func NewWebhookHandler(webhookService WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// RegisterRoutes регистрирует управление вебхуками в админском API.
+func (h *WebhookHandler) RegisterRoutes(router fiber.Router) {
+	webhooks := router.Group("/webhooks")
+
+	webhooks.Post("/", h.CreateWebhook)
+	webhooks.Get("/", h.ListWebhooks)
+	webhooks.Get("/deliveries/:id", h.GetDelivery)
+	webhooks.Post("/deliveries/:id/redeliver", h.Redeliver)
+	webhooks.Get("/:id", h.GetWebhook)
+	webhooks.Put("/:id", h.UpdateWebhook)
+	webhooks.Delete("/:id", h.DeleteWebhook)
+	webhooks.Get("/:id/deliveries", h.ListDeliveries)
+}
+
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req dto.CreateWebhookRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.CreateWebhook(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to create webhook")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	resp, err := h.webhookService.ListWebhooks(c.Context())
+	if err != nil {
+		return webhookError(c, err, "Failed to list webhooks")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *WebhookHandler) GetWebhook(c *fiber.Ctx) error {
+	req := dto.WebhookIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.GetWebhook(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to get webhook")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *WebhookHandler) UpdateWebhook(c *fiber.Ctx) error {
+	var req dto.UpdateWebhookRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid request body",
+			Error:   err.Error(),
+		})
+	}
+	req.ID = c.Params("id")
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.UpdateWebhook(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to update webhook")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	req := dto.WebhookIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Context(), req); err != nil {
+		return webhookError(c, err, "Failed to delete webhook")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	var req dto.WebhookDeliveryListRequest
+
+	if err := c.QueryParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusBadRequest,
+			Message: "Invalid query parameters",
+			Error:   err.Error(),
+		})
+	}
+	req.SubscriptionID = c.Params("id")
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 20
+	}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.ListDeliveries(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to list webhook deliveries")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	req := dto.WebhookIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.GetDelivery(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to get webhook delivery")
+	}
+
+	return c.JSON(resp)
+}
+
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	req := dto.WebhookIDRequest{ID: c.Params("id")}
+
+	if err := validate.Struct(req); err != nil {
+		return validationFailed(c, err)
+	}
+
+	resp, err := h.webhookService.Redeliver(c.Context(), req)
+	if err != nil {
+		return webhookError(c, err, "Failed to redeliver webhook")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(resp)
+}
+
+func validationFailed(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
+		Status:  fiber.StatusBadRequest,
+		Message: "Validation failed",
+		Error:   err.Error(),
+	})
+}
+
+func webhookError(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, postgres.ErrNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusNotFound,
+			Message: "Webhook not found",
+			Error:   err.Error(),
+		})
+	}
+
+	return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
+		Status:  fiber.StatusInternalServerError,
+		Message: message,
+		Error:   err.Error(),
+	})
+}
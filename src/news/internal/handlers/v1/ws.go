@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/metrics"
+)
+
+// wsReplyBuffer — сколько ответов на команды может ждать отправки.
+const wsReplyBuffer = 16
+
+type WebSocketHandler struct {
+	streamService StreamService
+	cfg           config.WebSocketConfig
+	writePool     *sync.Pool
+}
+
+func NewWebSocketHandler(streamService StreamService, cfg config.WebSocketConfig) *WebSocketHandler {
+	return &WebSocketHandler{
+		streamService: streamService,
+		cfg:           cfg,
+		writePool:     &sync.Pool{},
+	}
+}
+
+func (h *WebSocketHandler) RegisterRoutes(router fiber.Router) {
+	router.Get("/ws", h.Upgrade, websocket.New(h.Serve, websocket.Config{
+		// Буферы записи берутся из пула: большинство соединений простаивает.
+		WriteBufferPool: h.writePool,
+	}))
+}
+
+// Upgrade проверяет токен клиента до установки соединения. Токен передаётся
+// в заголовке Authorization или, для браузеров, в параметре token.
+func (h *WebSocketHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return c.Status(fiber.StatusUpgradeRequired).JSON(dto.ErrorResponse{
+			Status:  fiber.StatusUpgradeRequired,
+			Message: "WebSocket upgrade required",
+			Error:   "expected a websocket handshake",
+		})
+	}
+
+	if h.cfg.Token != "" {
+		token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok {
+			token = c.Query("token")
+		}
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.Token)) != 1 {
+			metrics.WebSocketAuthFailures.Inc()
+			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
+				Status:  fiber.StatusUnauthorized,
+				Message: "Unauthorized",
+				Error:   "invalid or missing token",
+			})
+		}
+	}
+
+	return c.Next()
+}
+
+// Serve обслуживает одно соединение: читает команды клиента и отправляет ему
+// события по выбранным темам. Писать в соединение может только одна горутина,
+// поэтому ответы на команды передаются ей через канал.
+func (h *WebSocketHandler) Serve(conn *websocket.Conn) {
+	topics := events.NewTopics(h.cfg.MaxTopics)
+	sub := h.streamService.Watch(topics, h.cfg.SlowConsumer != "disconnect")
+	if sub == nil {
+		h.closeWith(conn, websocket.CloseTryAgainLater, "server is shutting down")
+		return
+	}
+	defer sub.Close()
+
+	metrics.StreamConnections.WithLabelValues(metrics.TransportWebSocket).Inc()
+	defer metrics.StreamConnections.WithLabelValues(metrics.TransportWebSocket).Dec()
+
+	replies := make(chan dto.WSMessage, wsReplyBuffer)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.read(conn, topics, replies)
+	}()
+
+	h.write(conn, sub, replies, done)
+	// Закрытие соединения прерывает чтение, если оно ещё идёт.
+	_ = conn.Close()
+	<-done
+}
+
+func (h *WebSocketHandler) read(conn *websocket.Conn, topics *events.Topics, replies chan<- dto.WSMessage) {
+	conn.SetReadLimit(h.cfg.MaxMessageSize)
+	// Клиент обязан отвечать на ping, иначе соединение считается мёртвым.
+	_ = conn.SetReadDeadline(time.Now().Add(2 * h.cfg.PingInterval))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * h.cfg.PingInterval))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		reply := h.handleCommand(data, topics)
+		select {
+		case replies <- reply:
+		default:
+			// Клиент шлёт команды быстрее, чем читает ответы.
+			return
+		}
+	}
+}
+
+func (h *WebSocketHandler) handleCommand(data []byte, topics *events.Topics) dto.WSMessage {
+	var cmd dto.WSCommand
+	if err := json.Unmarshal(data, &cmd); err != nil {
+		return dto.WSMessage{Type: "error", Error: "invalid message: " + err.Error()}
+	}
+	if err := validate.Struct(cmd); err != nil {
+		return dto.WSMessage{Type: "error", Error: err.Error()}
+	}
+
+	switch cmd.Action {
+	case "subscribe":
+		if err := topics.Add(cmd.Topics...); err != nil {
+			return dto.WSMessage{Type: "error", Error: err.Error()}
+		}
+		return dto.WSMessage{Type: "subscribed", Topics: topics.List()}
+	case "unsubscribe":
+		if err := topics.Remove(cmd.Topics...); err != nil {
+			return dto.WSMessage{Type: "error", Error: err.Error()}
+		}
+		return dto.WSMessage{Type: "unsubscribed", Topics: topics.List()}
+	case "list":
+		return dto.WSMessage{Type: "topics", Topics: topics.List()}
+	default:
+		return dto.WSMessage{Type: "pong"}
+	}
+}
+
+func (h *WebSocketHandler) write(
+	conn *websocket.Conn,
+	sub *events.Subscription,
+	replies <-chan dto.WSMessage,
+	done <-chan struct{},
+) {
+	ping := time.NewTicker(h.cfg.PingInterval)
+	defer ping.Stop()
+
+	send := func(msg dto.WSMessage) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(h.cfg.PingInterval))
+		return conn.WriteJSON(msg) == nil
+	}
+
+	for {
+		select {
+		case <-done:
+			return
+		case reply := <-replies:
+			if !send(reply) {
+				return
+			}
+		case env, ok := <-sub.Events():
+			if !ok {
+				if sub.Lagged() {
+					metrics.StreamSlowDisconnects.WithLabelValues(metrics.TransportWebSocket).Inc()
+					h.closeWith(conn, websocket.CloseTryAgainLater, "client is too slow")
+				} else {
+					h.closeWith(conn, websocket.CloseGoingAway, "server is shutting down")
+				}
+				return
+			}
+			if dropped := sub.TakeDropped(); dropped > 0 {
+				metrics.StreamDroppedEvents.WithLabelValues(metrics.TransportWebSocket).Add(float64(dropped))
+				if !send(dto.WSMessage{Type: "dropped", Dropped: dropped}) {
+					return
+				}
+			}
+			event := env.Event
+			if !send(dto.WSMessage{Type: "event", ID: env.ID, Event: &event}) {
+				return
+			}
+			metrics.StreamMessagesSent.WithLabelValues(metrics.TransportWebSocket).Inc()
+		case <-ping.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.cfg.PingInterval))
+			if err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *WebSocketHandler) closeWith(conn *websocket.Conn, code int, text string) {
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, text),
+		time.Now().Add(time.Second),
+	)
+}
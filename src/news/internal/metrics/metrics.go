@@ -0,0 +1,76 @@
+// Package metrics содержит Prometheus-метрики сервиса. Они регистрируются
+// в реестре по умолчанию и отдаются на /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "news"
+
+// Значения метки transport.
+const (
+	TransportSSE       = "sse"
+	TransportWebSocket = "ws"
+)
+
+var (
+	// StreamConnections — открытые соединения живой ленты.
+	StreamConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_connections",
+		Help:      "Open live update connections.",
+	}, []string{"transport"})
+
+	// StreamMessagesSent — события, отправленные клиентам живой ленты.
+	StreamMessagesSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_messages_sent_total",
+		Help:      "Events sent to live update clients.",
+	}, []string{"transport"})
+
+	// StreamDroppedEvents — события, не доставленные медленным клиентам.
+	StreamDroppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_dropped_events_total",
+		Help:      "Events skipped because the client was not reading fast enough.",
+	}, []string{"transport"})
+
+	// StreamSlowDisconnects — клиенты, отключённые из-за переполнения буфера.
+	StreamSlowDisconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "stream_slow_disconnects_total",
+		Help:      "Clients disconnected because their buffer overflowed.",
+	}, []string{"transport"})
+
+	// TxRetries — повторы транзакций после конфликта сериализации, взаимоблокировки
+	// или обрыва соединения.
+	TxRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tx_retries_total",
+		Help:      "Transactions re-run after a retryable failure.",
+	}, []string{"reason"})
+
+	// TxRetriesExhausted — транзакции, которые так и не удалось выполнить: кончились
+	// попытки или не хватило времени до дедлайна.
+	TxRetriesExhausted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tx_retries_exhausted_total",
+		Help:      "Transactions that failed with a retryable error after the last allowed attempt.",
+	}, []string{"reason"})
+
+	// BrokenLinks — битые ссылки, найденные последней проверкой ссылок в новостях.
+	BrokenLinks = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "broken_links",
+		Help:      "Broken links found in visible news by the last link check.",
+	})
+
+	// WebSocketAuthFailures — отклонённые из-за токена подключения к WebSocket.
+	WebSocketAuthFailures = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "ws_auth_failures_total",
+		Help:      "WebSocket connections rejected because of a missing or invalid token.",
+	})
+)
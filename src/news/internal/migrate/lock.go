@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// LockKey — ключ advisory-блокировки, под которой миграции применяются и
+// откатываются. Её берут и сервис при запуске, и newsctl migrate, поэтому
+// реплики и ручной запуск не применяют миграции одновременно.
+const LockKey int64 = 0x6e657773_0002
+
+// sessionLocker реализует lock.SessionLocker goose: ждёт блокировку на
+// соединении goose не дольше timeout.
+type sessionLocker struct {
+	timeout time.Duration
+}
+
+func (l sessionLocker) SessionLock(ctx context.Context, conn *sql.Conn) error {
+	lockCtx, cancel := context.WithTimeout(ctx, l.timeout)
+	defer cancel()
+
+	if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", LockKey); err != nil {
+		return fmt.Errorf("wait for migrations lock: %w", err)
+	}
+	return nil
+}
+
+func (l sessionLocker) SessionUnlock(ctx context.Context, conn *sql.Conn) error {
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", LockKey); err != nil {
+		return fmt.Errorf("release migrations lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Package migrate применяет SQL-миграции через goose. Версии хранятся в таблице
+// goose_db_version, поэтому базу можно по-прежнему обслуживать внешним goose.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+)
+
+var (
+	ErrNoMigrations = goose.ErrNoMigrations
+	// ErrSchemaTooNew — в базе применены миграции новее последней известной бинарнику,
+	// то есть базу уже обновила более новая версия сервиса.
+	ErrSchemaTooNew = errors.New("database schema is newer than the migrations in this binary")
+)
+
+// Migration — версия и имя файла миграции.
+type Migration struct {
+	Version int64
+	Name    string
+}
+
+// Status — миграция и время её применения; AppliedAt равен nil, если она не применена.
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// State — сводка по схеме: последняя применённая версия, последняя версия,
+// известная бинарнику, и число неприменённых миграций.
+type State struct {
+	Current int64
+	Latest  int64
+	Pending int
+}
+
+// TooNew сообщает, что база обновлена версией сервиса новее этой.
+func (s State) TooNew() bool {
+	return s.Current > s.Latest
+}
+
+type Migrator struct {
+	provider *goose.Provider
+}
+
+// New читает миграции из каталога dir файловой системы fsys. Разбор SQL,
+// включая аннотации StatementBegin/StatementEnd и NO TRANSACTION, выполняет goose.
+// Up, Down и Status ждут блокировку LockKey не дольше lockTimeout.
+func New(pool *pgxpool.Pool, fsys fs.FS, dir string, lockTimeout time.Duration) (*Migrator, error) {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	// Закрывать db не нужно: он не владеет соединениями пула.
+	db := stdlib.OpenDBFromPool(pool)
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, sub,
+		goose.WithDisableGlobalRegistry(true),
+		goose.WithSessionLocker(sessionLocker{timeout: lockTimeout}),
+		goose.WithLogger(goose.NopLogger()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{provider: provider}, nil
+}
+
+// Up применяет все неприменённые миграции по возрастанию версии и возвращает их.
+// Если база новее бинарника, ничего не применяется и возвращается ErrSchemaTooNew.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	state, err := m.State(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if state.TooNew() {
+		return nil, fmt.Errorf("%w: applied %d, known %d", ErrSchemaTooNew, state.Current, state.Latest)
+	}
+
+	results, err := m.provider.Up(ctx)
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = partial.Applied
+		err = fmt.Errorf("%s: %w", path.Base(partial.Failed.Source.Path), partial.Err)
+	}
+
+	done := make([]Migration, 0, len(results))
+	for _, result := range results {
+		done = append(done, migrationOf(result.Source))
+	}
+	return done, err
+}
+
+// Down откатывает последнюю применённую миграцию; nil, если откатывать нечего.
+func (m *Migrator) Down(ctx context.Context) (*Migration, error) {
+	result, err := m.provider.Down(ctx)
+	if errors.Is(err, goose.ErrNoNextVersion) {
+		return nil, nil
+	}
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		return nil, fmt.Errorf("%s: %w", path.Base(partial.Failed.Source.Path), partial.Err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	migration := migrationOf(result.Source)
+	return &migration, nil
+}
+
+// Status возвращает все известные миграции с отметкой о применении.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	results, err := m.provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(results))
+	for i, result := range results {
+		statuses[i] = Status{Migration: migrationOf(result.Source)}
+		if result.State == goose.StateApplied {
+			appliedAt := result.AppliedAt
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+	return statuses, nil
+}
+
+// State возвращает сводку по схеме без блокировки, чтобы проверка готовности
+// не ждала применения миграций. Схему не меняет, кроме создания таблицы
+// версий при первом обращении. Goose применяет миграции строго по порядку,
+// поэтому неприменённые — те, что новее текущей версии.
+func (m *Migrator) State(ctx context.Context) (State, error) {
+	current, latest, err := m.provider.GetVersions(ctx)
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{Current: current, Latest: latest}
+	for _, source := range m.provider.ListSources() {
+		if source.Version > current {
+			state.Pending++
+		}
+	}
+	return state, nil
+}
+
+func migrationOf(source *goose.Source) Migration {
+	return Migration{Version: source.Version, Name: path.Base(source.Path)}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+type NewsEventType string
+
+const (
+	NewsCreated   NewsEventType = "created"
+	NewsUpdated   NewsEventType = "updated"
+	NewsDeleted   NewsEventType = "deleted"
+	NewsPublished NewsEventType = "published"
+	NewsExpired   NewsEventType = "expired"
+
+	LiveEntryAdded   NewsEventType = "entry_added"
+	LiveEntryUpdated NewsEventType = "entry_updated"
+	LiveEntryDeleted NewsEventType = "entry_deleted"
+)
+
+// NewsEvent описывает изменение новости, о котором нужно сообщить подписчикам.
+// ID — номер записи в outbox, по нему потребители отбрасывают повторы.
+type NewsEvent struct {
+	ID         int64         `json:"id"`
+	Type       NewsEventType `json:"type"`
+	NewsID     int64         `json:"news_id"`
+	Slug       string        `json:"slug,omitempty"`
+	Category   string        `json:"category"`
+	EntryID    int64         `json:"entry_id,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// NewNewsEvent создаёт событие о новости с текущим временем.
+func NewNewsEvent(eventType NewsEventType, news *News) NewsEvent {
+	return NewsEvent{
+		Type:       eventType,
+		NewsID:     news.ID,
+		Slug:       news.Slug,
+		Category:   news.Category,
+		OccurredAt: time.Now(),
+	}
+}
+
+// NewLiveEntryEvent создаёт событие о записи live-блога новости.
+func NewLiveEntryEvent(eventType NewsEventType, news *News, entryID int64) NewsEvent {
+	event := NewNewsEvent(eventType, news)
+	event.EntryID = entryID
+	return event
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+type ImportJobStatus string
+
+const (
+	ImportPending   ImportJobStatus = "pending"
+	ImportRunning   ImportJobStatus = "running"
+	ImportSucceeded ImportJobStatus = "succeeded"
+	ImportFailed    ImportJobStatus = "failed"
+)
+
+// ImportJob — задача импорта новостей из файла. Счётчики и BytesRead
+// обновляются после каждой пачки записей, по ним клиент следит за прогрессом.
+// При DryRun Created и Updated считают новости, которые были бы созданы и изменены.
+type ImportJob struct {
+	ID         int64
+	Format     string
+	DryRun     bool
+	Status     ImportJobStatus
+	BytesTotal int64
+	BytesRead  int64
+	Processed  int
+	Created    int
+	Updated    int
+	Failed     int
+	Error      string
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+// ImportError — ошибка одной записи файла; Line — номер строки, с которой она начинается.
+type ImportError struct {
+	Line       int
+	ExternalID string
+	Error      string
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobFailed    JobStatus = "failed"
+	JobCanceled  JobStatus = "canceled"
+)
+
+// Job — фоновая задача из очереди. Attempts растёт при каждом захвате задачи
+// воркером; задача выполняется не раньше RunAt.
+type Job struct {
+	ID           int64
+	Kind         string
+	Payload      json.RawMessage
+	Status       JobStatus
+	Attempts     int
+	MaxAttempts  int
+	RunAt        time.Time
+	LockedUntil  *time.Time
+	LastError    string
+	ScheduleName string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	FinishedAt   *time.Time
+}
+
+// JobSchedule — задача по расписанию crontab; NextRunAt — когда её поставить в очередь.
+type JobSchedule struct {
+	Name      string
+	Kind      string
+	Schedule  string
+	Payload   json.RawMessage
+	NextRunAt time.Time
+}
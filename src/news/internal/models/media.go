@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+type Media struct {
+	ID         int64     `json:"id"`
+	StorageKey string    `json:"storage_key"`
+	FileName   string    `json:"file_name"`
+	MimeType   string    `json:"mime_type"`
+	Size       int64     `json:"size"`
+	Width      int       `json:"width"`
+	Height     int       `json:"height"`
+	Checksum   string    `json:"checksum"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type MediaVariant struct {
+	ID             int64     `json:"id"`
+	MediaID        int64     `json:"media_id"`
+	SourceChecksum string    `json:"source_checksum"`
+	Width          int       `json:"width"`
+	Height         int       `json:"height"`
+	Format         string    `json:"format"`
+	MimeType       string    `json:"mime_type"`
+	StorageKey     string    `json:"storage_key"`
+	Size           int64     `json:"size"`
+	CreatedAt      time.Time `json:"created_at"`
+}
@@ -5,9 +5,12 @@ import "time"
 type News struct {
 	ID        int64          `json:"id"`
 	Title     string         `json:"title"`
+	Slug      string         `json:"slug"`
 	Category  string         `json:"category"`
+	Live      bool           `json:"live"`
 	Content   []ContentBlock `json:"content"`
 	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
 	StartTime time.Time      `json:"start_time"`
 	EndTime   time.Time      `json:"end_time"`
 }
@@ -17,15 +20,49 @@ type ContentBlock struct {
 	NewsID    int64     `json:"news_id"`
 	Type      BlockType `json:"type"`
 	Content   string    `json:"content"`
+	MediaID   *int64    `json:"media_id,omitempty"`
 	Position  int       `json:"position"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
 type BlockType string
 
+// NewsFilter — условия выборки списка новостей.
+type NewsFilter struct {
+	Search          string
+	Category        string
+	SortBy          string
+	SortDir         string
+	CheckVisibility bool
+}
+
+// NewsCursor — место в отсортированном списке: значение поля сортировки и id
+// последней полученной новости. Следующая страница начинается строго после него.
+type NewsCursor struct {
+	Value string
+	ID    int64
+}
+
+// CategoryCount — категория и число новостей в ней.
+type CategoryCount struct {
+	Name  string
+	Count int64
+}
+
+// LiveEntry — запись live-блога: короткое сообщение со своими блоками контента.
+type LiveEntry struct {
+	ID        int64          `json:"id"`
+	NewsID    int64          `json:"news_id"`
+	Pinned    bool           `json:"pinned"`
+	Content   []ContentBlock `json:"content"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
 const (
-	TextBlock BlockType = "text"
-	LinkBlock BlockType = "link"
+	TextBlock  BlockType = "text"
+	LinkBlock  BlockType = "link"
+	ImageBlock BlockType = "image"
 )
 
 func (n *News) IsVisible() bool {
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"
+	OutboxPublished OutboxStatus = "published"
+	OutboxDead      OutboxStatus = "dead"
+)
+
+// OutboxEntry — событие в outbox вместе с состоянием доставки.
+type OutboxEntry struct {
+	Event       NewsEvent
+	Status      OutboxStatus
+	Attempts    int
+	LastError   string
+	AvailableAt time.Time
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// SitemapEntry — облегчённое представление новости для карты сайта, без блоков контента.
+type SitemapEntry struct {
+	ID        int64
+	Slug      string
+	Title     string
+	StartTime time.Time
+	LastMod   time.Time
+}
+
+// SitemapChunk описывает одну часть карты сайта: новости с id
+// в диапазоне ((Number-1)*size, Number*size].
+type SitemapChunk struct {
+	Number  int
+	LastMod time.Time
+}
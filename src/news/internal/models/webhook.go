@@ -0,0 +1,58 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription — подписка партнёра на события. Пустой EventTypes означает
+// все типы событий, пустой Category — все категории.
+type WebhookSubscription struct {
+	ID         int64
+	URL        string
+	EventTypes []NewsEventType
+	Category   string
+	Secret     string
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookPending   WebhookDeliveryStatus = "pending"
+	WebhookSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery — доставка одного события одной подписке.
+// URL и Secret заполняются при выборке доставки на отправку.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	EventType      NewsEventType
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastStatusCode int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	URL    string
+	Secret string
+}
+
+// WebhookAttempt — запись журнала об одной попытке доставки.
+type WebhookAttempt struct {
+	ID         int64
+	DeliveryID int64
+	Attempt    int
+	StatusCode int
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
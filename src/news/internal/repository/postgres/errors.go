@@ -12,4 +12,26 @@ var (
 	ErrFailedToDeleteContentBlocks = errors.New("failed to delete content blocks")
 	ErrNotFound                    = errors.New("not found")
 	ErrFailedToDeleteNews          = errors.New("failed to delete news")
+	ErrFailedToCreateMedia         = errors.New("failed to create media")
+	ErrFailedToGetMedia            = errors.New("failed to get media")
+	ErrFailedToCreateMediaVariant  = errors.New("failed to create media variant")
+	ErrFailedToGetMediaVariants    = errors.New("failed to get media variants")
+	ErrFailedToGetSitemap          = errors.New("failed to get sitemap entries")
+	ErrFailedToResolveSlug         = errors.New("failed to resolve slug")
+	ErrFailedToMoveSlug            = errors.New("failed to move slug")
+	ErrSlugTaken                   = errors.New("slug is taken by another news")
+	ErrFailedToGetSchedule         = errors.New("failed to get news schedule")
+	ErrFailedToSaveSchedule        = errors.New("failed to save news schedule")
+	ErrFailedToWriteOutbox         = errors.New("failed to write outbox")
+	ErrFailedToReadOutbox          = errors.New("failed to read outbox")
+	ErrFailedToSaveWebhook         = errors.New("failed to save webhook")
+	ErrFailedToGetWebhooks         = errors.New("failed to get webhooks")
+	ErrFailedToSaveLiveEntry       = errors.New("failed to save live entry")
+	ErrFailedToGetLiveEntries      = errors.New("failed to get live entries")
+	ErrFailedToSaveImportJob       = errors.New("failed to save import job")
+	ErrFailedToGetImportJobs       = errors.New("failed to get import jobs")
+	ErrFailedToLinkExternalID      = errors.New("failed to link external id")
+	ErrFailedToSaveJob             = errors.New("failed to save job")
+	ErrFailedToGetJobs             = errors.New("failed to get jobs")
+	ErrInvalidJobState             = errors.New("job is not in a state that allows this operation")
 )
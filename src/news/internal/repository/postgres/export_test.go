@@ -0,0 +1,4 @@
+package postgres
+
+// InsertContentBlocks открывает insertContentBlocks для бенчмарков.
+var InsertContentBlocks = insertContentBlocks
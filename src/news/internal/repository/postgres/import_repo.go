@@ -0,0 +1,372 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// ImportRepository хранит задачи импорта, их отчёт об ошибках и соответствие
+// внешних id новостей из других систем новостям сервиса.
+type ImportRepository struct {
+	storage *storage.Storage
+}
+
+func NewImportRepository(storage *storage.Storage) *ImportRepository {
+	return &ImportRepository{
+		storage: storage,
+	}
+}
+
+const importJobColumns = `id, format, dry_run, status, bytes_total, bytes_read, processed, created, updated, failed,
+    error, created_at, updated_at, finished_at`
+
+// importChunkSize — размер части, которыми файл импорта пишется в базу и читается обратно.
+const importChunkSize = 1 << 20
+
+// CreateJob создаёт задачу импорта. data — файл для фонового импорта, он
+// читается потоком и хранится частями до успешного завершения задачи;
+// job.BytesTotal становится его размером. nil, если файл читается сразу.
+// Ошибка чтения data возвращается как есть.
+func (r *ImportRepository) CreateJob(ctx context.Context, job *models.ImportJob, data io.Reader) error {
+	const op = "ImportRepository.CreateJob"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO import_jobs (format, dry_run, status, bytes_total)
+    VALUES ($1, $2, $3, $4)
+    RETURNING id, created_at, updated_at
+    `
+
+	err := tx.QueryRow(ctx, query, job.Format, job.DryRun, job.Status, job.BytesTotal).
+		Scan(&job.ID, &job.CreatedAt, &job.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to create import job", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+	}
+	if data == nil {
+		return nil
+	}
+
+	chunk := make([]byte, importChunkSize)
+	var total int64
+	for seq := 0; ; seq++ {
+		n, readErr := io.ReadFull(data, chunk)
+		if n > 0 {
+			_, err := tx.Exec(ctx, `INSERT INTO import_job_chunks (job_id, seq, data) VALUES ($1, $2, $3)`,
+				job.ID, seq, chunk[:n])
+			if err != nil {
+				logger.Log.Error(op, "Failed to save import file", err, "jobID", job.ID)
+				return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+			}
+			total += int64(n)
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+
+	job.BytesTotal = total
+	_, err = tx.Exec(ctx, `UPDATE import_jobs SET bytes_total = $2 WHERE id = $1`, job.ID, total)
+	if err != nil {
+		logger.Log.Error(op, "Failed to save import file size", err, "jobID", job.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+	}
+
+	return nil
+}
+
+// UpdateJob сохраняет статус, прогресс и счётчики задачи. Файл успешно
+// завершённой задачи больше не нужен и удаляется.
+func (r *ImportRepository) UpdateJob(ctx context.Context, job *models.ImportJob) error {
+	const op = "ImportRepository.UpdateJob"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE import_jobs
+    SET status = $2, bytes_read = $3, processed = $4, created = $5, updated = $6, failed = $7,
+        error = $8, finished_at = $9, updated_at = NOW()
+    WHERE id = $1
+    RETURNING updated_at
+    `
+
+	err := tx.QueryRow(ctx, query,
+		job.ID, job.Status, job.BytesRead, job.Processed, job.Created, job.Updated, job.Failed,
+		job.Error, job.FinishedAt,
+	).Scan(&job.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to update import job", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+	}
+
+	if job.Status == models.ImportSucceeded {
+		if _, err := tx.Exec(ctx, `DELETE FROM import_job_chunks WHERE job_id = $1`, job.ID); err != nil {
+			logger.Log.Error(op, "Failed to drop import file", err)
+			return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+		}
+	}
+
+	return nil
+}
+
+// AddErrors добавляет записи в отчёт задачи одним запросом.
+func (r *ImportRepository) AddErrors(ctx context.Context, jobID int64, importErrors []models.ImportError) error {
+	const op = "ImportRepository.AddErrors"
+
+	if len(importErrors) == 0 {
+		return nil
+	}
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	lines := make([]int32, len(importErrors))
+	externalIDs := make([]string, len(importErrors))
+	messages := make([]string, len(importErrors))
+	for i, importErr := range importErrors {
+		lines[i] = int32(importErr.Line)
+		externalIDs[i] = importErr.ExternalID
+		messages[i] = importErr.Error
+	}
+
+	query := `
+    INSERT INTO import_job_errors (job_id, line, external_id, error)
+    SELECT $1, e.* FROM unnest($2::int[], $3::text[], $4::text[]) AS e(line, external_id, error)
+    `
+
+	if _, err := tx.Exec(ctx, query, jobID, lines, externalIDs, messages); err != nil {
+		logger.Log.Error(op, "Failed to save import errors", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+	}
+
+	return nil
+}
+
+func (r *ImportRepository) GetJob(ctx context.Context, id int64) (*models.ImportJob, error) {
+	const op = "ImportRepository.GetJob"
+
+	query := `SELECT ` + importJobColumns + ` FROM import_jobs WHERE id = $1`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to get import job", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetImportJobs, err)
+	}
+
+	job, err := pgx.CollectExactlyOneRow(rows, scanImportJob)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to scan import job", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetImportJobs, err)
+	}
+
+	return job, nil
+}
+
+// GetJobData возвращает файл задачи; ErrNotFound, если задачи нет или файл уже
+// удалён. Части файла читаются по одной по мере чтения из reader.
+func (r *ImportRepository) GetJobData(ctx context.Context, id int64) (io.Reader, error) {
+	const op = "ImportRepository.GetJobData"
+
+	data := &importDataReader{ctx: ctx, db: r.storage.Reader(ctx), jobID: id}
+	if err := data.next(); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to get import job data", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetImportJobs, err)
+	}
+
+	return data, nil
+}
+
+// importDataReader читает файл задачи импорта часть за частью.
+type importDataReader struct {
+	ctx   context.Context
+	db    storage.Querier
+	jobID int64
+	seq   int
+	chunk []byte
+}
+
+func (r *importDataReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// next загружает следующую часть; io.EOF, если частей больше нет.
+func (r *importDataReader) next() error {
+	err := r.db.QueryRow(r.ctx, `SELECT data FROM import_job_chunks WHERE job_id = $1 AND seq = $2`, r.jobID, r.seq).
+		Scan(&r.chunk)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return io.EOF
+	}
+	if err != nil {
+		return err
+	}
+	r.seq++
+	return nil
+}
+
+// ClearErrors удаляет отчёт задачи перед повторным импортом файла.
+func (r *ImportRepository) ClearErrors(ctx context.Context, jobID int64) error {
+	const op = "ImportRepository.ClearErrors"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM import_job_errors WHERE job_id = $1`, jobID); err != nil {
+		logger.Log.Error(op, "Failed to clear import errors", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveImportJob, err)
+	}
+
+	return nil
+}
+
+// ListErrors возвращает до limit первых ошибок задачи в порядке строк файла.
+func (r *ImportRepository) ListErrors(ctx context.Context, jobID int64, limit int) ([]models.ImportError, error) {
+	const op = "ImportRepository.ListErrors"
+
+	query := `
+    SELECT line, external_id, error
+    FROM import_job_errors
+    WHERE job_id = $1
+    ORDER BY line, id
+    LIMIT $2
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, jobID, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to list import errors", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetImportJobs, err)
+	}
+
+	importErrors, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ImportError, error) {
+		var importErr models.ImportError
+		err := row.Scan(&importErr.Line, &importErr.ExternalID, &importErr.Error)
+		return importErr, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan import errors", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetImportJobs, err)
+	}
+
+	return importErrors, nil
+}
+
+// NewsIDsByExternalID возвращает id новостей, уже связанных с переданными
+// внешними id. Читает через транзакцию, чтобы видеть связи, созданные в ней же.
+func (r *ImportRepository) NewsIDsByExternalID(ctx context.Context, externalIDs []string) (map[string]int64, error) {
+	const op = "ImportRepository.NewsIDsByExternalID"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	query := `SELECT external_id, news_id FROM news_external_ids WHERE external_id = ANY($1)`
+
+	rows, err := tx.Query(ctx, query, externalIDs)
+	if err != nil {
+		logger.Log.Error(op, "Failed to resolve external ids", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+	defer rows.Close()
+
+	newsIDs := make(map[string]int64, len(externalIDs))
+	for rows.Next() {
+		var (
+			externalID string
+			newsID     int64
+		)
+		if err := rows.Scan(&externalID, &newsID); err != nil {
+			logger.Log.Error(op, "Failed to scan external id", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+		newsIDs[externalID] = newsID
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating rows", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	return newsIDs, nil
+}
+
+// LinkExternalID связывает новость с её внешним id.
+func (r *ImportRepository) LinkExternalID(ctx context.Context, externalID string, newsID int64) error {
+	const op = "ImportRepository.LinkExternalID"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `INSERT INTO news_external_ids (external_id, news_id) VALUES ($1, $2)`
+
+	if _, err := tx.Exec(ctx, query, externalID, newsID); err != nil {
+		logger.Log.Error(op, "Failed to link external id", err, "externalID", externalID)
+		return fmt.Errorf("%w: %v", ErrFailedToLinkExternalID, err)
+	}
+
+	return nil
+}
+
+func scanImportJob(row pgx.CollectableRow) (*models.ImportJob, error) {
+	var job models.ImportJob
+	err := row.Scan(
+		&job.ID,
+		&job.Format,
+		&job.DryRun,
+		&job.Status,
+		&job.BytesTotal,
+		&job.BytesRead,
+		&job.Processed,
+		&job.Created,
+		&job.Updated,
+		&job.Failed,
+		&job.Error,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.FinishedAt,
+	)
+	return &job, err
+}
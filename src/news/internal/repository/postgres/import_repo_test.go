@@ -0,0 +1,82 @@
+package postgres_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestImportRepository_Jobs(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	repo := postgres.NewImportRepository(db)
+	newsRepo := postgres.NewNewsRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	// Файл больше одной части хранится и читается по частям.
+	file := bytes.Repeat([]byte("{}\n"), 1<<20)
+	job := &models.ImportJob{Format: "ndjson", Status: models.ImportPending}
+	news := &models.News{Title: "Imported", Category: "Tech", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := repo.CreateJob(ctx, job, bytes.NewReader(file)); err != nil {
+			return err
+		}
+		if err := newsRepo.Create(ctx, news); err != nil {
+			return err
+		}
+		return repo.LinkExternalID(ctx, "legacy-1", news.ID)
+	}))
+
+	assert.Equal(t, int64(len(file)), job.BytesTotal)
+	data, err := repo.GetJobData(ctx, job.ID)
+	require.NoError(t, err)
+	content, err := io.ReadAll(data)
+	require.NoError(t, err)
+	assert.Equal(t, file, content)
+
+	finished := time.Now()
+	job.Status, job.BytesRead, job.Processed, job.Created, job.Failed, job.FinishedAt = models.ImportSucceeded, 100, 3, 1, 2, &finished
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := repo.UpdateJob(ctx, job); err != nil {
+			return err
+		}
+		return repo.AddErrors(ctx, job.ID, []models.ImportError{
+			{Line: 7, ExternalID: "b", Error: "second"},
+			{Line: 2, Error: "first"},
+		})
+	}))
+
+	_, err = repo.GetJobData(ctx, job.ID)
+	assert.ErrorIs(t, err, postgres.ErrNotFound, "file is dropped once the job succeeds")
+
+	stored, err := repo.GetJob(ctx, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.ImportSucceeded, stored.Status)
+	assert.Equal(t, int64(100), stored.BytesRead)
+	assert.Equal(t, 2, stored.Failed)
+	require.NotNil(t, stored.FinishedAt)
+
+	importErrors, err := repo.ListErrors(ctx, job.ID, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ImportError{{Line: 2, Error: "first"}, {Line: 7, ExternalID: "b", Error: "second"}}, importErrors)
+
+	var newsIDs map[string]int64
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		newsIDs, err = repo.NewsIDsByExternalID(ctx, []string{"legacy-1", "missing"})
+		return err
+	}))
+	assert.Equal(t, map[string]int64{"legacy-1": news.ID}, newsIDs)
+
+	_, err = repo.GetJob(ctx, job.ID+1)
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,437 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// JobRepository хранит очередь фоновых задач и расписания задач по crontab.
+type JobRepository struct {
+	storage *storage.Storage
+}
+
+func NewJobRepository(storage *storage.Storage) *JobRepository {
+	return &JobRepository{
+		storage: storage,
+	}
+}
+
+const jobColumns = `id, kind, payload, status, attempts, max_attempts, run_at, locked_until, last_error,
+    schedule_name, created_at, updated_at, finished_at`
+
+// Enqueue ставит задачу в очередь в транзакции из контекста: задача появится,
+// только если закоммитятся и данные, ради которых она создана.
+func (r *JobRepository) Enqueue(ctx context.Context, job *models.Job) error {
+	const op = "JobRepository.Enqueue"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO jobs (kind, payload, max_attempts, run_at, schedule_name)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING ` + jobColumns
+
+	rows, err := tx.Query(ctx, query, job.Kind, job.Payload, job.MaxAttempts, job.RunAt, job.ScheduleName)
+	if err != nil {
+		logger.Log.Error(op, "Failed to enqueue job", err, "kind", job.Kind)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	created, err := pgx.CollectExactlyOneRow(rows, scanJob)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan enqueued job", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	*job = *created
+	return nil
+}
+
+// Claim забирает до limit задач, которым пора выполняться, и задачи, чей воркер
+// не уложился в lease. Задачи получают статус running и новую попытку, другие
+// воркеры их пропускают до истечения lease.
+func (r *JobRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]models.Job, error) {
+	const op = "JobRepository.Claim"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	query := `
+    WITH due AS (
+        SELECT id AS due_id FROM jobs
+        WHERE (status = 'pending' AND run_at <= NOW())
+           OR (status = 'running' AND locked_until < NOW())
+        ORDER BY run_at, id
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    UPDATE jobs j
+    SET status = 'running', attempts = j.attempts + 1, locked_until = NOW() + $2::INTERVAL, updated_at = NOW()
+    FROM due
+    WHERE j.id = due.due_id
+    RETURNING ` + jobColumns
+
+	rows, err := tx.Query(ctx, query, limit, lease)
+	if err != nil {
+		logger.Log.Error(op, "Failed to claim jobs", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	jobs, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Job, error) {
+		job, err := scanJob(row)
+		if err != nil {
+			return models.Job{}, err
+		}
+		return *job, nil
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan claimed jobs", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	return jobs, nil
+}
+
+// Finish сохраняет результат попытки. Запись обновляется, только если задача
+// всё ещё выполняется в той же попытке: результат отменённой задачи или задачи,
+// которую после истечения lease забрал другой воркер, отбрасывается с ErrNotFound.
+func (r *JobRepository) Finish(ctx context.Context, job *models.Job) error {
+	const op = "JobRepository.Finish"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE jobs
+    SET status = $3, last_error = $4, run_at = $5, finished_at = $6, locked_until = NULL, updated_at = NOW()
+    WHERE id = $1 AND attempts = $2 AND status = 'running'
+    `
+
+	tag, err := tx.Exec(ctx, query, job.ID, job.Attempts, job.Status, job.LastError, job.RunAt, job.FinishedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to finish job", err, "jobID", job.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// Release возвращает в очередь задачу, прерванную остановкой сервиса, не засчитывая
+// попытку. Как и Finish, обновляет задачу, только если её не забрали и не отменили.
+func (r *JobRepository) Release(ctx context.Context, job *models.Job) error {
+	const op = "JobRepository.Release"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE jobs
+    SET status = 'pending', attempts = attempts - 1, run_at = NOW(), locked_until = NULL, last_error = $3, updated_at = NOW()
+    WHERE id = $1 AND attempts = $2 AND status = 'running'
+    `
+
+	tag, err := tx.Exec(ctx, query, job.ID, job.Attempts, job.LastError)
+	if err != nil {
+		logger.Log.Error(op, "Failed to release job", err, "jobID", job.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *JobRepository) Get(ctx context.Context, id int64) (*models.Job, error) {
+	const op = "JobRepository.Get"
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to get job", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	job, err := pgx.CollectExactlyOneRow(rows, scanJob)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to scan job", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	return job, nil
+}
+
+// List возвращает задачи, новые первыми; пустые status и kind не фильтруют.
+func (r *JobRepository) List(
+	ctx context.Context,
+	status models.JobStatus,
+	kind string,
+	offset int,
+	limit int,
+) ([]*models.Job, int64, error) {
+	const op = "JobRepository.List"
+
+	filter := `WHERE ($1 = '' OR status = $1) AND ($2 = '' OR kind = $2)`
+
+	var total int64
+	err := r.storage.Reader(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM jobs `+filter, string(status), kind).Scan(&total)
+	if err != nil {
+		logger.Log.Error(op, "Failed to count jobs", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	query := `SELECT ` + jobColumns + ` FROM jobs ` + filter + `
+    ORDER BY id DESC
+    LIMIT $3 OFFSET $4`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, string(status), kind, limit, offset)
+	if err != nil {
+		logger.Log.Error(op, "Failed to list jobs", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	jobs, err := pgx.CollectRows(rows, scanJob)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan jobs", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	return jobs, total, nil
+}
+
+// Retry возвращает упавшую или отменённую задачу в очередь с нуля попыток.
+func (r *JobRepository) Retry(ctx context.Context, id int64) (*models.Job, error) {
+	query := `
+    UPDATE jobs
+    SET status = 'pending', attempts = 0, run_at = NOW(), last_error = '', finished_at = NULL, updated_at = NOW()
+    WHERE id = $1 AND status IN ('failed', 'canceled')
+    RETURNING ` + jobColumns
+
+	return r.transition(ctx, "JobRepository.Retry", query, id)
+}
+
+// Cancel отменяет ожидающую или выполняющуюся задачу. Воркер выполняющейся
+// задачи не прерывается, но её результат не будет сохранён.
+func (r *JobRepository) Cancel(ctx context.Context, id int64) (*models.Job, error) {
+	query := `
+    UPDATE jobs
+    SET status = 'canceled', locked_until = NULL, finished_at = NOW(), updated_at = NOW()
+    WHERE id = $1 AND status IN ('pending', 'running')
+    RETURNING ` + jobColumns
+
+	return r.transition(ctx, "JobRepository.Cancel", query, id)
+}
+
+// transition выполняет смену статуса задачи и отличает несуществующую задачу
+// (ErrNotFound) от задачи в неподходящем статусе (ErrInvalidJobState).
+func (r *JobRepository) transition(ctx context.Context, op string, query string, id int64) (*models.Job, error) {
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	rows, err := tx.Query(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to update job", err, "jobID", id)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	job, err := pgx.CollectExactlyOneRow(rows, scanJob)
+	if err == nil {
+		return job, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		logger.Log.Error(op, "Failed to scan job", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	var exists bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM jobs WHERE id = $1)`, id).Scan(&exists); err != nil {
+		logger.Log.Error(op, "Failed to check job", err, "jobID", id)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return nil, ErrInvalidJobState
+}
+
+// DeleteFinishedBefore удаляет завершённые задачи, закончившиеся раньше before.
+func (r *JobRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "JobRepository.DeleteFinishedBefore"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return 0, ErrNoTransactionInContext
+	}
+
+	tag, err := tx.Exec(ctx, `
+    DELETE FROM jobs
+    WHERE status IN ('succeeded', 'failed', 'canceled') AND finished_at < $1
+    `, before)
+	if err != nil {
+		logger.Log.Error(op, "Failed to delete finished jobs", err)
+		return 0, fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// scheduleSyncLockKey — ключ advisory-блокировки, под которой реплики по очереди
+// синхронизируют расписания.
+const scheduleSyncLockKey int64 = 0x6e657773_0003
+
+// SyncSchedules сохраняет расписания из конфига и удаляет из таблицы только
+// явно снятые (retired). Расписания, которых просто нет в переданном списке,
+// остаются: при раскатке их ещё используют реплики с другим конфигом. Время
+// следующего запуска пересчитывается, только если изменилось само расписание,
+// поэтому перезапуск сервиса не сдвигает и не повторяет запуски. Реплики
+// синхронизируют расписания по очереди под advisory-блокировкой транзакции.
+func (r *JobRepository) SyncSchedules(ctx context.Context, schedules []models.JobSchedule, retired []string) error {
+	const op = "JobRepository.SyncSchedules"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, scheduleSyncLockKey); err != nil {
+		logger.Log.Error(op, "Failed to lock job schedules", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	for _, schedule := range schedules {
+		_, err := tx.Exec(ctx, `
+        INSERT INTO job_schedules (name, kind, schedule, payload, next_run_at)
+        VALUES ($1, $2, $3, $4, $5)
+        ON CONFLICT (name) DO UPDATE
+        SET kind = EXCLUDED.kind,
+            payload = EXCLUDED.payload,
+            next_run_at = CASE WHEN job_schedules.schedule = EXCLUDED.schedule
+                THEN job_schedules.next_run_at ELSE EXCLUDED.next_run_at END,
+            schedule = EXCLUDED.schedule,
+            updated_at = NOW()
+        `, schedule.Name, schedule.Kind, schedule.Schedule, schedule.Payload, schedule.NextRunAt)
+		if err != nil {
+			logger.Log.Error(op, "Failed to save job schedule", err, "name", schedule.Name)
+			return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+		}
+	}
+
+	if len(retired) > 0 {
+		tag, err := tx.Exec(ctx, `DELETE FROM job_schedules WHERE name = ANY($1)`, retired)
+		if err != nil {
+			logger.Log.Error(op, "Failed to delete retired job schedules", err)
+			return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+		}
+		if tag.RowsAffected() > 0 {
+			logger.Log.Info(op, "Retired job schedules deleted", tag.RowsAffected(), "names", retired)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDueSchedules блокирует до limit расписаний, которым пора сработать.
+// Блокировка держится до конца транзакции, поэтому каждое срабатывание ставит
+// задачу ровно один раз, сколько бы реплик ни работало.
+func (r *JobRepository) ClaimDueSchedules(ctx context.Context, limit int) ([]models.JobSchedule, error) {
+	const op = "JobRepository.ClaimDueSchedules"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	rows, err := tx.Query(ctx, `
+    SELECT name, kind, schedule, payload, next_run_at
+    FROM job_schedules
+    WHERE next_run_at <= NOW()
+    ORDER BY next_run_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+    `, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to claim job schedules", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	schedules, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.JobSchedule, error) {
+		var schedule models.JobSchedule
+		err := row.Scan(&schedule.Name, &schedule.Kind, &schedule.Schedule, &schedule.Payload, &schedule.NextRunAt)
+		return schedule, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan job schedules", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetJobs, err)
+	}
+
+	return schedules, nil
+}
+
+func (r *JobRepository) AdvanceSchedule(ctx context.Context, name string, next time.Time) error {
+	const op = "JobRepository.AdvanceSchedule"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE job_schedules SET next_run_at = $2, updated_at = NOW() WHERE name = $1`, name, next)
+	if err != nil {
+		logger.Log.Error(op, "Failed to advance job schedule", err, "name", name)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveJob, err)
+	}
+
+	return nil
+}
+
+func scanJob(row pgx.CollectableRow) (*models.Job, error) {
+	var job models.Job
+	err := row.Scan(
+		&job.ID,
+		&job.Kind,
+		&job.Payload,
+		&job.Status,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.RunAt,
+		&job.LockedUntil,
+		&job.LastError,
+		&job.ScheduleName,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+		&job.FinishedAt,
+	)
+	return &job, err
+}
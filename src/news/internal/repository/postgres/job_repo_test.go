@@ -0,0 +1,150 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestJobRepository_Queue(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	repo := postgres.NewJobRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	jobs := []*models.Job{
+		{Kind: "a", Payload: []byte(`{"n":1}`), MaxAttempts: 3, RunAt: time.Now().Add(-time.Minute)},
+		{Kind: "b", Payload: []byte(`{}`), MaxAttempts: 3, RunAt: time.Now().Add(time.Hour)},
+	}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		for _, job := range jobs {
+			if err := repo.Enqueue(ctx, job); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	assert.Equal(t, models.JobPending, jobs[0].Status)
+
+	var claimed []models.Job
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) (err error) {
+		claimed, err = repo.Claim(ctx, 10, time.Minute)
+		return err
+	}))
+	require.Len(t, claimed, 1, "only due jobs are claimed")
+	job := claimed[0]
+	assert.Equal(t, jobs[0].ID, job.ID)
+	assert.Equal(t, models.JobRunning, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	require.NotNil(t, job.LockedUntil)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) (err error) {
+		claimed, err = repo.Claim(ctx, 10, time.Minute)
+		return err
+	}))
+	assert.Empty(t, claimed, "a leased job is not claimed again")
+
+	_, err := repo.Retry(context.Background(), job.ID)
+	assert.ErrorIs(t, err, postgres.ErrNoTransactionInContext)
+
+	err = txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		_, err := repo.Retry(ctx, job.ID)
+		return err
+	})
+	assert.ErrorIs(t, err, postgres.ErrInvalidJobState, "running jobs cannot be retried")
+
+	finished := time.Now()
+	job.Status, job.LastError, job.FinishedAt = models.JobFailed, "boom", &finished
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.Finish(ctx, &job)
+	}))
+
+	stale := job
+	stale.Status = models.JobSucceeded
+	err = txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.Finish(ctx, &stale)
+	})
+	assert.ErrorIs(t, err, postgres.ErrNotFound, "a finished job ignores late results")
+
+	listed, total, err := repo.List(ctx, models.JobFailed, "", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "boom", listed[0].LastError)
+
+	var retried *models.Job
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) (err error) {
+		retried, err = repo.Retry(ctx, job.ID)
+		return err
+	}))
+	assert.Equal(t, models.JobPending, retried.Status)
+	assert.Zero(t, retried.Attempts)
+	assert.Nil(t, retried.FinishedAt)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		_, err := repo.Cancel(ctx, jobs[1].ID)
+		return err
+	}))
+	err = txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		_, err := repo.Cancel(ctx, jobs[1].ID)
+		return err
+	})
+	assert.ErrorIs(t, err, postgres.ErrInvalidJobState)
+
+	_, err = repo.Get(ctx, jobs[1].ID+100)
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+}
+
+func TestJobRepository_Schedules(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	repo := postgres.NewJobRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	past := time.Now().Add(-time.Minute).Truncate(time.Microsecond)
+	sync := func(retired []string, schedules ...models.JobSchedule) {
+		require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+			return repo.SyncSchedules(ctx, schedules, retired)
+		}))
+	}
+	sync(nil,
+		models.JobSchedule{Name: "cleanup", Kind: "jobs.cleanup", Schedule: "@daily", Payload: []byte(`{}`), NextRunAt: past},
+		models.JobSchedule{Name: "stale", Kind: "x", Schedule: "@hourly", Payload: []byte(`{}`), NextRunAt: past},
+		models.JobSchedule{Name: "other", Kind: "x", Schedule: "@hourly", Payload: []byte(`{}`), NextRunAt: time.Now().Add(time.Hour)},
+	)
+	// Повторная синхронизация с тем же расписанием не сдвигает запуск. Расписание,
+	// которого нет в конфиге этой реплики, остаётся, пока его явно не снимут.
+	sync([]string{"stale"}, models.JobSchedule{Name: "cleanup", Kind: "jobs.cleanup", Schedule: "@daily", Payload: []byte(`{}`), NextRunAt: time.Now().Add(time.Hour)})
+
+	var due []models.JobSchedule
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) (err error) {
+		due, err = repo.ClaimDueSchedules(ctx, 10)
+		if err != nil {
+			return err
+		}
+		return repo.AdvanceSchedule(ctx, "cleanup", time.Now().Add(24*time.Hour))
+	}))
+	require.Len(t, due, 1, "retired schedules are removed")
+	assert.Equal(t, "cleanup", due[0].Name)
+	assert.True(t, due[0].NextRunAt.Equal(past))
+
+	var names []string
+	require.NoError(t, db.GetPool().QueryRow(ctx, `SELECT array_agg(name ORDER BY name) FROM job_schedules`).Scan(&names))
+	assert.Equal(t, []string{"cleanup", "other"}, names)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) (err error) {
+		due, err = repo.ClaimDueSchedules(ctx, 10)
+		return err
+	}))
+	assert.Empty(t, due)
+}
@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+type LiveEntryRepository struct {
+	storage *storage.Storage
+}
+
+func NewLiveEntryRepository(storage *storage.Storage) *LiveEntryRepository {
+	return &LiveEntryRepository{
+		storage: storage,
+	}
+}
+
+// LockNews блокирует строку новости до конца транзакции и возвращает её без блоков
+// контента. Все изменения записей новости проходят через эту блокировку, поэтому
+// ID записей одной новости растут в порядке коммита и годятся как курсор.
+func (r *LiveEntryRepository) LockNews(ctx context.Context, newsID int64) (*models.News, error) {
+	const op = "LiveEntryRepository.LockNews"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	query := `
+    SELECT id, title, slug, category, live
+    FROM news
+    WHERE id = $1
+    FOR UPDATE
+    `
+
+	news := &models.News{}
+	err := tx.QueryRow(ctx, query, newsID).Scan(&news.ID, &news.Title, &news.Slug, &news.Category, &news.Live)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to lock news", err, "id", newsID)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	return news, nil
+}
+
+func (r *LiveEntryRepository) Create(ctx context.Context, entry *models.LiveEntry) error {
+	const op = "LiveEntryRepository.Create"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO live_entries (news_id, pinned)
+    VALUES ($1, $2)
+    RETURNING id, created_at, updated_at
+    `
+
+	err := tx.QueryRow(ctx, query, entry.NewsID, entry.Pinned).Scan(&entry.ID, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to create live entry", err, "newsID", entry.NewsID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveLiveEntry, err)
+	}
+
+	return r.insertBlocks(ctx, tx, entry)
+}
+
+// Update меняет закрепление записи и, если entry.Content не nil, заменяет её блоки.
+func (r *LiveEntryRepository) Update(ctx context.Context, entry *models.LiveEntry) error {
+	const op = "LiveEntryRepository.Update"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE live_entries
+    SET pinned = $1, updated_at = NOW()
+    WHERE id = $2 AND news_id = $3
+    RETURNING created_at, updated_at
+    `
+
+	err := tx.QueryRow(ctx, query, entry.Pinned, entry.ID, entry.NewsID).Scan(&entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to update live entry", err, "id", entry.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveLiveEntry, err)
+	}
+
+	if entry.Content == nil {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM live_entry_blocks WHERE entry_id = $1`, entry.ID); err != nil {
+		logger.Log.Error(op, "Failed to delete live entry blocks", err, "id", entry.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToDeleteContentBlocks, err)
+	}
+
+	return r.insertBlocks(ctx, tx, entry)
+}
+
+func (r *LiveEntryRepository) Delete(ctx context.Context, newsID, entryID int64) error {
+	const op = "LiveEntryRepository.Delete"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM live_entries WHERE id = $1 AND news_id = $2`, entryID, newsID)
+	if err != nil {
+		logger.Log.Error(op, "Failed to delete live entry", err, "id", entryID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveLiveEntry, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *LiveEntryRepository) GetByID(ctx context.Context, newsID, entryID int64) (*models.LiveEntry, error) {
+	const op = "LiveEntryRepository.GetByID"
+
+	query := `
+    SELECT id, news_id, pinned, created_at, updated_at
+    FROM live_entries
+    WHERE id = $1 AND news_id = $2
+    `
+
+	entry := &models.LiveEntry{}
+	err := r.storage.Reader(ctx).QueryRow(ctx, query, entryID, newsID).Scan(
+		&entry.ID,
+		&entry.NewsID,
+		&entry.Pinned,
+		&entry.CreatedAt,
+		&entry.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to get live entry", err, "id", entryID)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetLiveEntries, err)
+	}
+
+	if err := r.loadBlocks(ctx, []*models.LiveEntry{entry}); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// List возвращает до limit записей новости с ID больше after в порядке добавления.
+func (r *LiveEntryRepository) List(
+	ctx context.Context,
+	newsID int64,
+	after int64,
+	limit int,
+	pinnedOnly bool,
+) ([]*models.LiveEntry, error) {
+	const op = "LiveEntryRepository.List"
+
+	query := `
+    SELECT id, news_id, pinned, created_at, updated_at
+    FROM live_entries
+    WHERE news_id = $1 AND id > $2 AND (pinned OR NOT $3)
+    ORDER BY id
+    LIMIT $4
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, newsID, after, pinnedOnly, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query live entries", err, "newsID", newsID)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetLiveEntries, err)
+	}
+	defer rows.Close()
+
+	entries := make([]*models.LiveEntry, 0)
+	for rows.Next() {
+		entry := &models.LiveEntry{}
+		if err := rows.Scan(&entry.ID, &entry.NewsID, &entry.Pinned, &entry.CreatedAt, &entry.UpdatedAt); err != nil {
+			logger.Log.Error(op, "Failed to scan live entry", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetLiveEntries, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating live entries", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetLiveEntries, err)
+	}
+
+	if err := r.loadBlocks(ctx, entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *LiveEntryRepository) insertBlocks(ctx context.Context, tx pgx.Tx, entry *models.LiveEntry) error {
+	const op = "LiveEntryRepository.insertBlocks"
+
+	query := `
+    INSERT INTO live_entry_blocks (entry_id, type, content, media_id, position)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, created_at
+    `
+
+	for i := range entry.Content {
+		block := &entry.Content[i]
+		block.NewsID = entry.NewsID
+
+		err := tx.QueryRow(ctx, query, entry.ID, block.Type, block.Content, block.MediaID, block.Position).
+			Scan(&block.ID, &block.CreatedAt)
+		if err != nil {
+			logger.Log.Error(op, "Failed to create live entry block", err, "entryID", entry.ID)
+			return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
+		}
+	}
+
+	return nil
+}
+
+// loadBlocks загружает блоки всех записей одним запросом.
+func (r *LiveEntryRepository) loadBlocks(ctx context.Context, entries []*models.LiveEntry) error {
+	const op = "LiveEntryRepository.loadBlocks"
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(entries))
+	byID := make(map[int64]*models.LiveEntry, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+		byID[entry.ID] = entry
+		entry.Content = make([]models.ContentBlock, 0)
+	}
+
+	query := `
+    SELECT id, entry_id, type, content, media_id, position, created_at
+    FROM live_entry_blocks
+    WHERE entry_id = ANY($1)
+    ORDER BY entry_id, position
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, ids)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query live entry blocks", err)
+		return fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var block models.ContentBlock
+		var entryID int64
+		var blockType string
+
+		err := rows.Scan(&block.ID, &entryID, &blockType, &block.Content, &block.MediaID, &block.Position, &block.CreatedAt)
+		if err != nil {
+			logger.Log.Error(op, "Failed to scan live entry block", err)
+			return fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
+		}
+
+		block.Type = models.BlockType(blockType)
+		if entry, ok := byID[entryID]; ok {
+			block.NewsID = entry.NewsID
+			entry.Content = append(entry.Content, block)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating live entry blocks", err)
+		return fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestLiveEntryRepository(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	newsRepo := postgres.NewNewsRepository(db)
+	repo := postgres.NewLiveEntryRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	news := &models.News{Title: "Live", Category: "sport", Live: true, StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return newsRepo.Create(ctx, news)
+	}))
+
+	entries := []*models.LiveEntry{
+		{NewsID: news.ID, Content: []models.ContentBlock{{Type: "text", Content: "kick-off", Position: 1}}},
+		{NewsID: news.ID, Pinned: true, Content: []models.ContentBlock{{Type: "text", Content: "goal", Position: 1}}},
+		{NewsID: news.ID, Content: []models.ContentBlock{{Type: "text", Content: "half-time", Position: 1}}},
+	}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		locked, err := repo.LockNews(ctx, news.ID)
+		if err != nil {
+			return err
+		}
+		assert.True(t, locked.Live)
+
+		for _, entry := range entries {
+			if err := repo.Create(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	page, err := repo.List(ctx, news.ID, entries[0].ID, 10, false)
+	require.NoError(t, err)
+	require.Len(t, page, 2)
+	assert.Equal(t, "goal", page[0].Content[0].Content)
+	assert.Equal(t, "half-time", page[1].Content[0].Content)
+
+	pinned, err := repo.List(ctx, news.ID, 0, 10, true)
+	require.NoError(t, err)
+	require.Len(t, pinned, 1)
+	assert.Equal(t, entries[1].ID, pinned[0].ID)
+
+	entries[0].Pinned = true
+	entries[0].Content = nil
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.Update(ctx, entries[0])
+	}))
+	updated, err := repo.GetByID(ctx, news.ID, entries[0].ID)
+	require.NoError(t, err)
+	assert.True(t, updated.Pinned)
+	require.Len(t, updated.Content, 1, "nil content keeps the blocks")
+	assert.Equal(t, "kick-off", updated.Content[0].Content)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.Delete(ctx, news.ID, entries[2].ID)
+	}))
+	_, err = repo.GetByID(ctx, news.ID, entries[2].ID)
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+type MediaRepository struct {
+	storage *storage.Storage
+}
+
+func NewMediaRepository(storage *storage.Storage) *MediaRepository {
+	return &MediaRepository{
+		storage: storage,
+	}
+}
+
+const mediaColumns = `id, storage_key, file_name, mime_type, size, width, height, checksum, created_at`
+
+func (r *MediaRepository) Create(ctx context.Context, media *models.Media) error {
+	const op = "MediaRepository.Create"
+	logger.Log.Debug(op, "checksum", media.Checksum, "mime", media.MimeType)
+
+	query := `
+    INSERT INTO media (storage_key, file_name, mime_type, size, width, height, checksum)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    RETURNING id, created_at
+    `
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	err := tx.QueryRow(ctx, query,
+		media.StorageKey,
+		media.FileName,
+		media.MimeType,
+		media.Size,
+		media.Width,
+		media.Height,
+		media.Checksum,
+	).Scan(&media.ID, &media.CreatedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to create media", err)
+		return fmt.Errorf("%w: %v", ErrFailedToCreateMedia, err)
+	}
+
+	logger.Log.Debug(op, "Media created successfully", media.ID)
+	return nil
+}
+
+func (r *MediaRepository) GetByID(ctx context.Context, id int64) (*models.Media, error) {
+	const op = "MediaRepository.GetByID"
+	logger.Log.Debug(op, "Getting media by ID", id)
+
+	query := `SELECT ` + mediaColumns + ` FROM media WHERE id = $1`
+
+	return r.getOne(ctx, op, query, id)
+}
+
+func (r *MediaRepository) GetByChecksum(ctx context.Context, checksum string) (*models.Media, error) {
+	const op = "MediaRepository.GetByChecksum"
+	logger.Log.Debug(op, "Getting media by checksum", checksum)
+
+	query := `SELECT ` + mediaColumns + ` FROM media WHERE checksum = $1`
+
+	return r.getOne(ctx, op, query, checksum)
+}
+
+// ExistingIDs возвращает подмножество ids, для которых есть запись в media.
+func (r *MediaRepository) ExistingIDs(ctx context.Context, ids []int64) (map[int64]struct{}, error) {
+	const op = "MediaRepository.ExistingIDs"
+
+	existing := make(map[int64]struct{}, len(ids))
+	if len(ids) == 0 {
+		return existing, nil
+	}
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, `SELECT id FROM media WHERE id = ANY($1)`, ids)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query media ids", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMedia, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			logger.Log.Error(op, "Failed to scan media id", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetMedia, err)
+		}
+		existing[id] = struct{}{}
+	}
+
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating media ids", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMedia, err)
+	}
+
+	return existing, nil
+}
+
+func (r *MediaRepository) getOne(ctx context.Context, op string, query string, arg any) (*models.Media, error) {
+	media := &models.Media{}
+	err := r.storage.Reader(ctx).QueryRow(ctx, query, arg).Scan(
+		&media.ID,
+		&media.StorageKey,
+		&media.FileName,
+		&media.MimeType,
+		&media.Size,
+		&media.Width,
+		&media.Height,
+		&media.Checksum,
+		&media.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Debug(op, "Media not found", arg)
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to get media", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMedia, err)
+	}
+	return media, nil
+}
+
+const mediaVariantColumns = `id, media_id, source_checksum, width, height, format, mime_type, storage_key, size, created_at`
+
+// CreateVariant сохраняет вариант изображения. Повторная вставка варианта
+// с тем же (source_checksum, width, format) ничего не делает.
+func (r *MediaRepository) CreateVariant(ctx context.Context, variant *models.MediaVariant) error {
+	const op = "MediaRepository.CreateVariant"
+	logger.Log.Debug(op, "mediaID", variant.MediaID, "width", variant.Width, "format", variant.Format)
+
+	query := `
+    INSERT INTO media_variants (media_id, source_checksum, width, height, format, mime_type, storage_key, size)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+    ON CONFLICT (source_checksum, width, format) DO NOTHING
+    `
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	_, err := tx.Exec(ctx, query,
+		variant.MediaID,
+		variant.SourceChecksum,
+		variant.Width,
+		variant.Height,
+		variant.Format,
+		variant.MimeType,
+		variant.StorageKey,
+		variant.Size,
+	)
+	if err != nil {
+		logger.Log.Error(op, "Failed to create media variant", err)
+		return fmt.Errorf("%w: %v", ErrFailedToCreateMediaVariant, err)
+	}
+
+	return nil
+}
+
+func (r *MediaRepository) GetVariant(ctx context.Context, mediaID int64, width int, format string) (*models.MediaVariant, error) {
+	const op = "MediaRepository.GetVariant"
+
+	query := `SELECT ` + mediaVariantColumns + ` FROM media_variants WHERE media_id = $1 AND width = $2 AND format = $3`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, mediaID, width, format)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query media variant", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMediaVariants, err)
+	}
+
+	variants, err := scanMediaVariants(rows)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan media variant", err)
+		return nil, err
+	}
+	if len(variants) == 0 {
+		return nil, ErrNotFound
+	}
+	return &variants[0], nil
+}
+
+func (r *MediaRepository) ListVariantsByChecksum(ctx context.Context, checksum string) ([]models.MediaVariant, error) {
+	const op = "MediaRepository.ListVariantsByChecksum"
+
+	query := `SELECT ` + mediaVariantColumns + ` FROM media_variants WHERE source_checksum = $1 ORDER BY width, format`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, checksum)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query media variants", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMediaVariants, err)
+	}
+
+	return scanMediaVariants(rows)
+}
+
+// ListVariants возвращает варианты для набора медиафайлов, сгруппированные по media_id.
+func (r *MediaRepository) ListVariants(ctx context.Context, mediaIDs []int64) (map[int64][]models.MediaVariant, error) {
+	const op = "MediaRepository.ListVariants"
+
+	result := make(map[int64][]models.MediaVariant, len(mediaIDs))
+	if len(mediaIDs) == 0 {
+		return result, nil
+	}
+
+	query := `SELECT ` + mediaVariantColumns + ` FROM media_variants WHERE media_id = ANY($1) ORDER BY media_id, width, format`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, mediaIDs)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query media variants", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMediaVariants, err)
+	}
+
+	variants, err := scanMediaVariants(rows)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan media variants", err)
+		return nil, err
+	}
+
+	for _, v := range variants {
+		result[v.MediaID] = append(result[v.MediaID], v)
+	}
+	return result, nil
+}
+
+// ReferencingNewsIDs возвращает id новостей, в блоках которых используется медиафайл.
+func (r *MediaRepository) ReferencingNewsIDs(ctx context.Context, mediaID int64) ([]int64, error) {
+	const op = "MediaRepository.ReferencingNewsIDs"
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, `SELECT DISTINCT news_id FROM content_blocks WHERE media_id = $1`, mediaID)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query news ids", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan news ids", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+	return ids, nil
+}
+
+func scanMediaVariants(rows pgx.Rows) ([]models.MediaVariant, error) {
+	defer rows.Close()
+
+	variants := make([]models.MediaVariant, 0)
+	for rows.Next() {
+		var v models.MediaVariant
+		err := rows.Scan(
+			&v.ID,
+			&v.MediaID,
+			&v.SourceChecksum,
+			&v.Width,
+			&v.Height,
+			&v.Format,
+			&v.MimeType,
+			&v.StorageKey,
+			&v.Size,
+			&v.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetMediaVariants, err)
+		}
+		variants = append(variants, v)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetMediaVariants, err)
+	}
+	return variants, nil
+}
@@ -0,0 +1,62 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+func TestTxManager_Nested(t *testing.T) {
+	repo, txManager, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	newNews := func(title string) *models.News {
+		return &models.News{Title: title, Category: "Sport", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	}
+
+	outer, inner := newNews("Outer"), newNews("Inner")
+	errInner := errors.New("inner failed")
+
+	err := txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := repo.Create(ctx, outer); err != nil {
+			return err
+		}
+
+		// Ошибка вложенного вызова откатывает только его записи.
+		err := txManager.RunSerializable(ctx, func(ctx context.Context) error {
+			if err := repo.Create(ctx, inner); err != nil {
+				return err
+			}
+			return errInner
+		})
+		assert.ErrorIs(t, err, errInner)
+
+		// Чтение в транзакции видит её незафиксированные записи.
+		return txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+			got, err := repo.GetByID(ctx, outer.ID)
+			if err != nil {
+				return err
+			}
+			assert.Equal(t, "Outer", got.Title)
+
+			_, err = repo.GetByID(ctx, inner.ID)
+			assert.ErrorIs(t, err, postgres.ErrNotFound)
+			return nil
+		})
+	})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(ctx, outer.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Outer", got.Title)
+
+	_, err = repo.GetByID(ctx, inner.ID)
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+}
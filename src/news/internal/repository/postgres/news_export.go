@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// Export проходит по всем новостям фильтра серверным курсором и вызывает fn для
+// каждой новости вместе с блоками. В памяти одновременно держится не больше
+// fetchSize строк, поэтому выгрузка архива не зависит от его размера. Курсор живёт
+// в транзакции из контекста; ошибка fn прерывает выгрузку и возвращается как есть.
+func (r *NewsRepository) Export(
+	ctx context.Context,
+	filter models.NewsFilter,
+	fetchSize int,
+	fn func(news *models.News) error,
+) error {
+	const op = "NewsRepository.Export"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	sortField, ok := newsSortFields[filter.SortBy]
+	if !ok {
+		sortField = newsSortFields["created_at"]
+	}
+	sortDir := "DESC"
+	if filter.SortDir == "asc" {
+		sortDir = "ASC"
+	}
+
+	where, args := newsFilterConditions(filter)
+
+	// Блоки собираются в JSON прямо в запросе, чтобы не делать отдельный запрос на новость.
+	query := fmt.Sprintf(`
+    DECLARE news_export NO SCROLL CURSOR FOR
+    SELECT n.id, n.title, n.slug, n.category, n.live, n.created_at, n.updated_at, n.start_time, n.end_time,
+        COALESCE(b.blocks, '[]'::json)
+    FROM news n
+    LEFT JOIN LATERAL (
+        SELECT json_agg(json_build_object(
+            'id', cb.id,
+            'news_id', cb.news_id,
+            'type', cb.type,
+            'content', cb.content,
+            'media_id', cb.media_id,
+            'position', cb.position,
+            'created_at', cb.created_at
+        ) ORDER BY cb.position) AS blocks
+        FROM content_blocks cb
+        WHERE cb.news_id = n.id
+    ) b ON TRUE
+    WHERE 1=1%s
+    ORDER BY %s %s, n.id %s
+    `, where, sortField.column, sortDir, sortDir)
+
+	if _, err := tx.Exec(ctx, query, args...); err != nil {
+		logger.Log.Error(op, "Failed to declare cursor", err)
+		return fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	fetchQuery := fmt.Sprintf("FETCH FORWARD %d FROM news_export", fetchSize)
+	exported := 0
+
+	for {
+		rows, err := tx.Query(ctx, fetchQuery)
+		if err != nil {
+			logger.Log.Error(op, "Failed to fetch from cursor", err)
+			return fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			news := &models.News{}
+			err := rows.Scan(
+				&news.ID,
+				&news.Title,
+				&news.Slug,
+				&news.Category,
+				&news.Live,
+				&news.CreatedAt,
+				&news.UpdatedAt,
+				&news.StartTime,
+				&news.EndTime,
+				&news.Content,
+			)
+			if err != nil {
+				rows.Close()
+				logger.Log.Error(op, "Failed to scan news row", err)
+				return fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+			}
+			if err := fn(news); err != nil {
+				rows.Close()
+				return err
+			}
+			fetched++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			logger.Log.Error(op, "Error iterating rows", err)
+			return fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+
+		exported += fetched
+		if fetched < fetchSize {
+			break
+		}
+	}
+
+	if _, err := tx.Exec(ctx, "CLOSE news_export"); err != nil {
+		logger.Log.Error(op, "Failed to close cursor", err)
+		return fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	logger.Log.Debug(op, "News exported", exported)
+	return nil
+}
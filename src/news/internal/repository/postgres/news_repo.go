@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/zhavkk/news-service/src/news/internal/logger"
 	"github.com/zhavkk/news-service/src/news/internal/models"
 	"github.com/zhavkk/news-service/src/news/internal/storage"
@@ -27,15 +30,9 @@ func (r *NewsRepository) Create(ctx context.Context, news *models.News) error {
 	logger.Log.Debug(op, "title", news.Title)
 
 	newsQuery := `
-    INSERT INTO news (title, category, start_time, end_time) 
-    VALUES ($1, $2, $3, $4)
-    RETURNING id, created_at
-    `
-
-	contentBlockQuery := `
-    INSERT INTO content_blocks (news_id, type, content, position) 
-    VALUES ($1, $2, $3, $4)
-    RETURNING id, created_at
+    INSERT INTO news (title, slug, category, live, start_time, end_time) 
+    VALUES ($1, $2, $3, $4, $5, $6)
+    RETURNING id, created_at, updated_at
     `
 
 	tx, ok := storage.GetTxFromContext(ctx)
@@ -47,12 +44,17 @@ func (r *NewsRepository) Create(ctx context.Context, news *models.News) error {
 	var newsID int64
 	err := tx.QueryRow(ctx, newsQuery,
 		news.Title,
+		news.Slug,
 		news.Category,
+		news.Live,
 		news.StartTime,
 		news.EndTime,
-	).Scan(&newsID, &news.CreatedAt)
+	).Scan(&newsID, &news.CreatedAt, &news.UpdatedAt)
 
 	if err != nil {
+		if isSlugConflict(err) {
+			return fmt.Errorf("%w: %v", ErrSlugTaken, err)
+		}
 		logger.Log.Error(op, "Failed to create news", err)
 		return fmt.Errorf("%w: %v", ErrFailedToCreateNews, err)
 	}
@@ -60,45 +62,104 @@ func (r *NewsRepository) Create(ctx context.Context, news *models.News) error {
 	news.ID = newsID
 	logger.Log.Debug(op, "News created successfully", newsID, "title", news.Title)
 
-	for i := range news.Content {
-		block := &news.Content[i]
-		block.NewsID = newsID
+	if err := insertContentBlocks(ctx, tx, newsID, news.Content); err != nil {
+		logger.Log.Error(op, "Failed to create content blocks", err, "newsID", newsID)
+		return err
+	}
 
-		err := tx.QueryRow(ctx, contentBlockQuery,
-			newsID,
-			block.Type,
-			block.Content,
-			block.Position,
-		).Scan(&block.ID, &block.CreatedAt)
+	return nil
+}
 
-		if err != nil {
-			logger.Log.Error(op, "Failed to create content block", err, "newsID", newsID)
-			return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
-		}
+// insertContentBlocksQuery вставляет все блоки новости одним запросом: массивы
+// колонок разворачиваются через unnest, RETURNING возвращает строки всех блоков.
+const insertContentBlocksQuery = `
+    INSERT INTO content_blocks (news_id, type, content, media_id, position)
+    SELECT $1, b.type, b.content, b.media_id, b.position
+    FROM unnest($2::text[], $3::text[], $4::bigint[], $5::int[]) AS b(type, content, media_id, position)
+    RETURNING id, position, created_at
+    `
+
+// insertContentBlocks вставляет блоки новости за один сетевой обмен и записывает
+// в них id и created_at.
+func insertContentBlocks(ctx context.Context, tx pgx.Tx, newsID int64, blocks []models.ContentBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	rows, err := tx.Query(ctx, insertContentBlocksQuery, contentBlockArgs(newsID, blocks)...)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
+	}
+	defer rows.Close()
+
+	return scanInsertedBlocks(rows, newsID, blocks)
+}
 
-		logger.Log.Debug(op, "Content block created successfully", block.ID, "content", block.Content)
+// contentBlockArgs раскладывает блоки по массивам колонок для insertContentBlocksQuery.
+func contentBlockArgs(newsID int64, blocks []models.ContentBlock) []interface{} {
+	types := make([]string, len(blocks))
+	contents := make([]string, len(blocks))
+	mediaIDs := make([]*int64, len(blocks))
+	positions := make([]int32, len(blocks))
+	for i, block := range blocks {
+		types[i] = string(block.Type)
+		contents[i] = block.Content
+		mediaIDs[i] = block.MediaID
+		positions[i] = int32(block.Position)
+	}
+	return []interface{}{newsID, types, contents, mediaIDs, positions}
+}
+
+// scanInsertedBlocks сопоставляет строки RETURNING с блоками по position: он уникален
+// в пределах новости, а порядок строк RETURNING не гарантирован.
+func scanInsertedBlocks(rows pgx.Rows, newsID int64, blocks []models.ContentBlock) error {
+	byPosition := make(map[int]*models.ContentBlock, len(blocks))
+	for i := range blocks {
+		blocks[i].NewsID = newsID
+		byPosition[blocks[i].Position] = &blocks[i]
 	}
 
+	for rows.Next() {
+		var (
+			id        int64
+			position  int
+			createdAt time.Time
+		)
+		if err := rows.Scan(&id, &position, &createdAt); err != nil {
+			return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
+		}
+		if block, ok := byPosition[position]; ok {
+			block.ID = id
+			block.CreatedAt = createdAt
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
+	}
 	return nil
 }
+
 func (r *NewsRepository) GetByID(ctx context.Context, id int64) (*models.News, error) {
 	const op = "NewsRepository.GetByID"
 	logger.Log.Debug(op, "Getting news by ID", id)
 
 	newsQuery := `
-    SELECT id, title, category, start_time, end_time, created_at 
+    SELECT id, title, slug, category, live, start_time, end_time, created_at, updated_at 
     FROM news 
     WHERE id = $1
     `
 
 	news := &models.News{}
-	err := r.storage.GetPool().QueryRow(ctx, newsQuery, id).Scan(
+	err := r.storage.Reader(ctx).QueryRow(ctx, newsQuery, id).Scan(
 		&news.ID,
 		&news.Title,
+		&news.Slug,
 		&news.Category,
+		&news.Live,
 		&news.StartTime,
 		&news.EndTime,
 		&news.CreatedAt,
+		&news.UpdatedAt,
 	)
 
 	if err != nil {
@@ -110,13 +171,13 @@ func (r *NewsRepository) GetByID(ctx context.Context, id int64) (*models.News, e
 		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
 	}
 	blocksQuery := `
-    SELECT id, type, content, position, created_at 
+    SELECT id, type, content, media_id, position, created_at 
     FROM content_blocks 
     WHERE news_id = $1 
     ORDER BY position
     `
 
-	rows, err := r.storage.GetPool().Query(ctx, blocksQuery, id)
+	rows, err := r.storage.Reader(ctx).Query(ctx, blocksQuery, id)
 	if err != nil {
 		logger.Log.Error(op, "Failed to query content blocks", err, "newsID", id)
 		return nil, fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
@@ -132,6 +193,7 @@ func (r *NewsRepository) GetByID(ctx context.Context, id int64) (*models.News, e
 			&block.ID,
 			&blockType,
 			&block.Content,
+			&block.MediaID,
 			&block.Position,
 			&block.CreatedAt,
 		)
@@ -154,25 +216,22 @@ func (r *NewsRepository) GetByID(ctx context.Context, id int64) (*models.News, e
 	return news, nil
 }
 
+// Update сохраняет новость и заменяет её блоки. UPDATE, удаление старых блоков и
+// вставка новых отправляются одним pgx.Batch — за один сетевой обмен.
 func (r *NewsRepository) Update(ctx context.Context, news *models.News) error {
 	const op = "NewsRepository.Update"
 	logger.Log.Debug(op, "Updating news", news.ID, "title", news.Title)
 
 	newsQuery := `
     UPDATE news
-    SET title = $1, category = $2, start_time = $3, end_time = $4
-    WHERE id = $5 
+    SET title = $1, slug = $2, category = $3, live = $4, start_time = $5, end_time = $6, updated_at = NOW()
+    WHERE id = $7 
+    RETURNING updated_at
     `
 
 	deleteBlocksQuery := `
     DELETE FROM content_blocks
     WHERE news_id = $1
-    `
-
-	insertBlockQuery := `
-    INSERT INTO content_blocks (news_id, type, content, position)
-    VALUES ($1, $2, $3, $4)
-    RETURNING id, created_at
     `
 
 	tx, ok := storage.GetTxFromContext(ctx)
@@ -181,47 +240,48 @@ func (r *NewsRepository) Update(ctx context.Context, news *models.News) error {
 		return ErrNoTransactionInContext
 	}
 
-	result, err := tx.Exec(ctx, newsQuery,
+	batch := &pgx.Batch{}
+	batch.Queue(newsQuery,
 		news.Title,
+		news.Slug,
 		news.Category,
+		news.Live,
 		news.StartTime,
 		news.EndTime,
 		news.ID,
-	)
-	if err != nil {
-		logger.Log.Error(op, "Failed to update news", err, "id", news.ID)
-		return fmt.Errorf("%w: %v", ErrFailedToUpdateNews, err)
-	}
-
-	rowsAffected := result.RowsAffected()
-	if rowsAffected == 0 {
-		logger.Log.Warn(op, "News not found for update", news.ID)
-		return ErrNotFound
-	}
-
-	_, err = tx.Exec(ctx, deleteBlocksQuery, news.ID)
-	if err != nil {
-		logger.Log.Error(op, "Failed to delete content blocks", err, "newsID", news.ID)
-		return fmt.Errorf("%w: %v", ErrFailedToDeleteContentBlocks, err)
-	}
-
-	for i := range news.Content {
-		block := &news.Content[i]
-		block.NewsID = news.ID
-
-		err := tx.QueryRow(ctx, insertBlockQuery,
-			news.ID,
-			block.Type,
-			block.Content,
-			block.Position,
-		).Scan(&block.ID, &block.CreatedAt)
-
+	).QueryRow(func(row pgx.Row) error {
+		err := row.Scan(&news.UpdatedAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		if isSlugConflict(err) {
+			return fmt.Errorf("%w: %v", ErrSlugTaken, err)
+		}
 		if err != nil {
-			logger.Log.Error(op, "Failed to create content block", err, "newsID", news.ID)
-			return fmt.Errorf("%w: %v", ErrFailedToCreateContentBlock, err)
+			return fmt.Errorf("%w: %v", ErrFailedToUpdateNews, err)
 		}
-
-		logger.Log.Debug(op, "Content block created successfully", block.ID, "content", block.Content)
+		return nil
+	})
+	batch.Queue(deleteBlocksQuery, news.ID)
+	if len(news.Content) > 0 {
+		batch.Queue(insertContentBlocksQuery, contentBlockArgs(news.ID, news.Content)...).Query(func(rows pgx.Rows) error {
+			return scanInsertedBlocks(rows, news.ID, news.Content)
+		})
+	}
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			logger.Log.Warn(op, "News not found for update", news.ID)
+			return ErrNotFound
+		}
+		if errors.Is(err, ErrSlugTaken) {
+			return err
+		}
+		logger.Log.Error(op, "Failed to update news", err, "id", news.ID)
+		if errors.Is(err, ErrFailedToUpdateNews) || errors.Is(err, ErrFailedToCreateContentBlock) {
+			return err
+		}
+		return fmt.Errorf("%w: %v", ErrFailedToUpdateNews, err)
 	}
 
 	logger.Log.Debug(op, "News updated successfully", news.ID)
@@ -259,6 +319,8 @@ func (r *NewsRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// List возвращает страницу новостей и их общее число. fields ограничивает выбираемые
+// колонки (пустой — все), блоки контента загружаются только при withContent.
 func (r *NewsRepository) List(
 	ctx context.Context,
 	offset int,
@@ -268,6 +330,8 @@ func (r *NewsRepository) List(
 	sortBy string,
 	sortDir string,
 	checkVisibility bool,
+	fields []string,
+	withContent bool,
 ) ([]*models.News, int64, error) {
 	const op = "NewsRepository.List"
 	logger.Log.Debug(op, "offset", offset, "limit", limit, "search", search, "category", category)
@@ -278,7 +342,7 @@ func (r *NewsRepository) List(
     `
 
 	query := `
-    SELECT n.id, n.title, n.category, n.created_at, n.start_time, n.end_time  
+    SELECT ` + newsSelectList(fields) + `
     FROM news n
     WHERE 1=1
     `
@@ -307,36 +371,28 @@ func (r *NewsRepository) List(
 		paramCount++
 	}
 
-	allowedSortFields := map[string]string{
-		"created_at": "n.created_at",
-		"title":      "n.title",
-		"category":   "n.category",
-		"start_time": "n.start_time",
-		"end_time":   "n.end_time",
-	}
-
-	sortField, ok := allowedSortFields[sortBy]
+	sortField, ok := newsSortFields[sortBy]
 	if !ok {
-		sortField = "n.created_at"
+		sortField = newsSortFields["created_at"]
 	}
 
 	if sortDir != "asc" && sortDir != "desc" {
 		sortDir = "desc"
 	}
 
-	query += fmt.Sprintf(" ORDER BY %s %s", sortField, sortDir)
+	query += fmt.Sprintf(" ORDER BY %s %s", sortField.column, sortDir)
 
 	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", paramCount, paramCount+1)
 	args = append(args, limit, offset)
 
 	var totalCount int64
-	err := r.storage.GetPool().QueryRow(ctx, countQuery, args[:paramCount-1]...).Scan(&totalCount)
+	err := r.storage.Reader(ctx).QueryRow(ctx, countQuery, args[:paramCount-1]...).Scan(&totalCount)
 	if err != nil {
 		logger.Log.Error(op, "Failed to get total count", err)
 		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
 	}
 
-	rows, err := r.storage.GetPool().Query(ctx, query, args...)
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, args...)
 	if err != nil {
 		logger.Log.Error(op, "Failed to query news", err)
 		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
@@ -346,14 +402,7 @@ func (r *NewsRepository) List(
 	newsList := make([]*models.News, 0)
 	for rows.Next() {
 		news := &models.News{}
-		err := rows.Scan(
-			&news.ID,
-			&news.Title,
-			&news.Category,
-			&news.CreatedAt,
-			&news.StartTime,
-			&news.EndTime,
-		)
+		err := rows.Scan(newsScanTargets(news, fields)...)
 		if err != nil {
 			logger.Log.Error(op, "Failed to scan news row", err)
 			return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
@@ -367,7 +416,7 @@ func (r *NewsRepository) List(
 		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
 	}
 
-	if len(newsList) > 0 {
+	if withContent && len(newsList) > 0 {
 		if err = r.loadContentBlocks(ctx, newsList); err != nil {
 			logger.Log.Error(op, "Failed to load content blocks", err)
 			return nil, 0, err
@@ -377,6 +426,321 @@ func (r *NewsRepository) List(
 	return newsList, totalCount, nil
 }
 
+// GetByIDs возвращает найденные новости с блоками контента одним запросом.
+// Порядок не определён, отсутствующие id пропускаются.
+func (r *NewsRepository) GetByIDs(ctx context.Context, ids []int64) ([]*models.News, error) {
+	const op = "NewsRepository.GetByIDs"
+	logger.Log.Debug(op, "Getting news by IDs", ids)
+
+	query := `
+    SELECT ` + newsSelectList(nil) + `
+    FROM news n
+    WHERE n.id = ANY($1)
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, ids)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query news", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+	defer rows.Close()
+
+	newsList := make([]*models.News, 0, len(ids))
+	for rows.Next() {
+		news := &models.News{}
+		if err := rows.Scan(newsScanTargets(news, nil)...); err != nil {
+			logger.Log.Error(op, "Failed to scan news row", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+		newsList = append(newsList, news)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating rows", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	if len(newsList) > 0 {
+		if err := r.loadContentBlocks(ctx, newsList); err != nil {
+			logger.Log.Error(op, "Failed to load content blocks", err)
+			return nil, err
+		}
+	}
+
+	return newsList, nil
+}
+
+// newsFields — поля новости, которые можно выбрать в List, в порядке колонок SELECT.
+var newsFields = []string{"title", "slug", "category", "live", "created_at", "updated_at", "start_time", "end_time"}
+
+// newsSelectList возвращает колонки для SELECT. id выбирается всегда,
+// пустой fields означает все колонки.
+func newsSelectList(fields []string) string {
+	columns := []string{"n.id"}
+	for _, field := range newsFields {
+		if len(fields) == 0 || slices.Contains(fields, field) {
+			columns = append(columns, "n."+field)
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// newsScanTargets возвращает адреса полей новости в порядке колонок newsSelectList.
+func newsScanTargets(news *models.News, fields []string) []interface{} {
+	targets := []interface{}{&news.ID}
+	for _, field := range newsFields {
+		if len(fields) != 0 && !slices.Contains(fields, field) {
+			continue
+		}
+		switch field {
+		case "title":
+			targets = append(targets, &news.Title)
+		case "slug":
+			targets = append(targets, &news.Slug)
+		case "category":
+			targets = append(targets, &news.Category)
+		case "live":
+			targets = append(targets, &news.Live)
+		case "created_at":
+			targets = append(targets, &news.CreatedAt)
+		case "updated_at":
+			targets = append(targets, &news.UpdatedAt)
+		case "start_time":
+			targets = append(targets, &news.StartTime)
+		case "end_time":
+			targets = append(targets, &news.EndTime)
+		}
+	}
+	return targets
+}
+
+// sortField — колонка сортировки списка новостей. Для временных колонок значение
+// курсора приводится к timestamptz.
+type sortField struct {
+	column string
+	isTime bool
+}
+
+var newsSortFields = map[string]sortField{
+	"created_at": {column: "n.created_at", isTime: true},
+	"title":      {column: "n.title"},
+	"category":   {column: "n.category"},
+	"start_time": {column: "n.start_time", isTime: true},
+	"end_time":   {column: "n.end_time", isTime: true},
+}
+
+// ListAfter возвращает до limit новостей, идущих в порядке сортировки после курсора
+// (с начала списка, если after == nil). Порядок однозначен благодаря id в качестве
+// второго ключа, поэтому вставки между запросами не сдвигают страницы.
+// Блоки контента загружаются одним запросом и только при withContent.
+func (r *NewsRepository) ListAfter(
+	ctx context.Context,
+	filter models.NewsFilter,
+	after *models.NewsCursor,
+	limit int,
+	withContent bool,
+) ([]*models.News, error) {
+	const op = "NewsRepository.ListAfter"
+
+	sortField, ok := newsSortFields[filter.SortBy]
+	if !ok {
+		sortField = newsSortFields["created_at"]
+	}
+	sortDir, cmp := "DESC", "<"
+	if filter.SortDir == "asc" {
+		sortDir, cmp = "ASC", ">"
+	}
+
+	where, args := newsFilterConditions(filter)
+	if after != nil {
+		value := fmt.Sprintf("$%d", len(args)+1)
+		if sortField.isTime {
+			value += "::timestamptz"
+		}
+		where += fmt.Sprintf(" AND (%s, n.id) %s (%s, $%d)", sortField.column, cmp, value, len(args)+2)
+		args = append(args, after.Value, after.ID)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+    SELECT n.id, n.title, n.slug, n.category, n.live, n.created_at, n.updated_at, n.start_time, n.end_time
+    FROM news n
+    WHERE 1=1%s
+    ORDER BY %s %s, n.id %s
+    LIMIT $%d
+    `, where, sortField.column, sortDir, sortDir, len(args))
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query news", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+	defer rows.Close()
+
+	newsList := make([]*models.News, 0)
+	for rows.Next() {
+		news := &models.News{}
+		err := rows.Scan(
+			&news.ID,
+			&news.Title,
+			&news.Slug,
+			&news.Category,
+			&news.Live,
+			&news.CreatedAt,
+			&news.UpdatedAt,
+			&news.StartTime,
+			&news.EndTime,
+		)
+		if err != nil {
+			logger.Log.Error(op, "Failed to scan news row", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+		newsList = append(newsList, news)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating rows", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	if withContent {
+		if err := r.loadContentBlocks(ctx, newsList); err != nil {
+			logger.Log.Error(op, "Failed to load content blocks", err)
+			return nil, err
+		}
+	}
+
+	return newsList, nil
+}
+
+// Count возвращает число новостей, подходящих под фильтр.
+func (r *NewsRepository) Count(ctx context.Context, filter models.NewsFilter) (int64, error) {
+	const op = "NewsRepository.Count"
+
+	where, args := newsFilterConditions(filter)
+
+	var count int64
+	err := r.storage.Reader(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM news n WHERE 1=1"+where, args...).Scan(&count)
+	if err != nil {
+		logger.Log.Error(op, "Failed to count news", err)
+		return 0, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	return count, nil
+}
+
+// ExpiredIDs возвращает до limit id новостей с id больше afterID, показ которых
+// закончился раньше before, по возрастанию id.
+func (r *NewsRepository) ExpiredIDs(ctx context.Context, before time.Time, afterID int64, limit int) ([]int64, error) {
+	const op = "NewsRepository.ExpiredIDs"
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, `
+    SELECT id FROM news
+    WHERE end_time < $1 AND id > $2
+    ORDER BY id
+    LIMIT $3
+    `, before, afterID, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to get expired news", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int64])
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan expired news", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	return ids, nil
+}
+
+// LinkBlocks возвращает блоки-ссылки видимых сейчас новостей с id больше afterID
+// в порядке id.
+func (r *NewsRepository) LinkBlocks(ctx context.Context, afterID int64, limit int) ([]models.ContentBlock, error) {
+	const op = "NewsRepository.LinkBlocks"
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, `
+    SELECT cb.id, cb.news_id, cb.content, cb.position
+    FROM content_blocks cb
+    JOIN news n ON n.id = cb.news_id
+    WHERE cb.type = 'link' AND cb.id > $1 AND NOW() BETWEEN n.start_time AND n.end_time
+    ORDER BY cb.id
+    LIMIT $2
+    `, afterID, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query link blocks", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
+	}
+
+	blocks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.ContentBlock, error) {
+		block := models.ContentBlock{Type: models.LinkBlock}
+		err := row.Scan(&block.ID, &block.NewsID, &block.Content, &block.Position)
+		return block, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan link blocks", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
+	}
+
+	return blocks, nil
+}
+
+// Categories возвращает категории с числом новостей в алфавитном порядке.
+func (r *NewsRepository) Categories(ctx context.Context, checkVisibility bool) ([]models.CategoryCount, error) {
+	const op = "NewsRepository.Categories"
+
+	where, args := newsFilterConditions(models.NewsFilter{CheckVisibility: checkVisibility})
+
+	query := `
+    SELECT n.category, COUNT(*)
+    FROM news n
+    WHERE 1=1` + where + `
+    GROUP BY n.category
+    ORDER BY n.category
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query categories", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+	defer rows.Close()
+
+	categories := make([]models.CategoryCount, 0)
+	for rows.Next() {
+		var category models.CategoryCount
+		if err := rows.Scan(&category.Name, &category.Count); err != nil {
+			logger.Log.Error(op, "Failed to scan category", err)
+			return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+		}
+		categories = append(categories, category)
+	}
+	if err := rows.Err(); err != nil {
+		logger.Log.Error(op, "Error iterating categories", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetNews, err)
+	}
+
+	return categories, nil
+}
+
+// newsFilterConditions строит условия WHERE для фильтра; параметры нумеруются с $1.
+func newsFilterConditions(filter models.NewsFilter) (string, []interface{}) {
+	where := ""
+	args := []interface{}{}
+
+	if filter.CheckVisibility {
+		where += " AND NOW() BETWEEN n.start_time AND n.end_time"
+	}
+	if filter.Search != "" {
+		args = append(args, "%"+filter.Search+"%")
+		where += fmt.Sprintf(" AND n.title ILIKE $%d", len(args))
+	}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		where += fmt.Sprintf(" AND n.category = $%d", len(args))
+	}
+
+	return where, args
+}
+
 func (r *NewsRepository) loadContentBlocks(ctx context.Context, newsList []*models.News) error {
 	const op = "NewsRepository.loadContentBlocks"
 
@@ -401,13 +765,13 @@ func (r *NewsRepository) loadContentBlocks(ctx context.Context, newsList []*mode
 	}
 
 	query := fmt.Sprintf(`
-        SELECT id, news_id, type, content, position, created_at
+        SELECT id, news_id, type, content, media_id, position, created_at
         FROM content_blocks
         WHERE news_id IN (%s)
         ORDER BY news_id, position
     `, strings.Join(placeholders, ", "))
 
-	rows, err := r.storage.GetPool().Query(ctx, query, args...)
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, args...)
 	if err != nil {
 		logger.Log.Error(op, "Failed to query content blocks", err, "newsIDs", newsIDs)
 		return fmt.Errorf("%w: %v", ErrFailedToGetContentBlocks, err)
@@ -426,6 +790,7 @@ func (r *NewsRepository) loadContentBlocks(ctx context.Context, newsList []*mode
 			&newsID,
 			&blockType,
 			&block.Content,
+			&block.MediaID,
 			&block.Position,
 			&block.CreatedAt,
 		)
@@ -454,3 +819,102 @@ func (r *NewsRepository) loadContentBlocks(ctx context.Context, newsList []*mode
 
 	return nil
 }
+
+// SlugTaken проверяет, занят ли slug другой новостью, в том числе как старый адрес из истории.
+func (r *NewsRepository) SlugTaken(ctx context.Context, slug string, exceptNewsID int64) (bool, error) {
+	const op = "NewsRepository.SlugTaken"
+
+	query := `
+    SELECT EXISTS (SELECT 1 FROM news WHERE slug = $1 AND id <> $2)
+        OR EXISTS (SELECT 1 FROM news_slug_history WHERE slug = $1 AND news_id <> $2)
+    `
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return false, ErrNoTransactionInContext
+	}
+
+	var taken bool
+	if err := tx.QueryRow(ctx, query, slug, exceptNewsID).Scan(&taken); err != nil {
+		logger.Log.Error(op, "Failed to check slug", err, "slug", slug)
+		return false, fmt.Errorf("%w: %v", ErrFailedToResolveSlug, err)
+	}
+	return taken, nil
+}
+
+// ResolveSlug находит новость по текущему или старому slug и возвращает её id и текущий slug.
+func (r *NewsRepository) ResolveSlug(ctx context.Context, slug string) (int64, string, error) {
+	const op = "NewsRepository.ResolveSlug"
+	logger.Log.Debug(op, "Resolving slug", slug)
+
+	query := `
+    SELECT n.id, n.slug
+    FROM news n
+    WHERE n.slug = $1
+    UNION ALL
+    SELECT n.id, n.slug
+    FROM news_slug_history h
+    JOIN news n ON n.id = h.news_id
+    WHERE h.slug = $1
+    LIMIT 1
+    `
+
+	var (
+		newsID      int64
+		currentSlug string
+	)
+	err := r.storage.Reader(ctx).QueryRow(ctx, query, slug).Scan(&newsID, &currentSlug)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			logger.Log.Debug(op, "Slug not found", slug)
+			return 0, "", ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to resolve slug", err, "slug", slug)
+		return 0, "", fmt.Errorf("%w: %v", ErrFailedToResolveSlug, err)
+	}
+
+	return newsID, currentSlug, nil
+}
+
+// isSlugConflict сообщает, что slug успела занять параллельная транзакция.
+func isSlugConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "news_slug_key"
+}
+
+// MoveSlug сохраняет старый slug новости в истории, чтобы по нему работал редирект.
+// Если новый slug раньше уже принадлежал этой новости, он удаляется из истории.
+func (r *NewsRepository) MoveSlug(ctx context.Context, newsID int64, oldSlug string, newSlug string) error {
+	const op = "NewsRepository.MoveSlug"
+	logger.Log.Debug(op, "newsID", newsID, "oldSlug", oldSlug, "newSlug", newSlug)
+
+	insertQuery := `
+    INSERT INTO news_slug_history (slug, news_id)
+    VALUES ($1, $2)
+    ON CONFLICT (slug) DO NOTHING
+    `
+
+	deleteQuery := `
+    DELETE FROM news_slug_history
+    WHERE slug = $1 AND news_id = $2
+    `
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	if _, err := tx.Exec(ctx, insertQuery, oldSlug, newsID); err != nil {
+		logger.Log.Error(op, "Failed to save slug history", err, "newsID", newsID)
+		return fmt.Errorf("%w: %v", ErrFailedToMoveSlug, err)
+	}
+
+	if _, err := tx.Exec(ctx, deleteQuery, newSlug, newsID); err != nil {
+		logger.Log.Error(op, "Failed to clean slug history", err, "newsID", newsID)
+		return fmt.Errorf("%w: %v", ErrFailedToMoveSlug, err)
+	}
+
+	return nil
+}
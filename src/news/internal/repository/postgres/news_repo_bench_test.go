@@ -0,0 +1,133 @@
+package postgres_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// Бенчмарки сравнивают способы вставки блоков при типичном числе блоков в статье.
+// Разница определяется числом сетевых обменов, поэтому её лучше измерять на базе
+// в другом контейнере или на другой машине, а не через loopback:
+//
+//	DB_URL_TEST=postgres://... go test -run '^$' -bench . ./src/news/internal/repository/postgres/
+
+var benchBlockCounts = []int{5, 40, 200}
+
+type insertBlocksFunc func(ctx context.Context, tx pgx.Tx, newsID int64, blocks []models.ContentBlock) error
+
+// insertBlocksPerRow — прежняя реализация: INSERT ... RETURNING на каждый блок.
+func insertBlocksPerRow(ctx context.Context, tx pgx.Tx, newsID int64, blocks []models.ContentBlock) error {
+	for i := range blocks {
+		block := &blocks[i]
+		err := tx.QueryRow(ctx, `
+            INSERT INTO content_blocks (news_id, type, content, media_id, position)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, created_at
+            `, newsID, block.Type, block.Content, block.MediaID, block.Position,
+		).Scan(&block.ID, &block.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertBlocksBatch — те же INSERT, но в одном pgx.Batch.
+func insertBlocksBatch(ctx context.Context, tx pgx.Tx, newsID int64, blocks []models.ContentBlock) error {
+	batch := &pgx.Batch{}
+	for i := range blocks {
+		block := &blocks[i]
+		batch.Queue(`
+            INSERT INTO content_blocks (news_id, type, content, media_id, position)
+            VALUES ($1, $2, $3, $4, $5)
+            RETURNING id, created_at
+            `, newsID, block.Type, block.Content, block.MediaID, block.Position,
+		).QueryRow(func(row pgx.Row) error {
+			return row.Scan(&block.ID, &block.CreatedAt)
+		})
+	}
+	return tx.SendBatch(ctx, batch).Close()
+}
+
+func benchBlocks(n int) []models.ContentBlock {
+	blocks := make([]models.ContentBlock, n)
+	for i := range blocks {
+		blocks[i] = models.ContentBlock{
+			Type:     models.TextBlock,
+			Content:  fmt.Sprintf("Абзац %d: текст средней длины, как в обычной новостной статье.", i),
+			Position: i + 1,
+		}
+	}
+	return blocks
+}
+
+func createBenchNews(b *testing.B, repo *postgres.NewsRepository, txManager storage.TxManagerInterface) *models.News {
+	news := &models.News{Title: "Bench", Slug: fmt.Sprintf("bench-%d", time.Now().UnixNano()), Category: "Bench", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	err := txManager.RunReadCommited(context.Background(), func(ctx context.Context) error {
+		return repo.Create(ctx, news)
+	})
+	require.NoError(b, err)
+	return news
+}
+
+func BenchmarkInsertContentBlocks(b *testing.B) {
+	db, cleanup := setupTestStorage(b)
+	defer cleanup()
+
+	ctx := context.Background()
+	news := createBenchNews(b, postgres.NewNewsRepository(db), storage.NewTxManagerForTest(db))
+
+	strategies := []struct {
+		name   string
+		insert insertBlocksFunc
+	}{
+		{"per_row", insertBlocksPerRow},
+		{"batch", insertBlocksBatch},
+		{"unnest", postgres.InsertContentBlocks},
+	}
+
+	for _, n := range benchBlockCounts {
+		for _, strategy := range strategies {
+			b.Run(fmt.Sprintf("blocks=%d/%s", n, strategy.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					blocks := benchBlocks(n)
+
+					tx, err := db.GetPool().Begin(ctx)
+					require.NoError(b, err)
+					require.NoError(b, strategy.insert(ctx, tx, news.ID, blocks))
+					// Откат оставляет таблицу пустой, и позиции не конфликтуют между итерациями.
+					require.NoError(b, tx.Rollback(ctx))
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkNewsRepository_Update(b *testing.B) {
+	db, cleanup := setupTestStorage(b)
+	defer cleanup()
+
+	repo := postgres.NewNewsRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	news := createBenchNews(b, repo, txManager)
+
+	for _, n := range benchBlockCounts {
+		b.Run(fmt.Sprintf("blocks=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				news.Content = benchBlocks(n)
+				err := txManager.RunReadCommited(context.Background(), func(ctx context.Context) error {
+					return repo.Update(ctx, news)
+				})
+				require.NoError(b, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// OutboxRepository хранит доменные события, записанные в той же транзакции,
+// что и изменение новости. Все методы работают только внутри транзакции.
+type OutboxRepository struct {
+	storage *storage.Storage
+}
+
+func NewOutboxRepository(storage *storage.Storage) *OutboxRepository {
+	return &OutboxRepository{
+		storage: storage,
+	}
+}
+
+// Add записывает событие в outbox и проставляет ему ID.
+func (r *OutboxRepository) Add(ctx context.Context, event *models.NewsEvent) error {
+	const op = "OutboxRepository.Add"
+	logger.Log.Debug(op, "Adding outbox event", event.Type, "newsID", event.NewsID)
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrFailedToWriteOutbox, err)
+	}
+
+	query := `
+    INSERT INTO outbox (news_id, event_type, payload)
+    VALUES ($1, $2, $3)
+    RETURNING id
+    `
+
+	if err := tx.QueryRow(ctx, query, event.NewsID, event.Type, payload).Scan(&event.ID); err != nil {
+		logger.Log.Error(op, "Failed to add outbox event", err, "newsID", event.NewsID)
+		return fmt.Errorf("%w: %v", ErrFailedToWriteOutbox, err)
+	}
+
+	return nil
+}
+
+// ClaimPending блокирует до limit готовых к отправке событий. Для каждой новости
+// берётся только самое раннее неотправленное событие, поэтому события одной новости
+// уходят строго по порядку даже при нескольких relay. Заблокированные другими
+// транзакциями записи пропускаются.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int) ([]models.OutboxEntry, error) {
+	const op = "OutboxRepository.ClaimPending"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	query := `
+    SELECT o.id, o.payload, o.status, o.attempts, COALESCE(o.last_error, ''), o.available_at
+    FROM outbox o
+    WHERE o.status = 'pending'
+      AND o.available_at <= NOW()
+      AND NOT EXISTS (
+          SELECT 1 FROM outbox p
+          WHERE p.news_id = o.news_id AND p.status = 'pending' AND p.id < o.id
+      )
+    ORDER BY o.id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+    `
+
+	rows, err := tx.Query(ctx, query, limit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to claim outbox events", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToReadOutbox, err)
+	}
+
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.OutboxEntry, error) {
+		var (
+			entry   models.OutboxEntry
+			id      int64
+			payload []byte
+		)
+		if err := row.Scan(&id, &payload, &entry.Status, &entry.Attempts, &entry.LastError, &entry.AvailableAt); err != nil {
+			return entry, err
+		}
+		if err := json.Unmarshal(payload, &entry.Event); err != nil {
+			return entry, err
+		}
+		entry.Event.ID = id
+		return entry, nil
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan outbox events", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToReadOutbox, err)
+	}
+
+	return entries, nil
+}
+
+func (r *OutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	const op = "OutboxRepository.MarkPublished"
+
+	query := `
+    UPDATE outbox
+    SET status = 'published', attempts = attempts + 1, last_error = NULL, published_at = NOW()
+    WHERE id = $1
+    `
+
+	return r.exec(ctx, op, query, id)
+}
+
+// MarkRetry откладывает повторную отправку события до availableAt.
+func (r *OutboxRepository) MarkRetry(
+	ctx context.Context,
+	id int64,
+	attempts int,
+	lastError string,
+	availableAt time.Time,
+) error {
+	const op = "OutboxRepository.MarkRetry"
+
+	query := `
+    UPDATE outbox
+    SET attempts = $2, last_error = $3, available_at = $4
+    WHERE id = $1
+    `
+
+	return r.exec(ctx, op, query, id, attempts, lastError, availableAt)
+}
+
+// MarkDead переводит событие в dead-letter: relay его больше не отправляет,
+// и следующие события той же новости перестают его ждать.
+func (r *OutboxRepository) MarkDead(ctx context.Context, id int64, attempts int, lastError string) error {
+	const op = "OutboxRepository.MarkDead"
+
+	query := `
+    UPDATE outbox
+    SET status = 'dead', attempts = $2, last_error = $3
+    WHERE id = $1
+    `
+
+	return r.exec(ctx, op, query, id, attempts, lastError)
+}
+
+// DeletePublishedBefore удаляет доставленные события старше before.
+func (r *OutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "OutboxRepository.DeletePublishedBefore"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return 0, ErrNoTransactionInContext
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM outbox WHERE status = 'published' AND published_at < $1`, before)
+	if err != nil {
+		logger.Log.Error(op, "Failed to delete published outbox events", err)
+		return 0, fmt.Errorf("%w: %v", ErrFailedToWriteOutbox, err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+func (r *OutboxRepository) exec(ctx context.Context, op string, query string, args ...any) error {
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(op, "Failed to update outbox event", err, "id", args[0])
+		return fmt.Errorf("%w: %v", ErrFailedToWriteOutbox, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestOutboxRepository_ClaimPending(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	repo := postgres.NewOutboxRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	events := []models.NewsEvent{
+		{Type: models.NewsCreated, NewsID: 1, Category: "A"},
+		{Type: models.NewsCreated, NewsID: 2, Category: "B"},
+		{Type: models.NewsUpdated, NewsID: 1, Category: "A"},
+	}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		for i := range events {
+			if err := repo.Add(ctx, &events[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	var claimed []models.OutboxEntry
+	err := txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		claimed, err = repo.ClaimPending(ctx, 10)
+		if err != nil {
+			return err
+		}
+		require.Len(t, claimed, 2, "only the head event of each news is claimed")
+		assert.Equal(t, events[0].ID, claimed[0].Event.ID)
+		assert.Equal(t, events[1].ID, claimed[1].Event.ID)
+		assert.Equal(t, "A", claimed[0].Event.Category)
+
+		if err := repo.MarkPublished(ctx, claimed[0].Event.ID); err != nil {
+			return err
+		}
+		return repo.MarkRetry(ctx, claimed[1].Event.ID, 1, "boom", time.Now().Add(time.Hour))
+	})
+	require.NoError(t, err)
+
+	err = txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		claimed, err := repo.ClaimPending(ctx, 10)
+		if err != nil {
+			return err
+		}
+		require.Len(t, claimed, 1, "delayed event is not claimed before available_at")
+		assert.Equal(t, events[2].ID, claimed[0].Event.ID)
+		return nil
+	})
+	require.NoError(t, err)
+}
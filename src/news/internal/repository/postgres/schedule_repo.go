@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// ScheduleRepository находит моменты, когда новости становятся видимыми или скрываются,
+// и хранит отметку, до которой эти моменты уже обработаны.
+type ScheduleRepository struct {
+	storage *storage.Storage
+}
+
+func NewScheduleRepository(storage *storage.Storage) *ScheduleRepository {
+	return &ScheduleRepository{
+		storage: storage,
+	}
+}
+
+// Transitions возвращает публикации (start_time) и снятия (end_time),
+// попавшие в полуинтервал (from, to], в хронологическом порядке.
+func (r *ScheduleRepository) Transitions(ctx context.Context, from, to time.Time) ([]models.NewsEvent, error) {
+	const op = "ScheduleRepository.Transitions"
+	logger.Log.Debug(op, "from", from, "to", to)
+
+	query := `
+    SELECT id, slug, category, 'published', start_time
+    FROM news
+    WHERE start_time > $1 AND start_time <= $2
+    UNION ALL
+    SELECT id, slug, category, 'expired', end_time
+    FROM news
+    WHERE end_time > $1 AND end_time <= $2
+    ORDER BY 5, 1
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, from, to)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query schedule transitions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSchedule, err)
+	}
+
+	events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.NewsEvent, error) {
+		var event models.NewsEvent
+		err := row.Scan(&event.NewsID, &event.Slug, &event.Category, &event.Type, &event.OccurredAt)
+		return event, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan schedule transitions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSchedule, err)
+	}
+
+	return events, nil
+}
+
+// NextBoundary возвращает ближайший после after момент публикации или снятия новости.
+// Второе значение false, если таких моментов нет.
+func (r *ScheduleRepository) NextBoundary(ctx context.Context, after time.Time) (time.Time, bool, error) {
+	const op = "ScheduleRepository.NextBoundary"
+
+	query := `
+    SELECT LEAST(
+        (SELECT MIN(start_time) FROM news WHERE start_time > $1),
+        (SELECT MIN(end_time) FROM news WHERE end_time > $1)
+    )
+    `
+
+	var next *time.Time
+	if err := r.storage.Reader(ctx).QueryRow(ctx, query, after).Scan(&next); err != nil {
+		logger.Log.Error(op, "Failed to query next schedule boundary", err)
+		return time.Time{}, false, fmt.Errorf("%w: %v", ErrFailedToGetSchedule, err)
+	}
+	if next == nil {
+		return time.Time{}, false, nil
+	}
+
+	return *next, true, nil
+}
+
+// Watermark возвращает момент, до которого события уже обработаны.
+// Второе значение false, если планировщик ещё ни разу не запускался.
+func (r *ScheduleRepository) Watermark(ctx context.Context) (time.Time, bool, error) {
+	const op = "ScheduleRepository.Watermark"
+
+	var processedUntil time.Time
+	err := r.storage.Reader(ctx).QueryRow(ctx, `SELECT processed_until FROM news_schedule_state`).Scan(&processedUntil)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return time.Time{}, false, nil
+		}
+		logger.Log.Error(op, "Failed to get schedule watermark", err)
+		return time.Time{}, false, fmt.Errorf("%w: %v", ErrFailedToGetSchedule, err)
+	}
+
+	return processedUntil, true, nil
+}
+
+func (r *ScheduleRepository) SaveWatermark(ctx context.Context, processedUntil time.Time) error {
+	const op = "ScheduleRepository.SaveWatermark"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO news_schedule_state (id, processed_until)
+    VALUES (TRUE, $1)
+    ON CONFLICT (id) DO UPDATE SET processed_until = EXCLUDED.processed_until
+    `
+
+	if _, err := tx.Exec(ctx, query, processedUntil); err != nil {
+		logger.Log.Error(op, "Failed to save schedule watermark", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveSchedule, err)
+	}
+
+	return nil
+}
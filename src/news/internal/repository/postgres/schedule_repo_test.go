@@ -0,0 +1,54 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestScheduleRepository(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	newsRepo := postgres.NewNewsRepository(db)
+	repo := postgres.NewScheduleRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+
+	ctx := context.Background()
+	base := time.Now().Truncate(time.Second)
+
+	news := &models.News{Title: "Scheduled", Slug: "scheduled", Category: "Schedule", StartTime: base.Add(time.Minute), EndTime: base.Add(time.Hour)}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return newsRepo.Create(ctx, news)
+	}))
+
+	next, ok, err := repo.NextBoundary(ctx, base)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, next.Equal(news.StartTime))
+
+	events, err := repo.Transitions(ctx, base, base.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, models.NewsPublished, events[0].Type)
+	assert.Equal(t, models.NewsExpired, events[1].Type)
+	assert.Equal(t, news.ID, events[1].NewsID)
+
+	_, ok, err = repo.Watermark(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.SaveWatermark(ctx, base)
+	}))
+	watermark, ok, err := repo.Watermark(ctx)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.True(t, watermark.Equal(base))
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// SitemapRepository читает видимые новости для карты сайта постранично по ключу (id),
+// не загружая блоки контента.
+type SitemapRepository struct {
+	storage *storage.Storage
+}
+
+func NewSitemapRepository(storage *storage.Storage) *SitemapRepository {
+	return &SitemapRepository{
+		storage: storage,
+	}
+}
+
+// Chunks возвращает непустые части карты сайта и время последнего изменения в каждой.
+func (r *SitemapRepository) Chunks(ctx context.Context, chunkSize int) ([]models.SitemapChunk, error) {
+	const op = "SitemapRepository.Chunks"
+	logger.Log.Debug(op, "chunkSize", chunkSize)
+
+	query := `
+    SELECT ((id - 1) / $1)::INT + 1 AS chunk, MAX(GREATEST(updated_at, start_time))
+    FROM news
+    WHERE NOW() BETWEEN start_time AND end_time
+    GROUP BY chunk
+    ORDER BY chunk
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, chunkSize)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query sitemap chunks", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSitemap, err)
+	}
+
+	chunks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SitemapChunk, error) {
+		var chunk models.SitemapChunk
+		err := row.Scan(&chunk.Number, &chunk.LastMod)
+		return chunk, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan sitemap chunks", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSitemap, err)
+	}
+
+	return chunks, nil
+}
+
+// ListVisible возвращает до limit видимых новостей с afterID < id <= maxID, упорядоченных по id.
+func (r *SitemapRepository) ListVisible(
+	ctx context.Context,
+	afterID int64,
+	maxID int64,
+	limit int,
+) ([]models.SitemapEntry, error) {
+	const op = "SitemapRepository.ListVisible"
+	logger.Log.Debug(op, "afterID", afterID, "maxID", maxID, "limit", limit)
+
+	query := `
+    SELECT id, slug, title, start_time, GREATEST(updated_at, start_time)
+    FROM news
+    WHERE id > $1 AND id <= $2 AND NOW() BETWEEN start_time AND end_time
+    ORDER BY id
+    LIMIT $3
+    `
+
+	return r.list(ctx, op, query, afterID, maxID, limit)
+}
+
+// ListPublishedSince возвращает видимые новости, опубликованные (start_time) не раньше since,
+// начиная с самых свежих.
+func (r *SitemapRepository) ListPublishedSince(
+	ctx context.Context,
+	since time.Time,
+	limit int,
+) ([]models.SitemapEntry, error) {
+	const op = "SitemapRepository.ListPublishedSince"
+	logger.Log.Debug(op, "since", since, "limit", limit)
+
+	query := `
+    SELECT id, slug, title, start_time, GREATEST(updated_at, start_time)
+    FROM news
+    WHERE start_time >= $1 AND NOW() BETWEEN start_time AND end_time
+    ORDER BY start_time DESC, id DESC
+    LIMIT $2
+    `
+
+	return r.list(ctx, op, query, since, limit)
+}
+
+func (r *SitemapRepository) list(ctx context.Context, op string, query string, args ...any) ([]models.SitemapEntry, error) {
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query sitemap entries", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSitemap, err)
+	}
+
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.SitemapEntry, error) {
+		var entry models.SitemapEntry
+		err := row.Scan(&entry.ID, &entry.Slug, &entry.Title, &entry.StartTime, &entry.LastMod)
+		return entry, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan sitemap entries", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetSitemap, err)
+	}
+
+	return entries, nil
+}
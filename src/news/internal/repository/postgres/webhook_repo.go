@@ -0,0 +1,498 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// WebhookRepository хранит подписки на вебхуки, доставки и журнал попыток.
+type WebhookRepository struct {
+	storage *storage.Storage
+}
+
+func NewWebhookRepository(storage *storage.Storage) *WebhookRepository {
+	return &WebhookRepository{
+		storage: storage,
+	}
+}
+
+const webhookSubscriptionColumns = `id, url, event_types, category, secret, active, created_at, updated_at`
+
+const webhookDeliveryColumns = `d.id, d.subscription_id, d.event_id, d.event_type, d.payload, d.status, d.attempts,
+    d.next_attempt_at, d.last_status_code, d.last_error, d.created_at, d.updated_at`
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	const op = "WebhookRepository.CreateSubscription"
+	logger.Log.Debug(op, "Creating webhook subscription", sub.URL)
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO webhook_subscriptions (url, event_types, category, secret, active)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, created_at, updated_at
+    `
+
+	err := tx.QueryRow(ctx, query, sub.URL, eventTypesToStrings(sub.EventTypes), sub.Category, sub.Secret, sub.Active).
+		Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to create webhook subscription", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	const op = "WebhookRepository.GetSubscription"
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to get webhook subscription", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	sub, err := pgx.CollectExactlyOneRow(rows, scanWebhookSubscription)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to scan webhook subscription", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return sub, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	const op = "WebhookRepository.ListSubscriptions"
+
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query)
+	if err != nil {
+		logger.Log.Error(op, "Failed to list webhook subscriptions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	subs, err := pgx.CollectRows(rows, scanWebhookSubscription)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan webhook subscriptions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return subs, nil
+}
+
+// MatchingSubscriptions возвращает активные подписки, фильтры которых пропускают событие.
+func (r *WebhookRepository) MatchingSubscriptions(
+	ctx context.Context,
+	eventType models.NewsEventType,
+	category string,
+) ([]*models.WebhookSubscription, error) {
+	const op = "WebhookRepository.MatchingSubscriptions"
+
+	query := `
+    SELECT ` + webhookSubscriptionColumns + `
+    FROM webhook_subscriptions
+    WHERE active
+      AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+      AND (category = '' OR category = $2)
+    ORDER BY id
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, string(eventType), category)
+	if err != nil {
+		logger.Log.Error(op, "Failed to query matching webhook subscriptions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	subs, err := pgx.CollectRows(rows, scanWebhookSubscription)
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan webhook subscriptions", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return subs, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, sub *models.WebhookSubscription) error {
+	const op = "WebhookRepository.UpdateSubscription"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE webhook_subscriptions
+    SET url = $2, event_types = $3, category = $4, secret = $5, active = $6, updated_at = NOW()
+    WHERE id = $1
+    RETURNING updated_at
+    `
+
+	err := tx.QueryRow(ctx, query, sub.ID, sub.URL, eventTypesToStrings(sub.EventTypes), sub.Category, sub.Secret, sub.Active).
+		Scan(&sub.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to update webhook subscription", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	const op = "WebhookRepository.DeleteSubscription"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	result, err := tx.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to delete webhook subscription", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// CreateDeliveries ставит событие в очередь доставки. Повторная постановка того же
+// события той же подписке игнорируется, поэтому повторы из outbox не дублируют отправку.
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	const op = "WebhookRepository.CreateDeliveries"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+    VALUES ($1, $2, $3, $4)
+    ON CONFLICT (subscription_id, event_id) DO NOTHING
+    `
+
+	for _, delivery := range deliveries {
+		_, err := tx.Exec(ctx, query, delivery.SubscriptionID, delivery.EventID, delivery.EventType, delivery.Payload)
+		if err != nil {
+			logger.Log.Error(op, "Failed to create webhook delivery", err, "subscriptionID", delivery.SubscriptionID)
+			return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+		}
+	}
+
+	return nil
+}
+
+// ClaimDueDeliveries выбирает до limit доставок, которым пора уходить, и сдвигает
+// их next_attempt_at на lease вперёд: пока отправка идёт, другие воркеры их не возьмут,
+// а если воркер упадёт, доставка вернётся в очередь по истечении lease.
+func (r *WebhookRepository) ClaimDueDeliveries(
+	ctx context.Context,
+	limit int,
+	lease time.Duration,
+) ([]models.WebhookDelivery, error) {
+	const op = "WebhookRepository.ClaimDueDeliveries"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return nil, ErrNoTransactionInContext
+	}
+
+	query := `
+    WITH due AS (
+        SELECT id FROM webhook_deliveries
+        WHERE status = 'pending' AND next_attempt_at <= NOW()
+        ORDER BY next_attempt_at
+        LIMIT $1
+        FOR UPDATE SKIP LOCKED
+    )
+    UPDATE webhook_deliveries d
+    SET next_attempt_at = NOW() + $2::INTERVAL
+    FROM due, webhook_subscriptions s
+    WHERE d.id = due.id AND s.id = d.subscription_id
+    RETURNING ` + webhookDeliveryColumns + `, s.url, s.secret
+    `
+
+	rows, err := tx.Query(ctx, query, limit, lease)
+	if err != nil {
+		logger.Log.Error(op, "Failed to claim webhook deliveries", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookDelivery, error) {
+		var delivery models.WebhookDelivery
+		err := row.Scan(append(webhookDeliveryDest(&delivery), &delivery.URL, &delivery.Secret)...)
+		return delivery, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan webhook deliveries", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordAttempt пишет попытку в журнал и сохраняет новое состояние доставки.
+func (r *WebhookRepository) RecordAttempt(
+	ctx context.Context,
+	delivery *models.WebhookDelivery,
+	attempt *models.WebhookAttempt,
+) error {
+	const op = "WebhookRepository.RecordAttempt"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	insertQuery := `
+    INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms)
+    VALUES ($1, $2, $3, $4, $5)
+    RETURNING id, created_at
+    `
+
+	err := tx.QueryRow(ctx, insertQuery,
+		attempt.DeliveryID,
+		attempt.Attempt,
+		attempt.StatusCode,
+		attempt.Error,
+		attempt.Duration.Milliseconds(),
+	).Scan(&attempt.ID, &attempt.CreatedAt)
+	if err != nil {
+		logger.Log.Error(op, "Failed to record webhook attempt", err, "deliveryID", attempt.DeliveryID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+
+	updateQuery := `
+    UPDATE webhook_deliveries
+    SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, updated_at = NOW()
+    WHERE id = $1
+    RETURNING updated_at
+    `
+
+	err = tx.QueryRow(ctx, updateQuery,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+	).Scan(&delivery.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to update webhook delivery", err, "deliveryID", delivery.ID)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+
+	return nil
+}
+
+// ListDeliveries возвращает страницу журнала доставок подписки, начиная с новых.
+// Пустой status — доставки в любом статусе.
+func (r *WebhookRepository) ListDeliveries(
+	ctx context.Context,
+	subscriptionID int64,
+	status models.WebhookDeliveryStatus,
+	offset int,
+	limit int,
+) ([]models.WebhookDelivery, int64, error) {
+	const op = "WebhookRepository.ListDeliveries"
+
+	filter := `WHERE d.subscription_id = $1 AND ($2 = '' OR d.status = $2)`
+
+	var total int64
+	err := r.storage.Reader(ctx).QueryRow(ctx, `SELECT COUNT(*) FROM webhook_deliveries d `+filter, subscriptionID, string(status)).
+		Scan(&total)
+	if err != nil {
+		logger.Log.Error(op, "Failed to count webhook deliveries", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries d ` + filter + `
+    ORDER BY d.id DESC
+    LIMIT $3 OFFSET $4`
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, subscriptionID, string(status), limit, offset)
+	if err != nil {
+		logger.Log.Error(op, "Failed to list webhook deliveries", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookDelivery, error) {
+		var delivery models.WebhookDelivery
+		err := row.Scan(webhookDeliveryDest(&delivery)...)
+		return delivery, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan webhook deliveries", err)
+		return nil, 0, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return deliveries, total, nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	const op = "WebhookRepository.GetDelivery"
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries d WHERE d.id = $1`
+
+	var delivery models.WebhookDelivery
+	if err := r.storage.Reader(ctx).QueryRow(ctx, query, id).Scan(webhookDeliveryDest(&delivery)...); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		logger.Log.Error(op, "Failed to get webhook delivery", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return &delivery, nil
+}
+
+func (r *WebhookRepository) ListAttempts(ctx context.Context, deliveryID int64) ([]models.WebhookAttempt, error) {
+	const op = "WebhookRepository.ListAttempts"
+
+	query := `
+    SELECT id, delivery_id, attempt, status_code, error, duration_ms, created_at
+    FROM webhook_delivery_attempts
+    WHERE delivery_id = $1
+    ORDER BY id
+    `
+
+	rows, err := r.storage.Reader(ctx).Query(ctx, query, deliveryID)
+	if err != nil {
+		logger.Log.Error(op, "Failed to list webhook attempts", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	attempts, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookAttempt, error) {
+		var (
+			attempt    models.WebhookAttempt
+			durationMS int64
+		)
+		err := row.Scan(
+			&attempt.ID,
+			&attempt.DeliveryID,
+			&attempt.Attempt,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&durationMS,
+			&attempt.CreatedAt,
+		)
+		attempt.Duration = time.Duration(durationMS) * time.Millisecond
+		return attempt, err
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to scan webhook attempts", err)
+		return nil, fmt.Errorf("%w: %v", ErrFailedToGetWebhooks, err)
+	}
+
+	return attempts, nil
+}
+
+// Redeliver возвращает доставку в очередь с полным запасом попыток.
+// Журнал прошлых попыток сохраняется.
+func (r *WebhookRepository) Redeliver(ctx context.Context, id int64) error {
+	const op = "WebhookRepository.Redeliver"
+
+	tx, ok := storage.GetTxFromContext(ctx)
+	if !ok {
+		logger.Log.Error(op, "No transaction found in context", nil)
+		return ErrNoTransactionInContext
+	}
+
+	query := `
+    UPDATE webhook_deliveries
+    SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+    WHERE id = $1
+    `
+
+	result, err := tx.Exec(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(op, "Failed to redeliver webhook", err)
+		return fmt.Errorf("%w: %v", ErrFailedToSaveWebhook, err)
+	}
+	if result.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func scanWebhookSubscription(row pgx.CollectableRow) (*models.WebhookSubscription, error) {
+	var (
+		sub        models.WebhookSubscription
+		eventTypes []string
+	)
+	err := row.Scan(
+		&sub.ID,
+		&sub.URL,
+		&eventTypes,
+		&sub.Category,
+		&sub.Secret,
+		&sub.Active,
+		&sub.CreatedAt,
+		&sub.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sub.EventTypes = make([]models.NewsEventType, len(eventTypes))
+	for i, eventType := range eventTypes {
+		sub.EventTypes[i] = models.NewsEventType(eventType)
+	}
+	return &sub, nil
+}
+
+func webhookDeliveryDest(delivery *models.WebhookDelivery) []any {
+	return []any{
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	}
+}
+
+func eventTypesToStrings(eventTypes []models.NewsEventType) []string {
+	result := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		result[i] = string(eventType)
+	}
+	return result
+}
@@ -0,0 +1,98 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	postgres "github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestWebhookRepository_Deliveries(t *testing.T) {
+	db, cleanup := setupTestStorage(t)
+	defer cleanup()
+
+	repo := postgres.NewWebhookRepository(db)
+	txManager := storage.NewTxManagerForTest(db)
+	ctx := context.Background()
+
+	subs := []*models.WebhookSubscription{
+		{URL: "https://a.example/hook", Secret: "a", Active: true},
+		{URL: "https://b.example/hook", Secret: "b", Active: true, EventTypes: []models.NewsEventType{models.NewsDeleted}},
+		{URL: "https://c.example/hook", Secret: "c", Active: true, Category: "sport"},
+	}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		for _, sub := range subs {
+			if err := repo.CreateSubscription(ctx, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	matching, err := repo.MatchingSubscriptions(ctx, models.NewsCreated, "tech")
+	require.NoError(t, err)
+	require.Len(t, matching, 1, "event type and category filters apply")
+	assert.Equal(t, subs[0].ID, matching[0].ID)
+
+	delivery := &models.WebhookDelivery{
+		SubscriptionID: subs[0].ID,
+		EventID:        1,
+		EventType:      models.NewsCreated,
+		Payload:        []byte(`{"id":1}`),
+	}
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := repo.CreateDeliveries(ctx, []*models.WebhookDelivery{delivery}); err != nil {
+			return err
+		}
+		// Повторная публикация того же события не создаёт дубликат.
+		return repo.CreateDeliveries(ctx, []*models.WebhookDelivery{delivery})
+	}))
+
+	var claimed []models.WebhookDelivery
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		claimed, err = repo.ClaimDueDeliveries(ctx, 10, time.Minute)
+		return err
+	}))
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "https://a.example/hook", claimed[0].URL)
+	assert.Equal(t, "a", claimed[0].Secret)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		again, err := repo.ClaimDueDeliveries(ctx, 10, time.Minute)
+		require.Empty(t, again, "leased delivery is hidden from other workers")
+		return err
+	}))
+
+	claimed[0].Status = models.WebhookFailed
+	claimed[0].Attempts = 1
+	claimed[0].LastStatusCode = 500
+	claimed[0].LastError = "boom"
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.RecordAttempt(ctx, &claimed[0], &models.WebhookAttempt{
+			DeliveryID: claimed[0].ID,
+			Attempt:    1,
+			StatusCode: 500,
+			Error:      "boom",
+			Duration:   30 * time.Millisecond,
+		})
+	}))
+
+	attempts, err := repo.ListAttempts(ctx, claimed[0].ID)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, 500, attempts[0].StatusCode)
+
+	require.NoError(t, txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return repo.Redeliver(ctx, claimed[0].ID)
+	}))
+
+	got, err := repo.GetDelivery(ctx, claimed[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.WebhookPending, got.Status)
+	assert.Equal(t, 0, got.Attempts)
+}
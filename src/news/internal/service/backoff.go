@@ -0,0 +1,16 @@
+package service
+
+import "time"
+
+// exponentialBackoff возвращает задержку после attempts неудачных попыток:
+// base * 2^(attempts-1), но не больше maxDelay (если он задан).
+func exponentialBackoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && (maxDelay <= 0 || delay < maxDelay); i++ {
+		delay *= 2
+	}
+	if maxDelay > 0 {
+		delay = min(delay, maxDelay)
+	}
+	return delay
+}
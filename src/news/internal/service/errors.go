@@ -0,0 +1,27 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrInvalidMediaReference  = errors.New("invalid media reference")
+	ErrMediaTooLarge          = errors.New("media file is too large")
+	ErrUnsupportedMediaType   = errors.New("unsupported media type")
+	ErrNewsNotLive            = errors.New("news is not a live blog")
+	ErrInvalidCursor          = errors.New("invalid cursor")
+	ErrInvalidFields          = errors.New("invalid fields")
+	ErrImportQueueUnavailable = errors.New("import job queue is not configured")
+	ErrImportFileTooLarge     = errors.New("import file is too large")
+	ErrEmptyImportFile        = errors.New("import file is empty")
+)
+
+// SlugMovedError означает, что запрошен старый slug и новость доступна по CurrentSlug.
+type SlugMovedError struct {
+	CurrentSlug string
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("slug moved to %q", e.CurrentSlug)
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gorilla/feeds"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// feedCacheKeys — множество в Redis со всеми закешированными лентами,
+// чтобы при изменении новостей сбросить их разом.
+const feedCacheKeys = "feeds:keys"
+
+var feedContentTypes = map[string]string{
+	dto.FeedFormatRSS:  "application/rss+xml; charset=utf-8",
+	dto.FeedFormatAtom: "application/atom+xml; charset=utf-8",
+	dto.FeedFormatJSON: "application/feed+json; charset=utf-8",
+}
+
+type FeedService struct {
+	newsRepo  NewsRepository
+	txManager storage.TxManagerInterface
+	redis     RedisClient
+	site      config.SiteConfig
+	limit     int
+	cacheTTL  time.Duration
+}
+
+func NewFeedService(
+	newsRepo NewsRepository,
+	txManager storage.TxManagerInterface,
+	redis RedisClient,
+	site config.SiteConfig,
+	cfg config.FeedsConfig,
+) *FeedService {
+	return &FeedService{
+		newsRepo:  newsRepo,
+		txManager: txManager,
+		redis:     redis,
+		site:      site,
+		limit:     cfg.Limit,
+		cacheTTL:  cfg.CacheTTL,
+	}
+}
+
+// GetFeed возвращает ленту в формате RSS 2.0, Atom или JSON Feed
+// из последних видимых новостей, опционально только одной категории.
+func (s *FeedService) GetFeed(
+	ctx context.Context,
+	req dto.FeedRequest,
+) (*dto.FeedResponse, error) {
+	const op = "service.FeedService.GetFeed"
+
+	cacheKey := fmt.Sprintf("feeds:%s:%s", req.Format, req.Category)
+
+	cached, err := s.redis.GetRedis().Get(ctx, cacheKey).Bytes()
+	if err == nil {
+		var resp dto.FeedResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			logger.Log.Debug(op, "Cache hit for feed", cacheKey)
+			return &resp, nil
+		}
+		logger.Log.Error(op, "Failed to unmarshal cached feed", err)
+	}
+
+	var feed *feeds.Feed
+
+	// Новости ленты и их блоки читаются из одного снимка.
+	err = s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+		var err error
+		feed, err = s.buildFeed(ctx, req.Category)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var body string
+	switch req.Format {
+	case dto.FeedFormatRSS:
+		body, err = feed.ToRss()
+	case dto.FeedFormatAtom:
+		body, err = feed.ToAtom()
+	case dto.FeedFormatJSON:
+		body, err = feed.ToJSON()
+	default:
+		return nil, fmt.Errorf("unknown feed format: %s", req.Format)
+	}
+	if err != nil {
+		logger.Log.Error(op, "Failed to render feed", err)
+		return nil, err
+	}
+
+	sum := sha256.Sum256([]byte(body))
+	resp := &dto.FeedResponse{
+		Body:         []byte(body),
+		ContentType:  feedContentTypes[req.Format],
+		ETag:         `"` + hex.EncodeToString(sum[:16]) + `"`,
+		LastModified: feed.Updated.UTC().Truncate(time.Second),
+	}
+
+	toCache, err := json.Marshal(resp)
+	if err != nil {
+		logger.Log.Error(op, "Failed to marshal feed for caching", err)
+		return resp, nil
+	}
+
+	pipe := s.redis.GetRedis().TxPipeline()
+	pipe.Set(ctx, cacheKey, toCache, s.cacheTTL)
+	pipe.SAdd(ctx, feedCacheKeys, cacheKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Log.Error(op, "Failed to set cache", cacheKey, "error", err)
+	}
+
+	return resp, nil
+}
+
+func (s *FeedService) buildFeed(ctx context.Context, category string) (*feeds.Feed, error) {
+	newsList, _, err := s.newsRepo.List(ctx, 0, s.limit, "", category, "start_time", "desc", true, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	title := s.site.Title
+	if category != "" {
+		title = fmt.Sprintf("%s — %s", s.site.Title, category)
+	}
+
+	feed := &feeds.Feed{
+		Title:       title,
+		Link:        &feeds.Link{Href: s.site.BaseURL},
+		Description: s.site.Description,
+		Id:          s.site.BaseURL,
+	}
+
+	for _, news := range newsList {
+		if !news.IsVisible() {
+			continue
+		}
+
+		// Новость появляется в ленте в start_time, поэтому изменением считается
+		// и правка, и начало показа.
+		updated := news.StartTime
+		if news.UpdatedAt.After(updated) {
+			updated = news.UpdatedAt
+		}
+
+		link := newsURL(s.site, news.ID, news.Slug)
+		feed.Add(&feeds.Item{
+			Id:          link,
+			Title:       news.Title,
+			Link:        &feeds.Link{Href: link},
+			Description: renderContentText(news.Content),
+			Content:     renderContentHTML(news.Content, s.site.BaseURL),
+			Created:     news.StartTime,
+			Updated:     updated,
+		})
+
+		if updated.After(feed.Updated) {
+			feed.Updated = updated
+		}
+	}
+	// Время ленты берётся из новостей, а не из момента сборки, чтобы пересборка
+	// кеша без изменений давала тот же ETag и Last-Modified.
+	feed.Created = feed.Updated
+
+	return feed, nil
+}
+
+// invalidateFeedCache удаляет все закешированные ленты.
+func invalidateFeedCache(ctx context.Context, redis RedisClient) {
+	const op = "service.invalidateFeedCache"
+
+	keys, err := redis.GetRedis().SMembers(ctx, feedCacheKeys).Result()
+	if err != nil {
+		logger.Log.Error(op, "Failed to get feed cache keys", err)
+		return
+	}
+
+	keys = append(keys, feedCacheKeys)
+	if err := redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+		logger.Log.Error(op, "Failed to invalidate feed cache", keys, "error", err)
+	}
+}
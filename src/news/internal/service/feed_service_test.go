@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeFeedRepo отдаёт для ленты один и тот же набор новостей.
+type fakeFeedRepo struct {
+	service.NewsRepository
+	news []*models.News
+}
+
+func (r *fakeFeedRepo) List(ctx context.Context, offset, limit int, search, category, sortBy, sortDir string, checkVisibility bool, fields []string, withContent bool) ([]*models.News, int64, error) {
+	return r.news, int64(len(r.news)), nil
+}
+
+func TestFeedService_GetFeedLastModified(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	edited := time.Date(2025, 9, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeFeedRepo{news: []*models.News{
+		{ID: 1, Title: "Edited", StartTime: edited.Add(-time.Hour), EndTime: time.Now().Add(time.Hour), UpdatedAt: edited},
+		{ID: 2, Title: "Old", StartTime: edited.Add(-2 * time.Hour), EndTime: time.Now().Add(time.Hour), UpdatedAt: edited.Add(-2 * time.Hour)},
+	}}
+	redis, mr := newFakeRedis(t)
+	feeds := service.NewFeedService(repo, fakeTxManager{}, redis, config.SiteConfig{Title: "News", BaseURL: "http://example.com", NewsPath: "/news/{id}"}, config.FeedsConfig{Limit: 10, CacheTTL: time.Minute})
+
+	first, err := feeds.GetFeed(ctx, dto.FeedRequest{Format: dto.FeedFormatRSS})
+	require.NoError(t, err)
+	assert.Equal(t, edited, first.LastModified)
+
+	// После пересборки кеша без изменений в новостях условный GET по-прежнему работает.
+	mr.FlushAll()
+	time.Sleep(time.Second)
+	second, err := feeds.GetFeed(ctx, dto.FeedRequest{Format: dto.FeedFormatRSS})
+	require.NoError(t, err)
+	assert.Equal(t, first.LastModified, second.LastModified)
+	assert.Equal(t, first.ETag, second.ETag)
+}
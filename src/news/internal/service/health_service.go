@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/migrate"
+)
+
+// readinessTimeout ограничивает все проверки готовности вместе, чтобы зависшая
+// зависимость не держала запрос балансировщика.
+const readinessTimeout = 3 * time.Second
+
+// Pinger — зависимость, доступность которой проверяется запросом.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// SchemaState сообщает, какие миграции применены к базе.
+type SchemaState interface {
+	State(ctx context.Context) (migrate.State, error)
+}
+
+type HealthService struct {
+	db     Pinger
+	redis  RedisClient
+	schema SchemaState
+}
+
+func NewHealthService(db Pinger, redis RedisClient, schema SchemaState) *HealthService {
+	return &HealthService{
+		db:     db,
+		redis:  redis,
+		schema: schema,
+	}
+}
+
+// Readiness проверяет Postgres, Redis и схему базы. Сервис готов, только если все
+// проверки прошли и все известные ему миграции применены.
+func (s *HealthService) Readiness(ctx context.Context) *dto.ReadinessResponse {
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	resp := &dto.ReadinessResponse{
+		Status: dto.ReadinessOK,
+		Checks: map[string]string{},
+	}
+	check := func(name string, err error) {
+		if err != nil {
+			resp.Status = dto.ReadinessUnavailable
+			resp.Checks[name] = err.Error()
+			return
+		}
+		resp.Checks[name] = dto.ReadinessOK
+	}
+
+	check("postgres", s.db.Ping(ctx))
+	check("redis", s.redis.GetRedis().Ping(ctx).Err())
+
+	state, err := s.schema.State(ctx)
+	if err == nil {
+		resp.Migrations = &dto.MigrationStatus{
+			Current: state.Current,
+			Latest:  state.Latest,
+			Pending: state.Pending,
+		}
+		switch {
+		case state.TooNew():
+			err = migrate.ErrSchemaTooNew
+		case state.Pending > 0:
+			err = fmt.Errorf("%d pending migrations", state.Pending)
+		}
+	}
+	check("migrations", err)
+
+	return resp
+}
@@ -0,0 +1,46 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/migrate"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type fakePinger struct{ err error }
+
+func (p fakePinger) Ping(ctx context.Context) error { return p.err }
+
+type fakeSchema struct{ state migrate.State }
+
+func (s fakeSchema) State(ctx context.Context) (migrate.State, error) { return s.state, nil }
+
+func TestHealthService_Readiness(t *testing.T) {
+	ctx := context.Background()
+	redis, mr := newFakeRedis(t)
+	upToDate := fakeSchema{migrate.State{Current: 3, Latest: 3}}
+
+	resp := service.NewHealthService(fakePinger{}, redis, upToDate).Readiness(ctx)
+	assert.Equal(t, dto.ReadinessOK, resp.Status)
+	assert.Equal(t, map[string]string{"postgres": "ok", "redis": "ok", "migrations": "ok"}, resp.Checks)
+	assert.Equal(t, &dto.MigrationStatus{Current: 3, Latest: 3}, resp.Migrations)
+
+	resp = service.NewHealthService(fakePinger{}, redis, fakeSchema{migrate.State{Current: 2, Latest: 3, Pending: 1}}).Readiness(ctx)
+	assert.Equal(t, dto.ReadinessUnavailable, resp.Status)
+	assert.Equal(t, "1 pending migrations", resp.Checks["migrations"])
+
+	resp = service.NewHealthService(fakePinger{}, redis, fakeSchema{migrate.State{Current: 4, Latest: 3}}).Readiness(ctx)
+	assert.Equal(t, dto.ReadinessUnavailable, resp.Status)
+	assert.Equal(t, migrate.ErrSchemaTooNew.Error(), resp.Checks["migrations"])
+
+	mr.Close()
+	resp = service.NewHealthService(fakePinger{errors.New("db down")}, redis, upToDate).Readiness(ctx)
+	assert.Equal(t, dto.ReadinessUnavailable, resp.Status)
+	assert.Equal(t, "db down", resp.Checks["postgres"])
+	assert.NotEqual(t, "ok", resp.Checks["redis"])
+	assert.Equal(t, "ok", resp.Checks["migrations"])
+}
@@ -0,0 +1,612 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/cron"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+const (
+	// JobKindJobsCleanup удаляет завершённые задачи старше older_than (по умолчанию неделя).
+	JobKindJobsCleanup = "jobs.cleanup"
+
+	// scheduleBatchSize — сколько сработавших расписаний обрабатывается за одну транзакцию.
+	scheduleBatchSize = 100
+)
+
+type JobRepository interface {
+	Enqueue(ctx context.Context, job *models.Job) error
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]models.Job, error)
+	Finish(ctx context.Context, job *models.Job) error
+	Release(ctx context.Context, job *models.Job) error
+	Get(ctx context.Context, id int64) (*models.Job, error)
+	List(ctx context.Context, status models.JobStatus, kind string, offset int, limit int) ([]*models.Job, int64, error)
+	Retry(ctx context.Context, id int64) (*models.Job, error)
+	Cancel(ctx context.Context, id int64) (*models.Job, error)
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+	SyncSchedules(ctx context.Context, schedules []models.JobSchedule, retired []string) error
+	ClaimDueSchedules(ctx context.Context, limit int) ([]models.JobSchedule, error)
+	AdvanceSchedule(ctx context.Context, name string, next time.Time) error
+}
+
+// JobHandler выполняет задачу одного вида. Ошибка означает повтор с задержкой,
+// пока не кончатся попытки; ошибку, которую повторять бессмысленно, нужно
+// обернуть в PermanentJobError. Обработчик должен быть идемпотентным: задачу,
+// чей воркер пропал, выполнит другой воркер.
+type JobHandler func(ctx context.Context, job *models.Job) error
+
+type permanentJobError struct {
+	err error
+}
+
+func (e *permanentJobError) Error() string { return e.err.Error() }
+func (e *permanentJobError) Unwrap() error { return e.err }
+
+// PermanentJobError помечает ошибку, после которой задача сразу получает статус failed.
+func PermanentJobError(err error) error {
+	return &permanentJobError{err: err}
+}
+
+func isPermanentJobError(err error) bool {
+	var permanent *permanentJobError
+	return errors.As(err, &permanent)
+}
+
+// JobQueue выполняет фоновые задачи из таблицы jobs. Воркеры любой реплики
+// забирают задачи через FOR UPDATE SKIP LOCKED; задача, чей воркер не уложился
+// в lease, возвращается в очередь. Расписания crontab из конфига ставят задачи
+// в ту же очередь.
+type JobQueue struct {
+	repo      JobRepository
+	txManager storage.TxManagerInterface
+	handlers  map[string]JobHandler
+	schedules []models.JobSchedule
+	retired   []string
+	// parsed — разобранные расписания по строке crontab.
+	parsed map[string]*cron.Schedule
+
+	workers      int
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+	drainTimeout time.Duration
+
+	cancel context.CancelFunc
+	abort  context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewJobQueue вычисляет первый запуск расписаний из конфига, уже проверенного
+// config.Validate, и отклоняет расписания, которые никогда не срабатывают.
+// Задачи jobs.cleanup обрабатываются самой очередью, остальные виды
+// регистрируются через Register.
+func NewJobQueue(repo JobRepository, txManager storage.TxManagerInterface, cfg config.JobsConfig) (*JobQueue, error) {
+	q := &JobQueue{
+		repo:         repo,
+		txManager:    txManager,
+		handlers:     make(map[string]JobHandler),
+		retired:      cfg.RetiredCron,
+		parsed:       make(map[string]*cron.Schedule),
+		workers:      max(cfg.Workers, 1),
+		pollInterval: cfg.PollInterval,
+		lease:        cfg.Lease,
+		maxAttempts:  max(cfg.MaxAttempts, 1),
+		retryBackoff: cfg.RetryBackoff,
+		maxBackoff:   cfg.MaxBackoff,
+		drainTimeout: cfg.DrainTimeout,
+	}
+
+	now := time.Now()
+	for _, entry := range cfg.Cron {
+		schedule, err := cron.Parse(entry.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("cron job %q: %w", entry.Name, err)
+		}
+		next := schedule.Next(now)
+		if next.IsZero() {
+			return nil, fmt.Errorf("cron job %q: schedule %q never fires", entry.Name, entry.Schedule)
+		}
+
+		payload, err := json.Marshal(entry.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("cron job %q: %w", entry.Name, err)
+		}
+		if entry.Payload == nil {
+			payload = []byte("{}")
+		}
+
+		q.parsed[entry.Schedule] = schedule
+		q.schedules = append(q.schedules, models.JobSchedule{
+			Name:      entry.Name,
+			Kind:      entry.Kind,
+			Schedule:  entry.Schedule,
+			Payload:   payload,
+			NextRunAt: next,
+		})
+	}
+
+	q.Register(JobKindJobsCleanup, q.cleanup)
+	return q, nil
+}
+
+// Register задаёт обработчик вида задач. Вызывается до Start.
+func (q *JobQueue) Register(kind string, handler JobHandler) {
+	q.handlers[kind] = handler
+}
+
+// Start запускает воркеры и постановку задач по расписанию. Задачи выполняются
+// в отдельном контексте, чтобы при остановке их можно было дождаться.
+func (q *JobQueue) Start(ctx context.Context) {
+	claimCtx, cancel := context.WithCancel(ctx)
+	runCtx, abort := context.WithCancel(context.WithoutCancel(ctx))
+	q.cancel, q.abort = cancel, abort
+
+	for range q.workers {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			q.work(claimCtx, runCtx)
+		}()
+	}
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		q.runSchedules(claimCtx)
+	}()
+}
+
+// Stop перестаёт забирать задачи и ждёт выполняющиеся не дольше drainTimeout,
+// после чего отменяет их контекст. Прерванные задачи возвращаются в очередь без
+// списания попытки.
+func (q *JobQueue) Stop() {
+	const op = "service.JobQueue.Stop"
+
+	if q.cancel == nil {
+		return
+	}
+	q.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(q.drainTimeout):
+		logger.Log.Warn(op, "Drain timeout exceeded, canceling running jobs", q.drainTimeout)
+		q.abort()
+		<-done
+	}
+	q.abort()
+}
+
+// Enqueue ставит задачу в очередь в транзакции из контекста, так что задача
+// появится вместе с данными, ради которых она создана. Нулевой runAt — выполнить сразу.
+func (q *JobQueue) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (*models.Job, error) {
+	const op = "service.JobQueue.Enqueue"
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Log.Error(op, "Failed to marshal job payload", err, "kind", kind)
+		return nil, err
+	}
+	if runAt.IsZero() {
+		runAt = time.Now()
+	}
+
+	job := &models.Job{
+		Kind:        kind,
+		Payload:     data,
+		MaxAttempts: q.maxAttempts,
+		RunAt:       runAt,
+	}
+	if err := q.repo.Enqueue(ctx, job); err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Job enqueued", job.ID, "kind", kind)
+	return job, nil
+}
+
+func (q *JobQueue) work(claimCtx, runCtx context.Context) {
+	const op = "service.JobQueue.work"
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-claimCtx.Done():
+			return
+		case <-timer.C:
+		}
+
+		ran, err := q.runNext(claimCtx, runCtx)
+		if err != nil {
+			logger.Log.Error(op, "Failed to run job", err)
+		}
+
+		if err == nil && ran {
+			timer.Reset(0)
+		} else {
+			timer.Reset(q.pollInterval)
+		}
+	}
+}
+
+// RunNext забирает одну задачу, которой пора выполняться, выполняет её и
+// сохраняет результат. Возвращает false, если очередь пуста.
+func (q *JobQueue) RunNext(ctx context.Context) (bool, error) {
+	return q.runNext(ctx, ctx)
+}
+
+func (q *JobQueue) runNext(claimCtx, runCtx context.Context) (bool, error) {
+	var jobs []models.Job
+	err := q.txManager.RunReadCommited(claimCtx, func(ctx context.Context) error {
+		var err error
+		jobs, err = q.repo.Claim(ctx, 1, q.lease)
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(jobs) == 0 {
+		return false, nil
+	}
+
+	job := &jobs[0]
+	// Попытки растут при захвате, поэтому задача, чей воркер пропадал каждый раз,
+	// набирает лишнюю попытку и больше не выполняется.
+	if job.Attempts > job.MaxAttempts {
+		q.finish(runCtx, job, PermanentJobError(errors.New("lease expired on the last attempt")))
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(runCtx, q.lease)
+	err = q.execute(ctx, job)
+	cancel()
+
+	q.finish(runCtx, job, err)
+	return true, nil
+}
+
+func (q *JobQueue) execute(ctx context.Context, job *models.Job) (err error) {
+	handler, ok := q.handlers[job.Kind]
+	if !ok {
+		return PermanentJobError(fmt.Errorf("unknown job kind %q", job.Kind))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, job)
+}
+
+// finish сохраняет результат попытки. Результат отменённой или заново
+// захваченной задачи отбрасывается.
+func (q *JobQueue) finish(runCtx context.Context, job *models.Job, runErr error) {
+	const op = "service.JobQueue.finish"
+
+	now := time.Now()
+	job.LastError = ""
+	job.FinishedAt = nil
+
+	interrupted := runErr != nil && runCtx.Err() != nil && !isPermanentJobError(runErr)
+	switch {
+	case runErr == nil:
+		job.Status = models.JobSucceeded
+		job.FinishedAt = &now
+	case interrupted:
+		job.LastError = runErr.Error()
+	case isPermanentJobError(runErr) || job.Attempts >= job.MaxAttempts:
+		job.Status = models.JobFailed
+		job.LastError = runErr.Error()
+		job.FinishedAt = &now
+		logger.Log.Error(op, "Job failed permanently", runErr, "jobID", job.ID, "kind", job.Kind)
+	default:
+		job.Status = models.JobPending
+		job.LastError = runErr.Error()
+		job.RunAt = now.Add(exponentialBackoff(q.retryBackoff, q.maxBackoff, job.Attempts))
+		logger.Log.Warn(op, "Job failed, will retry", runErr, "jobID", job.ID, "kind", job.Kind)
+	}
+
+	// Результат нужно сохранить, даже если сервис уже останавливается.
+	err := q.txManager.RunReadCommited(context.WithoutCancel(runCtx), func(ctx context.Context) error {
+		if interrupted {
+			return q.repo.Release(ctx, job)
+		}
+		return q.repo.Finish(ctx, job)
+	})
+	switch {
+	case errors.Is(err, postgres.ErrNotFound):
+		logger.Log.Warn(op, "Job was canceled or reclaimed, result discarded", job.ID, "kind", job.Kind)
+	case err != nil:
+		logger.Log.Error(op, "Failed to save job result", err, "jobID", job.ID)
+	}
+}
+
+func (q *JobQueue) runSchedules(ctx context.Context) {
+	const op = "service.JobQueue.runSchedules"
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	synced := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+		timer.Reset(q.pollInterval)
+
+		if !synced {
+			err := q.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+				return q.repo.SyncSchedules(ctx, q.schedules, q.retired)
+			})
+			if err != nil {
+				logger.Log.Error(op, "Failed to sync job schedules", err)
+				continue
+			}
+			synced = true
+		}
+
+		if _, err := q.EnqueueDueSchedules(ctx); err != nil {
+			logger.Log.Error(op, "Failed to enqueue scheduled jobs", err)
+		}
+	}
+}
+
+// EnqueueDueSchedules ставит в очередь задачи сработавших расписаний и
+// переносит их следующий запуск. Пропущенные, пока сервис не работал, запуски
+// не наверстываются: ставится одна задача.
+func (q *JobQueue) EnqueueDueSchedules(ctx context.Context) (int, error) {
+	const op = "service.JobQueue.EnqueueDueSchedules"
+
+	var enqueued int
+	err := q.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		enqueued = 0
+
+		due, err := q.repo.ClaimDueSchedules(ctx, scheduleBatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, schedule := range due {
+			job := &models.Job{
+				Kind:         schedule.Kind,
+				Payload:      schedule.Payload,
+				MaxAttempts:  q.maxAttempts,
+				RunAt:        schedule.NextRunAt,
+				ScheduleName: schedule.Name,
+			}
+			if err := q.repo.Enqueue(ctx, job); err != nil {
+				return err
+			}
+			enqueued++
+
+			if err := q.repo.AdvanceSchedule(ctx, schedule.Name, q.nextRun(schedule, now)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if enqueued > 0 {
+		logger.Log.Info(op, "Scheduled jobs enqueued", enqueued)
+	}
+	return enqueued, nil
+}
+
+// nextRun считает следующий запуск. Расписание, которого нет в конфиге этой
+// реплики (его сохранила реплика с другим конфигом), разбирается заново.
+func (q *JobQueue) nextRun(schedule models.JobSchedule, now time.Time) time.Time {
+	const op = "service.JobQueue.nextRun"
+
+	parsed, ok := q.parsed[schedule.Schedule]
+	if !ok {
+		var err error
+		parsed, err = cron.Parse(schedule.Schedule)
+		if err != nil {
+			logger.Log.Error(op, "Invalid job schedule", err, "name", schedule.Name)
+			return now.Add(24 * time.Hour)
+		}
+	}
+
+	next := parsed.Next(now)
+	if next.IsZero() {
+		return now.Add(24 * time.Hour)
+	}
+	return next
+}
+
+type jobsCleanupPayload struct {
+	OlderThan string `json:"older_than"`
+}
+
+func (q *JobQueue) cleanup(ctx context.Context, job *models.Job) error {
+	const op = "service.JobQueue.cleanup"
+
+	var payload jobsCleanupPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	olderThan := 7 * 24 * time.Hour
+	if payload.OlderThan != "" {
+		var err error
+		if olderThan, err = time.ParseDuration(payload.OlderThan); err != nil {
+			return PermanentJobError(err)
+		}
+	}
+
+	var deleted int64
+	err := q.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		var err error
+		deleted, err = q.repo.DeleteFinishedBefore(ctx, time.Now().Add(-olderThan))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Info(op, "Finished jobs deleted", deleted, "olderThan", olderThan)
+	return nil
+}
+
+// ListJobs godoc
+// @Summary      List background jobs
+// @Description  Returns background jobs, newest first
+// @Tags         jobs
+// @Produce      json
+// @Param        status  query     string  false  "Job status" Enums(pending, running, succeeded, failed, canceled)
+// @Param        kind    query     string  false  "Job kind"
+// @Param        page    query     int     false  "Page number" default(1)
+// @Param        limit   query     int     false  "Items per page" default(20)
+// @Success      200     {object}  dto.JobListResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Router       /admin/jobs [get]
+func (q *JobQueue) ListJobs(ctx context.Context, req dto.JobListRequest) (*dto.JobListResponse, error) {
+	jobs, total, err := q.repo.List(ctx, models.JobStatus(req.Status), req.Kind, (req.Page-1)*req.Limit, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.JobListResponse{
+		Items:      make([]dto.JobResponse, len(jobs)),
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	for i, job := range jobs {
+		resp.Items[i] = jobToResponse(job)
+	}
+	return resp, nil
+}
+
+// GetJob godoc
+// @Summary      Get a background job
+// @Description  Returns a background job with its last error
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  dto.JobResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/jobs/{id} [get]
+func (q *JobQueue) GetJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := q.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := jobToResponse(job)
+	return &resp, nil
+}
+
+// RetryJob godoc
+// @Summary      Retry a background job
+// @Description  Puts a failed or canceled job back into the queue with a fresh set of attempts
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  dto.JobResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/jobs/{id}/retry [post]
+func (q *JobQueue) RetryJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error) {
+	return q.transition(ctx, req, q.repo.Retry)
+}
+
+// CancelJob godoc
+// @Summary      Cancel a background job
+// @Description  Cancels a pending or running job. A running handler is not interrupted, but its result is discarded
+// @Tags         jobs
+// @Produce      json
+// @Param        id   path      string  true  "Job ID"
+// @Success      200  {object}  dto.JobResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      409  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/jobs/{id}/cancel [post]
+func (q *JobQueue) CancelJob(ctx context.Context, req dto.JobIDRequest) (*dto.JobResponse, error) {
+	return q.transition(ctx, req, q.repo.Cancel)
+}
+
+func (q *JobQueue) transition(
+	ctx context.Context,
+	req dto.JobIDRequest,
+	change func(ctx context.Context, id int64) (*models.Job, error),
+) (*dto.JobResponse, error) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var job *models.Job
+	err = q.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		job, err = change(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := jobToResponse(job)
+	return &resp, nil
+}
+
+func jobToResponse(job *models.Job) dto.JobResponse {
+	return dto.JobResponse{
+		ID:           strconv.FormatInt(job.ID, 10),
+		Kind:         job.Kind,
+		Payload:      job.Payload,
+		Status:       string(job.Status),
+		Attempts:     job.Attempts,
+		MaxAttempts:  job.MaxAttempts,
+		RunAt:        job.RunAt,
+		LockedUntil:  job.LockedUntil,
+		LastError:    job.LastError,
+		ScheduleName: job.ScheduleName,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
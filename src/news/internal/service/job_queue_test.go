@@ -0,0 +1,223 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeJobRepo хранит очередь в памяти и повторяет правила захвата и
+// завершения задач из JobRepository.
+type fakeJobRepo struct {
+	service.JobRepository
+	jobs      []*models.Job
+	schedules map[string]models.JobSchedule
+}
+
+func (r *fakeJobRepo) Enqueue(ctx context.Context, job *models.Job) error {
+	job.ID = int64(len(r.jobs) + 1)
+	job.Status = models.JobPending
+	copied := *job
+	r.jobs = append(r.jobs, &copied)
+	return nil
+}
+
+func (r *fakeJobRepo) Claim(ctx context.Context, limit int, lease time.Duration) ([]models.Job, error) {
+	var claimed []models.Job
+	for _, job := range r.jobs {
+		if len(claimed) == limit {
+			break
+		}
+		if job.Status == models.JobPending && !job.RunAt.After(time.Now()) {
+			until := time.Now().Add(lease)
+			job.Status, job.LockedUntil = models.JobRunning, &until
+			job.Attempts++
+			claimed = append(claimed, *job)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *fakeJobRepo) Finish(ctx context.Context, job *models.Job) error {
+	stored := r.jobs[job.ID-1]
+	if stored.Status != models.JobRunning || stored.Attempts != job.Attempts {
+		return postgres.ErrNotFound
+	}
+	*stored = *job
+	return nil
+}
+
+func (r *fakeJobRepo) Cancel(ctx context.Context, id int64) (*models.Job, error) {
+	if id > int64(len(r.jobs)) {
+		return nil, postgres.ErrNotFound
+	}
+	job := r.jobs[id-1]
+	if job.Status != models.JobPending && job.Status != models.JobRunning {
+		return nil, postgres.ErrInvalidJobState
+	}
+	job.Status = models.JobCanceled
+	return job, nil
+}
+
+func (r *fakeJobRepo) ClaimDueSchedules(ctx context.Context, limit int) ([]models.JobSchedule, error) {
+	var due []models.JobSchedule
+	for _, schedule := range r.schedules {
+		if !schedule.NextRunAt.After(time.Now()) {
+			due = append(due, schedule)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeJobRepo) AdvanceSchedule(ctx context.Context, name string, next time.Time) error {
+	schedule := r.schedules[name]
+	schedule.NextRunAt = next
+	r.schedules[name] = schedule
+	return nil
+}
+
+// noopJobs принимает задачи и ничего с ними не делает.
+type noopJobs struct{}
+
+func (noopJobs) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (*models.Job, error) {
+	return &models.Job{Kind: kind}, nil
+}
+
+func TestJobQueue(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	cfg := config.JobsConfig{Lease: time.Minute, MaxAttempts: 2, RetryBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	setup := func(t *testing.T) (*service.JobQueue, *fakeJobRepo) {
+		repo := &fakeJobRepo{schedules: map[string]models.JobSchedule{}}
+		queue, err := service.NewJobQueue(repo, fakeTxManager{}, cfg)
+		require.NoError(t, err)
+		return queue, repo
+	}
+
+	t.Run("runs a job and records success", func(t *testing.T) {
+		queue, repo := setup(t)
+
+		var got string
+		queue.Register("echo", func(ctx context.Context, job *models.Job) error {
+			got = string(job.Payload)
+			return nil
+		})
+		_, err := queue.Enqueue(ctx, "echo", map[string]int{"n": 1}, time.Time{})
+		require.NoError(t, err)
+
+		ran, err := queue.RunNext(ctx)
+		require.NoError(t, err)
+		assert.True(t, ran)
+		assert.JSONEq(t, `{"n":1}`, got)
+		assert.Equal(t, models.JobSucceeded, repo.jobs[0].Status)
+		assert.NotNil(t, repo.jobs[0].FinishedAt)
+
+		ran, err = queue.RunNext(ctx)
+		require.NoError(t, err)
+		assert.False(t, ran)
+	})
+
+	t.Run("retries with backoff until attempts run out", func(t *testing.T) {
+		queue, repo := setup(t)
+
+		queue.Register("flaky", func(ctx context.Context, job *models.Job) error {
+			return errors.New("boom")
+		})
+		_, err := queue.Enqueue(ctx, "flaky", nil, time.Time{})
+		require.NoError(t, err)
+
+		_, err = queue.RunNext(ctx)
+		require.NoError(t, err)
+		job := repo.jobs[0]
+		assert.Equal(t, models.JobPending, job.Status)
+		assert.Equal(t, "boom", job.LastError)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), job.RunAt, 5*time.Second)
+
+		job.RunAt = time.Now()
+		_, err = queue.RunNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobFailed, job.Status)
+		assert.Equal(t, 2, job.Attempts)
+	})
+
+	t.Run("permanent errors and unknown kinds fail at once", func(t *testing.T) {
+		queue, repo := setup(t)
+
+		queue.Register("broken", func(ctx context.Context, job *models.Job) error {
+			return service.PermanentJobError(errors.New("bad payload"))
+		})
+		for _, kind := range []string{"broken", "missing"} {
+			_, err := queue.Enqueue(ctx, kind, nil, time.Time{})
+			require.NoError(t, err)
+			_, err = queue.RunNext(ctx)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, models.JobFailed, repo.jobs[0].Status)
+		assert.Equal(t, "bad payload", repo.jobs[0].LastError)
+		assert.Equal(t, models.JobFailed, repo.jobs[1].Status)
+		assert.Contains(t, repo.jobs[1].LastError, `unknown job kind "missing"`)
+	})
+
+	t.Run("result of a job canceled while running is discarded", func(t *testing.T) {
+		queue, repo := setup(t)
+
+		queue.Register("slow", func(ctx context.Context, job *models.Job) error {
+			_, err := queue.CancelJob(ctx, dto.JobIDRequest{ID: "1"})
+			return err
+		})
+		_, err := queue.Enqueue(ctx, "slow", nil, time.Time{})
+		require.NoError(t, err)
+
+		_, err = queue.RunNext(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.JobCanceled, repo.jobs[0].Status)
+
+		_, err = queue.CancelJob(ctx, dto.JobIDRequest{ID: "1"})
+		assert.ErrorIs(t, err, postgres.ErrInvalidJobState)
+	})
+
+	t.Run("due schedules enqueue one job and move forward", func(t *testing.T) {
+		queue, repo := setup(t)
+		repo.schedules["hourly"] = models.JobSchedule{
+			Name:      "hourly",
+			Kind:      service.JobKindJobsCleanup,
+			Schedule:  "@hourly",
+			Payload:   []byte(`{}`),
+			NextRunAt: time.Now().Add(-3 * time.Hour),
+		}
+
+		enqueued, err := queue.EnqueueDueSchedules(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, enqueued)
+		require.Len(t, repo.jobs, 1)
+		assert.Equal(t, "hourly", repo.jobs[0].ScheduleName)
+
+		next := repo.schedules["hourly"].NextRunAt
+		assert.True(t, next.After(time.Now()))
+		assert.Zero(t, next.Minute())
+
+		enqueued, err = queue.EnqueueDueSchedules(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, enqueued)
+	})
+
+	t.Run("schedule that never fires is rejected", func(t *testing.T) {
+		_, err := service.NewJobQueue(&fakeJobRepo{}, fakeTxManager{}, config.JobsConfig{
+			Cron: []config.CronJobConfig{{Name: "leap", Kind: "x", Schedule: "0 0 30 2 *"}},
+		})
+		assert.ErrorContains(t, err, `cron job "leap": schedule "0 0 30 2 *" never fires`)
+	})
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/metrics"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+const (
+	// JobKindNewsLinkCheck проверяет ссылки из блоков link видимых новостей.
+	// Битые ссылки пишутся в лог, их число — в метрику news_broken_links.
+	JobKindNewsLinkCheck = "news.link_check"
+
+	defaultLinkCheckTimeout = 10 * time.Second
+	linkCheckWorkers        = 8
+)
+
+type linkCheckPayload struct {
+	Timeout string `json:"timeout"`
+}
+
+// BrokenLink — ссылка, которая не открылась, и новости, в которых она встречается.
+type BrokenLink struct {
+	URL     string
+	NewsIDs []int64
+	Reason  string
+}
+
+// LinkChecker проверяет внешние ссылки из новостей запросом HEAD, а если сервер
+// его не поддерживает, — GET. Ссылка считается битой при ошибке соединения или
+// ответе 4xx/5xx.
+type LinkChecker struct {
+	newsRepo NewsRepository
+	client   *http.Client
+}
+
+func NewLinkChecker(newsRepo NewsRepository, client *http.Client) *LinkChecker {
+	return &LinkChecker{
+		newsRepo: newsRepo,
+		client:   client,
+	}
+}
+
+// RunLinkCheckJob обрабатывает задачу news.link_check.
+func (c *LinkChecker) RunLinkCheckJob(ctx context.Context, job *models.Job) error {
+	const op = "service.LinkChecker.RunLinkCheckJob"
+
+	var payload linkCheckPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	timeout := defaultLinkCheckTimeout
+	if payload.Timeout != "" {
+		var err error
+		if timeout, err = time.ParseDuration(payload.Timeout); err != nil {
+			return PermanentJobError(err)
+		}
+	}
+
+	broken, err := c.CheckLinks(ctx, timeout)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range broken {
+		logger.Log.Warn(op, "Broken link", link.URL, "newsIDs", link.NewsIDs, "reason", link.Reason)
+	}
+	metrics.BrokenLinks.Set(float64(len(broken)))
+	logger.Log.Info(op, "Link check finished", len(broken))
+	return nil
+}
+
+// CheckLinks проверяет все http(s)-ссылки видимых новостей, каждую не дольше
+// timeout, и возвращает битые в порядке первого появления.
+func (c *LinkChecker) CheckLinks(ctx context.Context, timeout time.Duration) ([]BrokenLink, error) {
+	var (
+		urls    []string
+		newsIDs = make(map[string][]int64)
+		afterID int64
+	)
+	for {
+		blocks, err := c.newsRepo.LinkBlocks(ctx, afterID, maintenanceBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(blocks) == 0 {
+			break
+		}
+		afterID = blocks[len(blocks)-1].ID
+
+		for _, block := range blocks {
+			if !isHTTPURL(block.Content) {
+				continue
+			}
+			if _, ok := newsIDs[block.Content]; !ok {
+				urls = append(urls, block.Content)
+			}
+			if !slices.Contains(newsIDs[block.Content], block.NewsID) {
+				newsIDs[block.Content] = append(newsIDs[block.Content], block.NewsID)
+			}
+		}
+	}
+
+	reasons := make([]string, len(urls))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(linkCheckWorkers, len(urls)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				reasons[i] = c.check(ctx, urls[i], timeout)
+			}
+		}()
+	}
+	for i := range urls {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var broken []BrokenLink
+	for i, url := range urls {
+		if reasons[i] != "" {
+			broken = append(broken, BrokenLink{URL: url, NewsIDs: newsIDs[url], Reason: reasons[i]})
+		}
+	}
+	return broken, nil
+}
+
+// check возвращает причину, по которой ссылка битая, или пустую строку.
+func (c *LinkChecker) check(ctx context.Context, url string, timeout time.Duration) string {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	status, err := c.request(ctx, http.MethodHead, url)
+	if err == nil && (status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented) {
+		status, err = c.request(ctx, http.MethodGet, url)
+	}
+	switch {
+	case err != nil:
+		return err.Error()
+	case status >= http.StatusBadRequest:
+		return fmt.Sprintf("status %d", status)
+	default:
+		return ""
+	}
+}
+
+func (c *LinkChecker) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
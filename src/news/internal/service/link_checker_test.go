@@ -0,0 +1,73 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeLinkRepo отдаёт блоки-ссылки страницами, как NewsRepository.LinkBlocks.
+type fakeLinkRepo struct {
+	service.NewsRepository
+	blocks []models.ContentBlock
+}
+
+func (r *fakeLinkRepo) LinkBlocks(ctx context.Context, afterID int64, limit int) ([]models.ContentBlock, error) {
+	var page []models.ContentBlock
+	for _, block := range r.blocks {
+		if block.ID > afterID && len(page) < limit {
+			page = append(page, block)
+		}
+	}
+	return page, nil
+}
+
+func TestLinkChecker_CheckLinks(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/gone", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/get-only", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	repo := &fakeLinkRepo{blocks: []models.ContentBlock{
+		{ID: 1, NewsID: 1, Content: server.URL + "/ok"},
+		{ID: 2, NewsID: 1, Content: server.URL + "/gone"},
+		{ID: 3, NewsID: 2, Content: server.URL + "/gone"},
+		{ID: 4, NewsID: 2, Content: server.URL + "/get-only"},
+		{ID: 5, NewsID: 3, Content: "mailto:editor@example.com"},
+		{ID: 6, NewsID: 3, Content: server.URL + "/slow"},
+	}}
+	checker := service.NewLinkChecker(repo, server.Client())
+
+	broken, err := checker.CheckLinks(ctx, 200*time.Millisecond)
+	require.NoError(t, err)
+	require.Len(t, broken, 2)
+
+	assert.Equal(t, server.URL+"/gone", broken[0].URL)
+	assert.Equal(t, []int64{1, 2}, broken[0].NewsIDs)
+	assert.Equal(t, "status 404", broken[0].Reason)
+
+	assert.Equal(t, server.URL+"/slow", broken[1].URL)
+	assert.Contains(t, broken[1].Reason, "deadline exceeded")
+}
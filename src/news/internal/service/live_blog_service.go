@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+type LiveEntryRepository interface {
+	LockNews(ctx context.Context, newsID int64) (*models.News, error)
+	Create(ctx context.Context, entry *models.LiveEntry) error
+	Update(ctx context.Context, entry *models.LiveEntry) error
+	Delete(ctx context.Context, newsID, entryID int64) error
+	GetByID(ctx context.Context, newsID, entryID int64) (*models.LiveEntry, error)
+	List(ctx context.Context, newsID int64, after int64, limit int, pinnedOnly bool) ([]*models.LiveEntry, error)
+}
+
+// LiveBlogService ведёт записи live-блога. Каждое изменение записи попадает
+// в outbox, откуда доходит до подписчиков SSE, WebSocket и вебхуков.
+type LiveBlogService struct {
+	repo      LiveEntryRepository
+	mediaRepo MediaRepository
+	outbox    OutboxWriter
+	jobs      JobEnqueuer
+	txManager storage.TxManagerInterface
+}
+
+func NewLiveBlogService(
+	repo LiveEntryRepository,
+	mediaRepo MediaRepository,
+	outbox OutboxWriter,
+	jobs JobEnqueuer,
+	txManager storage.TxManagerInterface,
+) *LiveBlogService {
+	return &LiveBlogService{
+		repo:      repo,
+		mediaRepo: mediaRepo,
+		outbox:    outbox,
+		jobs:      jobs,
+		txManager: txManager,
+	}
+}
+
+// AppendEntry godoc
+// @Summary      Append a live blog entry
+// @Description  Adds a timestamped entry to a live news item. Subscribers receive an entry_added event.
+// @Tags         live
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string                      true  "News ID"
+// @Param        entry  body      dto.CreateLiveEntryRequest  true  "Entry"
+// @Success      201    {object}  dto.LiveEntryResponse
+// @Failure      400    {object}  dto.ErrorResponse
+// @Failure      404    {object}  dto.ErrorResponse
+// @Failure      409    {object}  dto.ErrorResponse
+// @Failure      500    {object}  dto.ErrorResponse
+// @Router       /news/{id}/entries [post]
+func (s *LiveBlogService) AppendEntry(
+	ctx context.Context,
+	req dto.CreateLiveEntryRequest,
+) (*dto.LiveEntryResponse, error) {
+	const op = "service.LiveBlogService.AppendEntry"
+
+	newsID, err := strconv.ParseInt(req.NewsID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks, err := buildContentBlocks(ctx, s.mediaRepo, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &models.LiveEntry{
+		NewsID:  newsID,
+		Pinned:  req.Pinned,
+		Content: blocks,
+	}
+
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		news, err := s.lockLiveNews(ctx, newsID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Create(ctx, entry); err != nil {
+			return err
+		}
+
+		event := models.NewLiveEntryEvent(models.LiveEntryAdded, news, entry.ID)
+		if err := s.outbox.Add(ctx, &event); err != nil {
+			return err
+		}
+		return enqueueVariants(ctx, s.jobs, blocks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Live entry appended", entry.ID, "newsID", newsID)
+
+	return s.entryResponse(ctx, entry)
+}
+
+// ListEntries godoc
+// @Summary      List live blog entries
+// @Description  Returns entries added after the `after` cursor in the order they were appended.
+// @Description  Pass the returned cursor as `after` to poll for newer entries.
+// @Tags         live
+// @Produce      json
+// @Param        id      path      string  true   "News ID"
+// @Param        after   query     int     false  "Return entries after this cursor"
+// @Param        limit   query     int     false  "Page size" default(50)
+// @Param        pinned  query     bool    false  "Only pinned entries"
+// @Success      200     {object}  dto.LiveEntryListResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Router       /news/{id}/entries [get]
+func (s *LiveBlogService) ListEntries(
+	ctx context.Context,
+	req dto.LiveEntryListRequest,
+) (*dto.LiveEntryListResponse, error) {
+	newsID, err := strconv.ParseInt(req.NewsID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// Одна лишняя запись показывает, есть ли что-то за пределами страницы.
+	entries, err := s.repo.List(ctx, newsID, req.After, req.Limit+1, req.Pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(entries) > req.Limit
+	if hasMore {
+		entries = entries[:req.Limit]
+	}
+
+	blocks := make([]models.ContentBlock, 0)
+	for _, entry := range entries {
+		blocks = append(blocks, entry.Content...)
+	}
+	variants, err := loadBlockVariants(ctx, s.mediaRepo, blocks)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.LiveEntryListResponse{
+		Entries: make([]dto.LiveEntryResponse, len(entries)),
+		Cursor:  strconv.FormatInt(req.After, 10),
+		HasMore: hasMore,
+	}
+	for i, entry := range entries {
+		resp.Entries[i] = liveEntryToResponse(entry, variants)
+	}
+	if len(entries) > 0 {
+		resp.Cursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+
+	return resp, nil
+}
+
+// UpdateEntry godoc
+// @Summary      Edit a live blog entry
+// @Description  Changes the pinned flag and/or replaces the content blocks of an entry.
+// @Tags         live
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                      true  "News ID"
+// @Param        entryId  path      string                      true  "Entry ID"
+// @Param        entry    body      dto.UpdateLiveEntryRequest  true  "Changes"
+// @Success      200      {object}  dto.LiveEntryResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      404      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /news/{id}/entries/{entryId} [put]
+func (s *LiveBlogService) UpdateEntry(
+	ctx context.Context,
+	req dto.UpdateLiveEntryRequest,
+) (*dto.LiveEntryResponse, error) {
+	const op = "service.LiveBlogService.UpdateEntry"
+
+	newsID, entryID, err := parseEntryIDs(req.NewsID, req.EntryID)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []models.ContentBlock
+	if req.Content != nil {
+		blocks, err = buildContentBlocks(ctx, s.mediaRepo, req.Content)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var entry *models.LiveEntry
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		news, err := s.repo.LockNews(ctx, newsID)
+		if err != nil {
+			return err
+		}
+
+		entry, err = s.repo.GetByID(ctx, newsID, entryID)
+		if err != nil {
+			return err
+		}
+		if req.Pinned != nil {
+			entry.Pinned = *req.Pinned
+		}
+		// nil Content оставляет блоки записи без изменений.
+		entry.Content = blocks
+
+		if err := s.repo.Update(ctx, entry); err != nil {
+			return err
+		}
+
+		event := models.NewLiveEntryEvent(models.LiveEntryUpdated, news, entry.ID)
+		if err := s.outbox.Add(ctx, &event); err != nil {
+			return err
+		}
+		return enqueueVariants(ctx, s.jobs, blocks)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Live entry updated", entryID, "newsID", newsID)
+
+	entry, err = s.repo.GetByID(ctx, newsID, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return s.entryResponse(ctx, entry)
+}
+
+// DeleteEntry godoc
+// @Summary      Delete a live blog entry
+// @Tags         live
+// @Param        id       path  string  true  "News ID"
+// @Param        entryId  path  string  true  "Entry ID"
+// @Success      204
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /news/{id}/entries/{entryId} [delete]
+func (s *LiveBlogService) DeleteEntry(ctx context.Context, req dto.LiveEntryIDRequest) error {
+	const op = "service.LiveBlogService.DeleteEntry"
+
+	newsID, entryID, err := parseEntryIDs(req.NewsID, req.EntryID)
+	if err != nil {
+		return err
+	}
+
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		news, err := s.repo.LockNews(ctx, newsID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.Delete(ctx, newsID, entryID); err != nil {
+			return err
+		}
+
+		event := models.NewLiveEntryEvent(models.LiveEntryDeleted, news, entryID)
+		return s.outbox.Add(ctx, &event)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Log.Info(op, "Live entry deleted", entryID, "newsID", newsID)
+	return nil
+}
+
+func (s *LiveBlogService) lockLiveNews(ctx context.Context, newsID int64) (*models.News, error) {
+	news, err := s.repo.LockNews(ctx, newsID)
+	if err != nil {
+		return nil, err
+	}
+	if !news.Live {
+		return nil, ErrNewsNotLive
+	}
+	return news, nil
+}
+
+func (s *LiveBlogService) entryResponse(ctx context.Context, entry *models.LiveEntry) (*dto.LiveEntryResponse, error) {
+	variants, err := loadBlockVariants(ctx, s.mediaRepo, entry.Content)
+	if err != nil {
+		return nil, err
+	}
+	resp := liveEntryToResponse(entry, variants)
+	return &resp, nil
+}
+
+func parseEntryIDs(rawNewsID, rawEntryID string) (int64, int64, error) {
+	newsID, err := strconv.ParseInt(rawNewsID, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	entryID, err := strconv.ParseInt(rawEntryID, 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return newsID, entryID, nil
+}
+
+func liveEntryToResponse(entry *models.LiveEntry, variants map[int64][]models.MediaVariant) dto.LiveEntryResponse {
+	return dto.LiveEntryResponse{
+		ID:        strconv.FormatInt(entry.ID, 10),
+		NewsID:    strconv.FormatInt(entry.NewsID, 10),
+		Pinned:    entry.Pinned,
+		Content:   blocksToResponse(entry.Content, variants),
+		CreatedAt: entry.CreatedAt,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
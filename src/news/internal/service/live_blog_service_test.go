@@ -0,0 +1,150 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type fakeLiveRepo struct {
+	news    map[int64]*models.News
+	entries []*models.LiveEntry
+}
+
+func (r *fakeLiveRepo) LockNews(ctx context.Context, newsID int64) (*models.News, error) {
+	news, ok := r.news[newsID]
+	if !ok {
+		return nil, postgres.ErrNotFound
+	}
+	return news, nil
+}
+
+func (r *fakeLiveRepo) Create(ctx context.Context, entry *models.LiveEntry) error {
+	entry.ID = int64(len(r.entries) + 1)
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeLiveRepo) Update(ctx context.Context, entry *models.LiveEntry) error {
+	for _, stored := range r.entries {
+		if stored.ID != entry.ID || stored.NewsID != entry.NewsID {
+			continue
+		}
+		stored.Pinned = entry.Pinned
+		if entry.Content != nil {
+			stored.Content = entry.Content
+		}
+		return nil
+	}
+	return postgres.ErrNotFound
+}
+
+func (r *fakeLiveRepo) Delete(ctx context.Context, newsID, entryID int64) error {
+	for i, entry := range r.entries {
+		if entry.ID == entryID && entry.NewsID == newsID {
+			r.entries = append(r.entries[:i], r.entries[i+1:]...)
+			return nil
+		}
+	}
+	return postgres.ErrNotFound
+}
+
+func (r *fakeLiveRepo) GetByID(ctx context.Context, newsID, entryID int64) (*models.LiveEntry, error) {
+	for _, entry := range r.entries {
+		if entry.ID == entryID && entry.NewsID == newsID {
+			copied := *entry
+			return &copied, nil
+		}
+	}
+	return nil, postgres.ErrNotFound
+}
+
+func (r *fakeLiveRepo) List(ctx context.Context, newsID int64, after int64, limit int, pinnedOnly bool) ([]*models.LiveEntry, error) {
+	result := make([]*models.LiveEntry, 0)
+	for _, entry := range r.entries {
+		if entry.NewsID != newsID || entry.ID <= after || (pinnedOnly && !entry.Pinned) || len(result) == limit {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+func textEntry(newsID string, text string) dto.CreateLiveEntryRequest {
+	return dto.CreateLiveEntryRequest{
+		NewsID:  newsID,
+		Content: []dto.CreateContentBlock{{Type: "text", Content: text, Position: 1}},
+	}
+}
+
+func TestLiveBlogService(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	repo := &fakeLiveRepo{news: map[int64]*models.News{
+		1: {ID: 1, Category: "politics", Live: true},
+		2: {ID: 2, Category: "sport"},
+	}}
+	outbox := &fakeOutbox{}
+	live := service.NewLiveBlogService(repo, &fakeMediaRepo{}, outbox, noopJobs{}, fakeTxManager{})
+
+	_, err := live.AppendEntry(ctx, textEntry("2", "not live"))
+	assert.ErrorIs(t, err, service.ErrNewsNotLive)
+	_, err = live.AppendEntry(ctx, textEntry("3", "missing"))
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+
+	for _, text := range []string{"first", "second", "third"} {
+		entry, err := live.AppendEntry(ctx, textEntry("1", text))
+		require.NoError(t, err)
+		assert.Equal(t, text, entry.Content[0].Content)
+	}
+
+	require.Len(t, outbox.events, 3)
+	assert.Equal(t, models.LiveEntryAdded, outbox.events[0].Type)
+	assert.Equal(t, int64(1), outbox.events[0].EntryID)
+	assert.Equal(t, "politics", outbox.events[0].Category)
+
+	page, err := live.ListEntries(ctx, dto.LiveEntryListRequest{NewsID: "1", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 2)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, "2", page.Cursor)
+
+	page, err = live.ListEntries(ctx, dto.LiveEntryListRequest{NewsID: "1", After: 2, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "third", page.Entries[0].Content[0].Content)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, "3", page.Cursor)
+
+	// Пустая страница оставляет курсор на месте.
+	page, err = live.ListEntries(ctx, dto.LiveEntryListRequest{NewsID: "1", After: 3, Limit: 2})
+	require.NoError(t, err)
+	assert.Empty(t, page.Entries)
+	assert.Equal(t, "3", page.Cursor)
+
+	pinned := true
+	updated, err := live.UpdateEntry(ctx, dto.UpdateLiveEntryRequest{NewsID: "1", EntryID: "2", Pinned: &pinned})
+	require.NoError(t, err)
+	assert.True(t, updated.Pinned)
+	assert.Equal(t, "second", updated.Content[0].Content, "content is kept when not passed")
+
+	page, err = live.ListEntries(ctx, dto.LiveEntryListRequest{NewsID: "1", Limit: 10, Pinned: true})
+	require.NoError(t, err)
+	require.Len(t, page.Entries, 1)
+	assert.Equal(t, "2", page.Entries[0].ID)
+
+	require.NoError(t, live.DeleteEntry(ctx, dto.LiveEntryIDRequest{NewsID: "1", EntryID: "1"}))
+	assert.ErrorIs(t, live.DeleteEntry(ctx, dto.LiveEntryIDRequest{NewsID: "1", EntryID: "1"}), postgres.ErrNotFound)
+
+	last := outbox.events[len(outbox.events)-1]
+	assert.Equal(t, models.LiveEntryDeleted, last.Type)
+	assert.Equal(t, int64(1), last.EntryID)
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+	_ "golang.org/x/image/webp"
+)
+
+type MediaRepository interface {
+	Create(ctx context.Context, media *models.Media) error
+	GetByID(ctx context.Context, id int64) (*models.Media, error)
+	GetByChecksum(ctx context.Context, checksum string) (*models.Media, error)
+	ExistingIDs(ctx context.Context, ids []int64) (map[int64]struct{}, error)
+	CreateVariant(ctx context.Context, variant *models.MediaVariant) error
+	GetVariant(ctx context.Context, mediaID int64, width int, format string) (*models.MediaVariant, error)
+	ListVariantsByChecksum(ctx context.Context, checksum string) ([]models.MediaVariant, error)
+	ListVariants(ctx context.Context, mediaIDs []int64) (map[int64][]models.MediaVariant, error)
+	ReferencingNewsIDs(ctx context.Context, mediaID int64) ([]int64, error)
+}
+
+var allowedMediaTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+type MediaService struct {
+	mediaRepo     MediaRepository
+	txManager     storage.TxManagerInterface
+	files         storage.MediaStorage
+	jobs          JobEnqueuer
+	maxUploadSize int64
+	maxPixels     int64
+}
+
+func NewMediaService(
+	mediaRepo MediaRepository,
+	txManager storage.TxManagerInterface,
+	files storage.MediaStorage,
+	jobs JobEnqueuer,
+	cfg *config.MediaConfig,
+) *MediaService {
+	return &MediaService{
+		mediaRepo:     mediaRepo,
+		txManager:     txManager,
+		files:         files,
+		jobs:          jobs,
+		maxUploadSize: cfg.MaxUploadSize,
+		maxPixels:     cfg.MaxPixels,
+	}
+}
+
+// UploadMedia godoc
+// @Summary      Upload an image
+// @Description  Stores an image file and records its metadata. Uploading the same file twice returns the existing record.
+// @Tags         media
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file  formData  file  true  "Image file (jpeg, png, gif, webp)"
+// @Success      201   {object}  dto.MediaResponse
+// @Failure      400   {object}  dto.ErrorResponse
+// @Failure      413   {object}  dto.ErrorResponse
+// @Failure      415   {object}  dto.ErrorResponse
+// @Failure      500   {object}  dto.ErrorResponse
+// @Router       /media [post]
+func (s *MediaService) UploadMedia(
+	ctx context.Context,
+	req dto.UploadMediaRequest,
+) (*dto.MediaResponse, error) {
+	const op = "service.MediaService.UploadMedia"
+
+	if req.Size > s.maxUploadSize {
+		return nil, ErrMediaTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(req.File, s.maxUploadSize+1))
+	if err != nil {
+		logger.Log.Error(op, "Failed to read uploaded file", err)
+		return nil, err
+	}
+	if int64(len(data)) > s.maxUploadSize {
+		return nil, ErrMediaTooLarge
+	}
+
+	mimeType := http.DetectContentType(data)
+	ext, ok := allowedMediaTypes[mimeType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedMediaType, mimeType)
+	}
+
+	imgCfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedMediaType, err)
+	}
+	// Сильно сжатый файл небольшого размера может раскрыться в гигабайты при
+	// декодировании для вариантов, поэтому ограничено и число пикселей.
+	if pixels := int64(imgCfg.Width) * int64(imgCfg.Height); pixels > s.maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d exceeds %d pixels", ErrMediaTooLarge, imgCfg.Width, imgCfg.Height, s.maxPixels)
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+
+	existing, err := s.mediaRepo.GetByChecksum(ctx, checksum)
+	if err == nil {
+		logger.Log.Info(op, "Media already exists", existing.ID)
+		return s.withVariants(ctx, existing)
+	}
+	if !errors.Is(err, postgres.ErrNotFound) {
+		return nil, err
+	}
+
+	media := &models.Media{
+		StorageKey: fmt.Sprintf("originals/%s/%s%s", checksum[:2], checksum, ext),
+		FileName:   req.FileName,
+		MimeType:   mimeType,
+		Size:       int64(len(data)),
+		Width:      imgCfg.Width,
+		Height:     imgCfg.Height,
+		Checksum:   checksum,
+	}
+
+	// Ключ объекта определяется содержимым, поэтому повторная запись
+	// при гонке двух одинаковых загрузок безопасна.
+	if err := s.files.Put(ctx, media.StorageKey, bytes.NewReader(data), media.Size, mimeType); err != nil {
+		logger.Log.Error(op, "Failed to store media file", err)
+		return nil, err
+	}
+
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := s.mediaRepo.Create(ctx, media); err != nil {
+			return err
+		}
+		return enqueueMediaVariants(ctx, s.jobs, media.ID)
+	})
+	if err != nil {
+		if concurrent, getErr := s.mediaRepo.GetByChecksum(ctx, checksum); getErr == nil {
+			media = concurrent
+		} else {
+			return nil, err
+		}
+	}
+
+	logger.Log.Info(op, "Media uploaded successfully", media.ID)
+
+	resp := mediaToResponse(media, nil)
+	return &resp, nil
+}
+
+// GetMedia godoc
+// @Summary      Get image metadata
+// @Description  Retrieves metadata of an uploaded image
+// @Tags         media
+// @Produce      json
+// @Param        id   path      string  true  "Media ID"
+// @Success      200  {object}  dto.MediaResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /media/{id} [get]
+func (s *MediaService) GetMedia(
+	ctx context.Context,
+	req dto.GetMediaRequest,
+) (*dto.MediaResponse, error) {
+	media, err := s.getMedia(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withVariants(ctx, media)
+}
+
+// OpenMedia возвращает метаданные и поток с содержимым файла.
+// Закрыть поток должен вызывающий.
+func (s *MediaService) OpenMedia(
+	ctx context.Context,
+	req dto.GetMediaRequest,
+) (*dto.MediaResponse, io.ReadCloser, error) {
+	const op = "service.MediaService.OpenMedia"
+
+	media, err := s.getMedia(ctx, req.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.files.Get(ctx, media.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			logger.Log.Error(op, "Media file is missing in storage", media.StorageKey)
+			return nil, nil, postgres.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	resp := mediaToResponse(media, nil)
+	return &resp, file, nil
+}
+
+// OpenMediaVariant возвращает поток с уменьшенной копией изображения.
+// Закрыть поток должен вызывающий.
+func (s *MediaService) OpenMediaVariant(
+	ctx context.Context,
+	req dto.GetMediaVariantRequest,
+) (*dto.MediaVariantResponse, io.ReadCloser, error) {
+	const op = "service.MediaService.OpenMediaVariant"
+
+	mediaID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		logger.Log.Error(op, "Failed to parse media ID", err)
+		return nil, nil, err
+	}
+	width, err := strconv.Atoi(req.Width)
+	if err != nil {
+		logger.Log.Error(op, "Failed to parse variant width", err)
+		return nil, nil, err
+	}
+
+	variant, err := s.mediaRepo.GetVariant(ctx, mediaID, width, req.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := s.files.Get(ctx, variant.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			logger.Log.Error(op, "Variant file is missing in storage", variant.StorageKey)
+			return nil, nil, postgres.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	resp := variantToResponse(*variant)
+	return &resp, file, nil
+}
+
+func (s *MediaService) withVariants(ctx context.Context, media *models.Media) (*dto.MediaResponse, error) {
+	variants, err := s.mediaRepo.ListVariants(ctx, []int64{media.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := mediaToResponse(media, variants[media.ID])
+	return &resp, nil
+}
+
+func (s *MediaService) getMedia(ctx context.Context, rawID string) (*models.Media, error) {
+	const op = "service.MediaService.getMedia"
+
+	mediaID, err := strconv.ParseInt(rawID, 10, 64)
+	if err != nil {
+		logger.Log.Error(op, "Failed to parse media ID", err)
+		return nil, err
+	}
+
+	return s.mediaRepo.GetByID(ctx, mediaID)
+}
+
+func mediaURL(id int64) string {
+	return "/media/" + strconv.FormatInt(id, 10)
+}
+
+func variantURL(v models.MediaVariant) string {
+	return fmt.Sprintf("%s/variants/%d/%s", mediaURL(v.MediaID), v.Width, v.Format)
+}
+
+func variantToResponse(v models.MediaVariant) dto.MediaVariantResponse {
+	return dto.MediaVariantResponse{
+		URL:      variantURL(v),
+		Width:    v.Width,
+		Height:   v.Height,
+		Format:   v.Format,
+		MimeType: v.MimeType,
+		Size:     v.Size,
+	}
+}
+
+func variantsToResponse(variants []models.MediaVariant) []dto.MediaVariantResponse {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	resp := make([]dto.MediaVariantResponse, len(variants))
+	for i, v := range variants {
+		resp[i] = variantToResponse(v)
+	}
+	return resp
+}
+
+// srcset строит значение атрибута srcset из вариантов одного формата.
+func srcset(variants []models.MediaVariant, format string) string {
+	parts := make([]string, 0, len(variants))
+	for _, v := range variants {
+		if v.Format == format {
+			parts = append(parts, fmt.Sprintf("%s %dw", variantURL(v), v.Width))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func mediaToResponse(media *models.Media, variants []models.MediaVariant) dto.MediaResponse {
+	return dto.MediaResponse{
+		ID:        strconv.FormatInt(media.ID, 10),
+		URL:       mediaURL(media.ID),
+		FileName:  media.FileName,
+		MimeType:  media.MimeType,
+		Size:      media.Size,
+		Width:     media.Width,
+		Height:    media.Height,
+		Checksum:  media.Checksum,
+		Variants:  variantsToResponse(variants),
+		CreatedAt: media.CreatedAt,
+	}
+}
@@ -0,0 +1,56 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func TestMediaService_UploadMediaLimitsPixels(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	files, err := storage.NewLocalMediaStorage(t.TempDir())
+	require.NoError(t, err)
+
+	repo := &fakeMediaRepo{media: map[int64]*models.Media{}}
+	jobs := &fakeEnqueuer{}
+	mediaService := service.NewMediaService(repo, fakeTxManager{}, files, jobs, &config.MediaConfig{
+		MaxUploadSize: 1 << 20,
+		MaxPixels:     100 * 100,
+	})
+
+	upload := func(width, height int) (*dto.MediaResponse, error) {
+		var buf bytes.Buffer
+		require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height))))
+		return mediaService.UploadMedia(ctx, dto.UploadMediaRequest{
+			FileName: "image.png",
+			Size:     int64(buf.Len()),
+			File:     &buf,
+		})
+	}
+
+	// Однотонная картинка сжимается в сотни байт, но не проходит по числу пикселей.
+	_, err = upload(1000, 1000)
+	assert.ErrorIs(t, err, service.ErrMediaTooLarge)
+	assert.Empty(t, repo.media)
+	assert.Empty(t, jobs.jobs)
+
+	resp, err := upload(100, 100)
+	require.NoError(t, err)
+	assert.Equal(t, 100, resp.Width)
+	require.Len(t, jobs.jobs, 1)
+	assert.Equal(t, service.JobKindMediaVariants, jobs.jobs[0].Kind)
+	assert.JSONEq(t, `{"media_ids":[1]}`, string(jobs.jobs[0].Payload))
+}
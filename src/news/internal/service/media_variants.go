@@ -0,0 +1,278 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+	"golang.org/x/image/draw"
+)
+
+type variantEncoder struct {
+	mimeType string
+	ext      string
+	encode   func(w io.Writer, img image.Image, quality int) error
+}
+
+// variantEncoders содержит форматы, в которые мы умеем кодировать варианты.
+// Форматы из конфига, для которых нет энкодера (например, webp без cgo),
+// пропускаются.
+var variantEncoders = map[string]variantEncoder{
+	"jpeg": {
+		mimeType: "image/jpeg",
+		ext:      ".jpg",
+		encode: func(w io.Writer, img image.Image, quality int) error {
+			return jpeg.Encode(w, img, &jpeg.Options{Quality: quality})
+		},
+	},
+}
+
+// JobKindMediaVariants генерирует уменьшенные копии изображений из payload.media_ids.
+const JobKindMediaVariants = "media.variants"
+
+type mediaVariantsPayload struct {
+	MediaIDs []int64 `json:"media_ids"`
+}
+
+// VariantGenerator генерирует уменьшенные копии изображений в задачах media.variants.
+// Генерация идемпотентна: уже существующие варианты для той же контрольной суммы
+// исходника повторно не создаются.
+type VariantGenerator struct {
+	mediaRepo MediaRepository
+	txManager storage.TxManagerInterface
+	files     storage.MediaStorage
+	redis     RedisClient
+
+	widths  []int
+	formats []string
+	quality int
+}
+
+func NewVariantGenerator(
+	mediaRepo MediaRepository,
+	txManager storage.TxManagerInterface,
+	files storage.MediaStorage,
+	redis RedisClient,
+	cfg *config.VariantConfig,
+) *VariantGenerator {
+	const op = "service.NewVariantGenerator"
+
+	formats := make([]string, 0, len(cfg.Formats))
+	for _, format := range cfg.Formats {
+		if _, ok := variantEncoders[format]; !ok {
+			logger.Log.Warn(op, "Variant format is not supported, skipping", format)
+			continue
+		}
+		formats = append(formats, format)
+	}
+
+	widths := slices.Clone(cfg.Widths)
+	slices.Sort(widths)
+
+	return &VariantGenerator{
+		mediaRepo: mediaRepo,
+		txManager: txManager,
+		files:     files,
+		redis:     redis,
+		widths:    widths,
+		formats:   formats,
+		quality:   cfg.JPEGQuality,
+	}
+}
+
+// RunVariantsJob обрабатывает задачу media.variants. Файл, который не удаётся
+// декодировать, повторять бессмысленно, поэтому задача сразу завершается ошибкой.
+func (g *VariantGenerator) RunVariantsJob(ctx context.Context, job *models.Job) error {
+	var payload mediaVariantsPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	for _, mediaID := range payload.MediaIDs {
+		if err := g.Generate(ctx, mediaID); err != nil {
+			if errors.Is(err, ErrUnsupportedMediaType) || errors.Is(err, postgres.ErrNotFound) {
+				return PermanentJobError(fmt.Errorf("media %d: %w", mediaID, err))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueVariants ставит генерацию вариантов для изображений из блоков в очередь
+// задач. Вызывается внутри транзакции, которая сохраняет блоки, так что задача
+// появится только вместе с ними. Для уже обработанных файлов задача ничего не
+// стоит: генерация идемпотентна.
+func enqueueVariants(ctx context.Context, jobs JobEnqueuer, blocks []models.ContentBlock) error {
+	mediaIDs := make([]int64, 0)
+	for _, block := range blocks {
+		if block.MediaID != nil && !slices.Contains(mediaIDs, *block.MediaID) {
+			mediaIDs = append(mediaIDs, *block.MediaID)
+		}
+	}
+	return enqueueMediaVariants(ctx, jobs, mediaIDs...)
+}
+
+func enqueueMediaVariants(ctx context.Context, jobs JobEnqueuer, mediaIDs ...int64) error {
+	if len(mediaIDs) == 0 {
+		return nil
+	}
+	_, err := jobs.Enqueue(ctx, JobKindMediaVariants, mediaVariantsPayload{MediaIDs: mediaIDs}, time.Time{})
+	return err
+}
+
+// Generate синхронно создаёт недостающие варианты для медиафайла.
+func (g *VariantGenerator) Generate(ctx context.Context, mediaID int64) error {
+	const op = "service.VariantGenerator.Generate"
+
+	media, err := g.mediaRepo.GetByID(ctx, mediaID)
+	if err != nil {
+		return err
+	}
+
+	existing, err := g.mediaRepo.ListVariantsByChecksum(ctx, media.Checksum)
+	if err != nil {
+		return err
+	}
+
+	type variantKey struct {
+		width  int
+		format string
+	}
+	done := make(map[variantKey]struct{}, len(existing))
+	for _, v := range existing {
+		done[variantKey{v.Width, v.Format}] = struct{}{}
+	}
+
+	missing := make([]variantKey, 0)
+	for _, width := range g.widths {
+		// Не увеличиваем изображения, меньшие целевой ширины.
+		if width >= media.Width {
+			continue
+		}
+		for _, format := range g.formats {
+			if _, ok := done[variantKey{width, format}]; !ok {
+				missing = append(missing, variantKey{width, format})
+			}
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	src, err := g.decodeOriginal(ctx, media)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range missing {
+		variant, err := g.render(ctx, media, src, key.width, key.format)
+		if err != nil {
+			return err
+		}
+
+		err = g.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+			return g.mediaRepo.CreateVariant(ctx, variant)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	logger.Log.Info(op, "Variants generated", mediaID, "count", len(missing))
+	g.invalidateNews(ctx, mediaID)
+
+	return nil
+}
+
+func (g *VariantGenerator) decodeOriginal(ctx context.Context, media *models.Media) (image.Image, error) {
+	file, err := g.files.Get(ctx, media.StorageKey)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedMediaType, err)
+	}
+	return img, nil
+}
+
+func (g *VariantGenerator) render(
+	ctx context.Context,
+	media *models.Media,
+	src image.Image,
+	width int,
+	format string,
+) (*models.MediaVariant, error) {
+	encoder := variantEncoders[format]
+
+	bounds := src.Bounds()
+	height := max(bounds.Dy()*width/bounds.Dx(), 1)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Src, nil)
+
+	var buf bytes.Buffer
+	if err := encoder.encode(&buf, dst, g.quality); err != nil {
+		return nil, err
+	}
+
+	variant := &models.MediaVariant{
+		MediaID:        media.ID,
+		SourceChecksum: media.Checksum,
+		Width:          width,
+		Height:         height,
+		Format:         format,
+		MimeType:       encoder.mimeType,
+		StorageKey:     variantStorageKey(media.Checksum, width, encoder.ext),
+		Size:           int64(buf.Len()),
+	}
+
+	if err := g.files.Put(ctx, variant.StorageKey, &buf, variant.Size, variant.MimeType); err != nil {
+		return nil, err
+	}
+	return variant, nil
+}
+
+// invalidateNews сбрасывает кеш новостей, использующих медиафайл,
+// чтобы в ответах появились новые варианты.
+func (g *VariantGenerator) invalidateNews(ctx context.Context, mediaID int64) {
+	const op = "service.VariantGenerator.invalidateNews"
+
+	newsIDs, err := g.mediaRepo.ReferencingNewsIDs(ctx, mediaID)
+	if err != nil {
+		logger.Log.Error(op, "Failed to get referencing news", err)
+		return
+	}
+	if len(newsIDs) == 0 {
+		return
+	}
+
+	keys := make([]string, len(newsIDs))
+	for i, id := range newsIDs {
+		keys[i] = fmt.Sprintf("news:%d", id)
+	}
+	if err := g.redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+		logger.Log.Error(op, "Failed to invalidate cache", keys, "error", err)
+	}
+	invalidateNewsListCache(ctx, g.redis)
+}
+
+// variantStorageKey кладёт варианты рядом с оригиналом: originals/ab/<sha>_<w>w.<ext>.
+func variantStorageKey(checksum string, width int, ext string) string {
+	return fmt.Sprintf("originals/%s/%s_%dw%s", checksum[:2], checksum, width, ext)
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+type fakeTxManager struct{}
+
+func (fakeTxManager) RunSerializable(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func (fakeTxManager) RunReadUncommited(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func (fakeTxManager) RunReadCommited(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func (fakeTxManager) RunRepeatableRead(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+func (fakeTxManager) RunReadOnly(ctx context.Context, f func(context.Context) error) error {
+	return f(ctx)
+}
+
+type fakeMediaRepo struct {
+	media    map[int64]*models.Media
+	variants []models.MediaVariant
+}
+
+func (r *fakeMediaRepo) Create(ctx context.Context, media *models.Media) error {
+	media.ID = int64(len(r.media) + 1)
+	r.media[media.ID] = media
+	return nil
+}
+
+func (r *fakeMediaRepo) GetByID(ctx context.Context, id int64) (*models.Media, error) {
+	media, ok := r.media[id]
+	if !ok {
+		return nil, postgres.ErrNotFound
+	}
+	return media, nil
+}
+
+func (r *fakeMediaRepo) GetByChecksum(ctx context.Context, checksum string) (*models.Media, error) {
+	for _, media := range r.media {
+		if media.Checksum == checksum {
+			return media, nil
+		}
+	}
+	return nil, postgres.ErrNotFound
+}
+
+func (r *fakeMediaRepo) ExistingIDs(ctx context.Context, ids []int64) (map[int64]struct{}, error) {
+	existing := make(map[int64]struct{})
+	for _, id := range ids {
+		if _, ok := r.media[id]; ok {
+			existing[id] = struct{}{}
+		}
+	}
+	return existing, nil
+}
+
+func (r *fakeMediaRepo) CreateVariant(ctx context.Context, variant *models.MediaVariant) error {
+	r.variants = append(r.variants, *variant)
+	return nil
+}
+
+func (r *fakeMediaRepo) GetVariant(ctx context.Context, mediaID int64, width int, format string) (*models.MediaVariant, error) {
+	for _, v := range r.variants {
+		if v.MediaID == mediaID && v.Width == width && v.Format == format {
+			return &v, nil
+		}
+	}
+	return nil, postgres.ErrNotFound
+}
+
+func (r *fakeMediaRepo) ListVariantsByChecksum(ctx context.Context, checksum string) ([]models.MediaVariant, error) {
+	result := make([]models.MediaVariant, 0)
+	for _, v := range r.variants {
+		if v.SourceChecksum == checksum {
+			result = append(result, v)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeMediaRepo) ListVariants(ctx context.Context, mediaIDs []int64) (map[int64][]models.MediaVariant, error) {
+	result := make(map[int64][]models.MediaVariant)
+	for _, v := range r.variants {
+		result[v.MediaID] = append(result[v.MediaID], v)
+	}
+	return result, nil
+}
+
+func (r *fakeMediaRepo) ReferencingNewsIDs(ctx context.Context, mediaID int64) ([]int64, error) {
+	return nil, nil
+}
+
+func TestVariantGenerator_GenerateIsIdempotent(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	files, err := storage.NewLocalMediaStorage(t.TempDir())
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 1000, 500))))
+
+	media := &models.Media{
+		ID:         1,
+		StorageKey: "originals/ab/abcdef.png",
+		MimeType:   "image/png",
+		Width:      1000,
+		Height:     500,
+		Checksum:   "abcdef",
+	}
+	require.NoError(t, files.Put(ctx, media.StorageKey, &buf, int64(buf.Len()), media.MimeType))
+
+	repo := &fakeMediaRepo{media: map[int64]*models.Media{1: media}}
+	generator := service.NewVariantGenerator(repo, fakeTxManager{}, files, nil, &config.VariantConfig{
+		Widths:      []int{1280, 320, 640},
+		Formats:     []string{"jpeg", "webp"},
+		JPEGQuality: 80,
+	})
+
+	require.NoError(t, generator.Generate(ctx, 1))
+	require.Len(t, repo.variants, 2, "1280 is wider than the source and webp has no encoder")
+	assert.Equal(t, 320, repo.variants[0].Width)
+	assert.Equal(t, 160, repo.variants[0].Height)
+	assert.Equal(t, 640, repo.variants[1].Width)
+
+	r, err := files.Get(ctx, repo.variants[0].StorageKey)
+	require.NoError(t, err)
+	cfg, format, err := image.DecodeConfig(r)
+	require.NoError(t, r.Close())
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg", format)
+	assert.Equal(t, 320, cfg.Width)
+
+	require.NoError(t, generator.RunVariantsJob(ctx, &models.Job{Payload: []byte(`{"media_ids":[1]}`)}))
+	assert.Len(t, repo.variants, 2)
+
+	err = generator.RunVariantsJob(ctx, &models.Job{Payload: []byte(`{"media_ids":[2]}`)})
+	assert.ErrorIs(t, err, postgres.ErrNotFound)
+}
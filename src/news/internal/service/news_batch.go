@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+// GetNewsBatch godoc
+// @Summary      Get news items by ids
+// @Description  Returns up to 100 news items in the requested order. Cached items are read from Redis in one MGET, the rest are loaded from Postgres in one query and cached. Missing or hidden items are returned with found=false.
+// @Tags         news
+// @Accept       json
+// @Produce      json
+// @Param        ids               query     string                 false "Comma-separated news IDs (GET)"
+// @Param        request           body      dto.NewsBatchRequest   false "News IDs (POST)"
+// @Param        check_visibility  query     bool                   false "Check visibility (start/end time)" default(true)
+// @Success      200               {object}  dto.NewsBatchResponse
+// @Failure      400               {object}  dto.ErrorResponse
+// @Failure      500               {object}  dto.ErrorResponse
+// @Router       /news/batch [get]
+// @Router       /news/batch [post]
+func (s *NewsService) GetNewsBatch(
+	ctx context.Context,
+	req dto.NewsBatchRequest,
+) (*dto.NewsBatchResponse, error) {
+	const op = "service.NewsService.GetNewsBatch"
+
+	// Ключи кеша те же, что у GetNewsByID, поэтому id приводятся к каноническому виду.
+	found := make(map[string]*dto.NewsResponse, len(req.IDs))
+	keys := make([]string, 0, len(req.IDs))
+	ids := make([]int64, 0, len(req.IDs))
+	for _, raw := range req.IDs {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			continue
+		}
+		key := strconv.FormatInt(id, 10)
+		if _, ok := found[key]; ok {
+			continue
+		}
+		found[key] = nil
+		keys = append(keys, fmt.Sprintf("news:%s", key))
+		ids = append(ids, id)
+	}
+
+	var misses []int64
+	if len(keys) > 0 {
+		cached, err := s.redis.GetRedis().MGet(ctx, keys...).Result()
+		if err != nil {
+			logger.Log.Error(op, "Failed to get cached news", err)
+			cached = make([]interface{}, len(keys))
+		}
+		for i, value := range cached {
+			raw, ok := value.(string)
+			if !ok {
+				misses = append(misses, ids[i])
+				continue
+			}
+			var news dto.NewsResponse
+			if err := json.Unmarshal([]byte(raw), &news); err != nil {
+				logger.Log.Error(op, "Failed to unmarshal cached news", err)
+				misses = append(misses, ids[i])
+				continue
+			}
+			found[strconv.FormatInt(ids[i], 10)] = &news
+		}
+	}
+	logger.Log.Debug(op, "Cache hits", len(ids)-len(misses), "misses", len(misses))
+
+	if len(misses) > 0 {
+		loaded := make([]dto.NewsResponse, 0, len(misses))
+
+		err := s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+			loaded = loaded[:0]
+			newsList, err := s.newsRepo.GetByIDs(ctx, misses)
+			if err != nil {
+				return err
+			}
+
+			variants, err := s.loadVariants(ctx, newsList...)
+			if err != nil {
+				return err
+			}
+
+			for _, news := range newsList {
+				loaded = append(loaded, newsToResponse(news, variants))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		pipe := s.redis.GetRedis().Pipeline()
+		for i := range loaded {
+			news := &loaded[i]
+			found[news.ID] = news
+
+			toCache, err := json.Marshal(news)
+			if err != nil {
+				logger.Log.Error(op, "Failed to marshal news for caching", err)
+				continue
+			}
+			pipe.Set(ctx, fmt.Sprintf("news:%s", news.ID), toCache, s.cacheTTL)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			logger.Log.Error(op, "Failed to back-fill cache", err)
+		}
+	}
+
+	now := time.Now()
+	resp := &dto.NewsBatchResponse{Items: make([]dto.NewsBatchItem, len(req.IDs))}
+	for i, raw := range req.IDs {
+		item := dto.NewsBatchItem{ID: raw}
+		if id, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			news := found[strconv.FormatInt(id, 10)]
+			// В кеше лежат и скрытые новости, поэтому видимость проверяется для всех.
+			if news != nil && (!req.CheckVisibility || isVisibleAt(news, now)) {
+				item.Found = true
+				item.News = news
+			}
+		}
+		resp.Items[i] = item
+	}
+
+	return resp, nil
+}
+
+// isVisibleAt повторяет models.News.IsVisible для ответа из кеша.
+func isVisibleAt(news *dto.NewsResponse, now time.Time) bool {
+	startOk := news.StartTime.IsZero() || !now.Before(news.StartTime)
+	endOk := news.EndTime.IsZero() || !now.After(news.EndTime)
+	return startOk && endOk
+}
@@ -0,0 +1,85 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeNewsBatchRepo реализует только GetByIDs и запоминает запрошенные id.
+type fakeNewsBatchRepo struct {
+	service.NewsRepository
+	news      map[int64]*models.News
+	requested [][]int64
+}
+
+func (r *fakeNewsBatchRepo) GetByIDs(ctx context.Context, ids []int64) ([]*models.News, error) {
+	r.requested = append(r.requested, ids)
+
+	var out []*models.News
+	for _, id := range ids {
+		if news, ok := r.news[id]; ok {
+			copied := *news
+			out = append(out, &copied)
+		}
+	}
+	return out, nil
+}
+
+func batchIDs(resp *dto.NewsBatchResponse) (ids []string, found []bool) {
+	for _, item := range resp.Items {
+		ids = append(ids, item.ID)
+		found = append(found, item.Found)
+	}
+	return ids, found
+}
+
+func TestNewsService_GetNewsBatch(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := &fakeNewsBatchRepo{news: map[int64]*models.News{
+		2: {ID: 2, Title: "visible", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)},
+		3: {ID: 3, Title: "expired", StartTime: now.Add(-2 * time.Hour), EndTime: now.Add(-time.Hour)},
+	}}
+	redis, mr := newFakeRedis(t)
+	news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, fakeTxManager{}, redis, time.Minute)
+
+	cached, err := json.Marshal(dto.NewsResponse{ID: "1", Title: "cached", StartTime: now.Add(-time.Hour), EndTime: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.NoError(t, mr.Set("news:1", string(cached)))
+
+	resp, err := news.GetNewsBatch(ctx, dto.NewsBatchRequest{IDs: []string{"3", "1", "2", "99", "02"}, CheckVisibility: true})
+	require.NoError(t, err)
+
+	ids, found := batchIDs(resp)
+	assert.Equal(t, []string{"3", "1", "2", "99", "02"}, ids)
+	assert.Equal(t, []bool{false, true, true, false, true}, found)
+	assert.Equal(t, "cached", resp.Items[1].News.Title)
+	assert.Equal(t, "visible", resp.Items[4].News.Title)
+	assert.Nil(t, resp.Items[0].News)
+
+	require.Len(t, repo.requested, 1)
+	assert.ElementsMatch(t, []int64{3, 2, 99}, repo.requested[0], "only cache misses are loaded, each once")
+	assert.True(t, mr.Exists("news:2"))
+	assert.True(t, mr.Exists("news:3"))
+	assert.False(t, mr.Exists("news:99"))
+
+	// Второй запрос берёт найденные новости из кеша, в базу идёт только отсутствующая.
+	resp, err = news.GetNewsBatch(ctx, dto.NewsBatchRequest{IDs: []string{"1", "2", "3", "99"}})
+	require.NoError(t, err)
+	require.Len(t, repo.requested, 2)
+	assert.Equal(t, []int64{99}, repo.requested[1])
+
+	_, found = batchIDs(resp)
+	assert.Equal(t, []bool{true, true, true, false}, found, "hidden news is returned without visibility check")
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+// bulkEffects — то, что нужно сделать после коммита транзакции пакета.
+type bulkEffects struct {
+	changed []string
+}
+
+// BulkNews godoc
+// @Summary      Create, update and delete news in bulk
+// @Description  Applies up to 1000 operations. In atomic mode all operations run in one transaction and nothing is applied if one fails (422). In best_effort mode operations run in transactions of chunk_size; a failed chunk is retried one operation per transaction so that only the failing operations are reported.
+// @Tags         news
+// @Accept       json
+// @Produce      json
+// @Param        request  body      dto.BulkNewsRequest   true  "Operations"
+// @Success      200      {object}  dto.BulkNewsResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      422      {object}  dto.BulkNewsResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /news/bulk [post]
+func (s *NewsService) BulkNews(
+	ctx context.Context,
+	req dto.BulkNewsRequest,
+) (*dto.BulkNewsResponse, error) {
+	const op = "service.NewsService.BulkNews"
+
+	resp := &dto.BulkNewsResponse{
+		Mode:    req.Mode,
+		Results: make([]dto.BulkNewsResult, len(req.Operations)),
+	}
+	for i, operation := range req.Operations {
+		resp.Results[i] = dto.BulkNewsResult{Index: i, Op: operation.Op, ID: operation.ID}
+	}
+
+	var effects bulkEffects
+
+	if req.Mode == dto.BulkModeBestEffort {
+		for start := 0; start < len(req.Operations); start += req.ChunkSize {
+			end := min(start+req.ChunkSize, len(req.Operations))
+			if s.runBulk(ctx, req.Operations[start:end], resp.Results[start:end], &effects) {
+				continue
+			}
+			// Пачка откатилась целиком: повторяем её операции по одной,
+			// чтобы применить удачные и найти неудачные.
+			for i := start; i < end; i++ {
+				s.runBulk(ctx, req.Operations[i:i+1], resp.Results[i:i+1], &effects)
+			}
+		}
+	} else {
+		s.runBulk(ctx, req.Operations, resp.Results, &effects)
+	}
+
+	for _, result := range resp.Results {
+		if result.Status == dto.BulkStatusOK {
+			resp.Succeeded++
+		} else {
+			resp.Failed++
+		}
+	}
+	logger.Log.Info(op, "Bulk operations applied", resp.Succeeded, "failed", resp.Failed)
+
+	s.applyBulkEffects(ctx, effects)
+
+	return resp, nil
+}
+
+// applyBulkEffects сбрасывает кеш изменённых новостей, лент и списков.
+// Вызывается после коммита.
+func (s *NewsService) applyBulkEffects(ctx context.Context, effects bulkEffects) {
+	const op = "service.NewsService.applyBulkEffects"
+
+	if len(effects.changed) == 0 {
+		return
+	}
+
+	keys := make([]string, len(effects.changed))
+	for i, id := range effects.changed {
+		keys[i] = fmt.Sprintf("news:%s", id)
+	}
+	if err := s.redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+		logger.Log.Error(op, "Failed to invalidate cache", keys, "error", err)
+	}
+	invalidateFeedCache(ctx, s.redis)
+	invalidateNewsListCache(ctx, s.redis)
+}
+
+// runBulk выполняет операции в одной транзакции и заполняет results. При ошибке
+// транзакция откатывается: неудачная операция получает статус failed, остальные —
+// rolled_back. Возвращает true, если транзакция закоммичена.
+func (s *NewsService) runBulk(
+	ctx context.Context,
+	operations []dto.BulkNewsOperation,
+	results []dto.BulkNewsResult,
+	effects *bulkEffects,
+) bool {
+	const op = "service.NewsService.runBulk"
+
+	var (
+		chunk     bulkEffects
+		ids       = make([]string, len(operations))
+		failed    = -1
+		failedErr error
+	)
+
+	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		// При повторе транзакции всё собирается заново.
+		chunk, failed, failedErr = bulkEffects{}, -1, nil
+		for i, operation := range operations {
+			id, err := s.applyBulkOperation(ctx, operation)
+			if err != nil {
+				failed, failedErr = i, err
+				return err
+			}
+			ids[i] = id
+			chunk.changed = append(chunk.changed, id)
+		}
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.Warn(op, "Bulk transaction rolled back", err)
+		for i := range results {
+			switch {
+			case i == failed:
+				results[i].Status = dto.BulkStatusFailed
+				results[i].Error = failedErr.Error()
+			case failed >= 0:
+				results[i].Status = dto.BulkStatusRolledBack
+			default:
+				// Все операции прошли, но транзакцию не удалось закоммитить.
+				results[i].Status = dto.BulkStatusFailed
+				results[i].Error = err.Error()
+			}
+		}
+		return false
+	}
+
+	for i := range results {
+		results[i].ID = ids[i]
+		results[i].Status = dto.BulkStatusOK
+	}
+	effects.changed = append(effects.changed, chunk.changed...)
+	return true
+}
+
+// applyBulkOperation выполняет одну операцию внутри транзакции и возвращает id
+// новости.
+func (s *NewsService) applyBulkOperation(
+	ctx context.Context,
+	operation dto.BulkNewsOperation,
+) (string, error) {
+	switch operation.Op {
+	case "create":
+		blocks, err := s.buildContentBlocks(ctx, operation.Create.Content)
+		if err != nil {
+			return "", err
+		}
+		news, err := s.createNews(ctx, *operation.Create, blocks)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(news.ID, 10), nil
+	case "update":
+		newsID, err := strconv.ParseInt(operation.ID, 10, 64)
+		if err != nil {
+			return "", err
+		}
+		blocks, err := s.buildContentBlocks(ctx, operation.Update.Content)
+		if err != nil {
+			return "", err
+		}
+		if _, err := s.updateNews(ctx, newsID, *operation.Update, blocks); err != nil {
+			return "", err
+		}
+		return operation.ID, nil
+	case "delete":
+		newsID, err := strconv.ParseInt(operation.ID, 10, 64)
+		if err != nil {
+			return "", err
+		}
+		if err := s.deleteNews(ctx, newsID); err != nil {
+			return "", err
+		}
+		return operation.ID, nil
+	default:
+		return "", fmt.Errorf("unknown bulk operation %q", operation.Op)
+	}
+}
@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"context"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeBulkRepo хранит новости в памяти; откат транзакции эмулирует snapshotTxManager.
+type fakeBulkRepo struct {
+	service.NewsRepository
+	news   map[int64]models.News
+	nextID int64
+}
+
+func (r *fakeBulkRepo) Create(ctx context.Context, news *models.News) error {
+	r.nextID++
+	news.ID = r.nextID
+	r.news[news.ID] = *news
+	return nil
+}
+
+func (r *fakeBulkRepo) GetByID(ctx context.Context, id int64) (*models.News, error) {
+	news, ok := r.news[id]
+	if !ok {
+		return nil, postgres.ErrNotFound
+	}
+	return &news, nil
+}
+
+func (r *fakeBulkRepo) Update(ctx context.Context, news *models.News) error {
+	if _, ok := r.news[news.ID]; !ok {
+		return postgres.ErrNotFound
+	}
+	r.news[news.ID] = *news
+	return nil
+}
+
+func (r *fakeBulkRepo) Delete(ctx context.Context, id int64) error {
+	if _, ok := r.news[id]; !ok {
+		return postgres.ErrNotFound
+	}
+	delete(r.news, id)
+	return nil
+}
+
+func (r *fakeBulkRepo) SlugTaken(ctx context.Context, slug string, exceptNewsID int64) (bool, error) {
+	for id, news := range r.news {
+		if news.Slug == slug && id != exceptNewsID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *fakeBulkRepo) MoveSlug(ctx context.Context, newsID int64, oldSlug, newSlug string) error {
+	return nil
+}
+
+// snapshotTxManager откатывает репозиторий при ошибке и считает внешние
+// транзакции; вложенный вызов ведёт себя как SAVEPOINT.
+type snapshotTxManager struct {
+	fakeTxManager
+	repo         *fakeBulkRepo
+	transactions int
+	depth        int
+}
+
+func (m *snapshotTxManager) RunReadCommited(ctx context.Context, f func(context.Context) error) error {
+	if m.depth == 0 {
+		m.transactions++
+	}
+	m.depth++
+	defer func() { m.depth-- }()
+
+	snapshot, nextID := maps.Clone(m.repo.news), m.repo.nextID
+	if err := f(ctx); err != nil {
+		m.repo.news, m.repo.nextID = snapshot, nextID
+		return err
+	}
+	return nil
+}
+
+func createOp(title string) dto.BulkNewsOperation {
+	return dto.BulkNewsOperation{Op: "create", Create: &dto.CreateNewsRequest{
+		Title:     title,
+		Category:  "Tech",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+		Content:   []dto.CreateContentBlock{{Type: "text", Content: "text", Position: 1}},
+	}}
+}
+
+func statuses(resp *dto.BulkNewsResponse) []string {
+	out := make([]string, len(resp.Results))
+	for i, result := range resp.Results {
+		out[i] = result.Status
+	}
+	return out
+}
+
+func TestNewsService_BulkNews(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*service.NewsService, *fakeBulkRepo, *snapshotTxManager, *miniredis.Miniredis) {
+		repo := &fakeBulkRepo{news: map[int64]models.News{
+			1: {ID: 1, Title: "Existing", Slug: "existing"},
+			2: {ID: 2, Title: "To delete", Slug: "to-delete"},
+		}, nextID: 2}
+		txManager := &snapshotTxManager{repo: repo}
+		redis, mr := newFakeRedis(t)
+		require.NoError(t, mr.Set("news:1", "{}"))
+		news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, txManager, redis, time.Minute)
+		return news, repo, txManager, mr
+	}
+
+	t.Run("atomic applies everything in one transaction", func(t *testing.T) {
+		news, repo, txManager, mr := setup(t)
+
+		title := "Renamed"
+		resp, err := news.BulkNews(ctx, dto.BulkNewsRequest{Mode: dto.BulkModeAtomic, ChunkSize: 100, Operations: []dto.BulkNewsOperation{
+			createOp("Hello"),
+			createOp("Hello"),
+			{Op: "update", ID: "1", Update: &dto.UpdateNewsRequest{ID: "1", Title: title}},
+			{Op: "delete", ID: "2"},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ok", "ok", "ok", "ok"}, statuses(resp))
+		assert.Equal(t, 4, resp.Succeeded)
+		assert.Equal(t, 1, txManager.transactions)
+		assert.Equal(t, "3", resp.Results[0].ID)
+		assert.Equal(t, "hello", repo.news[3].Slug)
+		assert.Equal(t, "hello-2", repo.news[4].Slug)
+		assert.Equal(t, title, repo.news[1].Title)
+		assert.NotContains(t, repo.news, int64(2))
+		assert.False(t, mr.Exists("news:1"))
+	})
+
+	t.Run("atomic rolls back on failure", func(t *testing.T) {
+		news, repo, _, mr := setup(t)
+
+		resp, err := news.BulkNews(ctx, dto.BulkNewsRequest{Mode: dto.BulkModeAtomic, ChunkSize: 100, Operations: []dto.BulkNewsOperation{
+			createOp("Hello"),
+			{Op: "delete", ID: "999"},
+			createOp("World"),
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"rolled_back", "failed", "rolled_back"}, statuses(resp))
+		assert.Equal(t, 0, resp.Succeeded)
+		assert.Equal(t, 3, resp.Failed)
+		assert.NotEmpty(t, resp.Results[1].Error)
+		assert.Len(t, repo.news, 2)
+		assert.True(t, mr.Exists("news:1"))
+	})
+
+	t.Run("best effort isolates failed operations", func(t *testing.T) {
+		news, repo, txManager, _ := setup(t)
+
+		resp, err := news.BulkNews(ctx, dto.BulkNewsRequest{Mode: dto.BulkModeBestEffort, ChunkSize: 2, Operations: []dto.BulkNewsOperation{
+			createOp("First"),
+			{Op: "delete", ID: "999"},
+			createOp("Second"),
+			{Op: "delete", ID: "2"},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"ok", "failed", "ok", "ok"}, statuses(resp))
+		assert.Equal(t, 3, resp.Succeeded)
+		assert.Equal(t, 1, resp.Failed)
+		// Первая пачка, две её операции по отдельности и вторая пачка.
+		assert.Equal(t, 4, txManager.transactions)
+		assert.Len(t, repo.news, 3)
+	})
+}
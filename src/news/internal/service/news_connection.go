@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// cursorPayload — содержимое курсора. Поле сортировки хранится в курсоре, чтобы
+// курсор от одного порядка нельзя было применить к другому.
+type cursorPayload struct {
+	SortBy string `json:"s"`
+	Value  string `json:"v"`
+	ID     int64  `json:"id"`
+}
+
+// ListNewsConnection возвращает страницу новостей после курсора req.After.
+// В отличие от ListNews, страницы не смещаются, когда между запросами
+// добавляются новости. Блоки контента и общее число читаются только по запросу.
+func (s *NewsService) ListNewsConnection(
+	ctx context.Context,
+	req dto.NewsConnectionRequest,
+) (*dto.NewsConnectionResponse, error) {
+	filter := models.NewsFilter{
+		Search:          req.Search,
+		Category:        req.Category,
+		SortBy:          req.SortBy,
+		SortDir:         req.SortDir,
+		CheckVisibility: req.CheckVisibility,
+	}
+
+	var after *models.NewsCursor
+	if req.After != "" {
+		cursor, err := decodeCursor(req.After, req.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		after = cursor
+	}
+
+	var resp *dto.NewsConnectionResponse
+	// Страница, блоки контента и общее число читаются из одного снимка базы.
+	err := s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+		// Одна лишняя новость показывает, есть ли следующая страница.
+		newsList, err := s.newsRepo.ListAfter(ctx, filter, after, req.First+1, req.IncludeContent)
+		if err != nil {
+			return err
+		}
+
+		resp = &dto.NewsConnectionResponse{
+			HasNextPage: len(newsList) > req.First,
+		}
+		if resp.HasNextPage {
+			newsList = newsList[:req.First]
+		}
+
+		variants, err := s.loadVariants(ctx, newsList...)
+		if err != nil {
+			return err
+		}
+
+		resp.Items = make([]dto.NewsResponse, len(newsList))
+		for i, news := range newsList {
+			resp.Items[i] = newsToResponse(news, variants)
+		}
+		if len(newsList) > 0 {
+			resp.EndCursor = encodeCursor(newsList[len(newsList)-1], req.SortBy)
+		}
+
+		if req.IncludeTotal {
+			resp.TotalCount, err = s.newsRepo.Count(ctx, filter)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListCategories возвращает категории с числом новостей.
+func (s *NewsService) ListCategories(ctx context.Context, checkVisibility bool) ([]dto.CategoryResponse, error) {
+	var categories []models.CategoryCount
+	err := s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+		var err error
+		categories, err = s.newsRepo.Categories(ctx, checkVisibility)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]dto.CategoryResponse, len(categories))
+	for i, category := range categories {
+		resp[i] = dto.CategoryResponse{
+			Name:  category.Name,
+			Count: category.Count,
+		}
+	}
+	return resp, nil
+}
+
+func encodeCursor(news *models.News, sortBy string) string {
+	payload := cursorPayload{SortBy: sortBy, ID: news.ID}
+
+	switch sortBy {
+	case "title":
+		payload.Value = news.Title
+	case "category":
+		payload.Value = news.Category
+	case "start_time":
+		payload.Value = news.StartTime.Format(time.RFC3339Nano)
+	case "end_time":
+		payload.Value = news.EndTime.Format(time.RFC3339Nano)
+	default:
+		payload.Value = news.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	raw, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(cursor string, sortBy string) (*models.NewsCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if payload.SortBy != sortBy {
+		return nil, fmt.Errorf("%w: cursor was issued for sort by %q", ErrInvalidCursor, payload.SortBy)
+	}
+
+	switch sortBy {
+	case "title", "category":
+	default:
+		if _, err := time.Parse(time.RFC3339Nano, payload.Value); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+	}
+
+	return &models.NewsCursor{Value: payload.Value, ID: payload.ID}, nil
+}
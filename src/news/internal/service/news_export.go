@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// exportCSVHeader — колонки CSV-выгрузки; блоки контента склеиваются в последнюю.
+var exportCSVHeader = []string{
+	"id", "title", "slug", "category", "live",
+	"created_at", "updated_at", "start_time", "end_time", "content",
+}
+
+type ExportService struct {
+	newsRepo  NewsRepository
+	txManager storage.TxManagerInterface
+	fetchSize int
+}
+
+func NewExportService(
+	newsRepo NewsRepository,
+	txManager storage.TxManagerInterface,
+	cfg config.ExportConfig,
+) *ExportService {
+	return &ExportService{
+		newsRepo:  newsRepo,
+		txManager: txManager,
+		fetchSize: cfg.FetchSize,
+	}
+}
+
+// ExportNews godoc
+// @Summary      Export news archive
+// @Description  Streams all news matching the list filters as NDJSON (one news with nested content blocks per line) or CSV (content blocks concatenated into one column). Gzip is used when the client accepts it.
+// @Tags         news
+// @Produce      application/x-ndjson
+// @Produce      text/csv
+// @Param        format            query     string  false  "Export format"  Enums(ndjson, csv)  default(ndjson)
+// @Param        search            query     string  false  "Search by title"
+// @Param        category          query     string  false  "Filter by category"
+// @Param        sort_by           query     string  false  "Sort field"  Enums(created_at, start_time, end_time, title, category)  default(created_at)
+// @Param        sort_dir          query     string  false  "Sort direction"  Enums(asc, desc)  default(desc)
+// @Param        check_visibility  query     bool    false  "Only visible news"  default(true)
+// @Success      200  {string}  string  "NDJSON or CSV stream"
+// @Failure      400  {object}  dto.ErrorResponse
+// @Router       /news/export [get]
+func (s *ExportService) ExportNews(ctx context.Context, req dto.NewsExportRequest, w io.Writer) error {
+	const op = "service.ExportService.ExportNews"
+
+	filter := models.NewsFilter{
+		Search:          req.Search,
+		Category:        req.Category,
+		SortBy:          req.SortBy,
+		SortDir:         req.SortDir,
+		CheckVisibility: req.CheckVisibility,
+	}
+
+	var (
+		write func(news *models.News) error
+		flush func() error
+	)
+	switch req.Format {
+	case dto.ArchiveFormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(exportCSVHeader); err != nil {
+			return err
+		}
+		write = func(news *models.News) error {
+			return cw.Write(newsToCSVRecord(news))
+		}
+		flush = func() error {
+			cw.Flush()
+			return cw.Error()
+		}
+	default:
+		enc := json.NewEncoder(w)
+		write = func(news *models.News) error {
+			return enc.Encode(newsToResponse(news, nil))
+		}
+		flush = func() error { return nil }
+	}
+
+	// Курсор живёт в одной REPEATABLE READ транзакции: выгрузка видит согласованный
+	// снимок архива, а в памяти держится не больше fetchSize строк. Строки сразу
+	// уходят клиенту, поэтому транзакцию нельзя повторять.
+	exported := 0
+	err := s.txManager.RunRepeatableRead(storage.WithRetryPolicy(ctx, storage.NoRetry), func(ctx context.Context) error {
+		return s.newsRepo.Export(ctx, filter, s.fetchSize, func(news *models.News) error {
+			exported++
+			return write(news)
+		})
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to export news", err)
+		return err
+	}
+	if err := flush(); err != nil {
+		logger.Log.Error(op, "Failed to flush export", err)
+		return err
+	}
+
+	logger.Log.Info(op, "News exported", exported, "format", req.Format)
+	return nil
+}
+
+func newsToCSVRecord(news *models.News) []string {
+	return []string{
+		strconv.FormatInt(news.ID, 10),
+		news.Title,
+		news.Slug,
+		news.Category,
+		strconv.FormatBool(news.Live),
+		formatExportTime(news.CreatedAt),
+		formatExportTime(news.UpdatedAt),
+		formatExportTime(news.StartTime),
+		formatExportTime(news.EndTime),
+		flattenContent(news.Content),
+	}
+}
+
+// flattenContent склеивает блоки в текст через пустую строку; картинка
+// представлена адресом файла.
+func flattenContent(blocks []models.ContentBlock) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type == models.ImageBlock && block.MediaID != nil {
+			parts = append(parts, mediaURL(*block.MediaID))
+			continue
+		}
+		parts = append(parts, block.Content)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,98 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeExportRepo реализует только Export и запоминает фильтр и размер порции.
+type fakeExportRepo struct {
+	service.NewsRepository
+	news      []*models.News
+	filter    models.NewsFilter
+	fetchSize int
+}
+
+func (r *fakeExportRepo) Export(ctx context.Context, filter models.NewsFilter, fetchSize int, fn func(news *models.News) error) error {
+	r.filter, r.fetchSize = filter, fetchSize
+	for _, news := range r.news {
+		if err := fn(news); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportRequest(format string) dto.NewsExportRequest {
+	return dto.NewsExportRequest{Format: format, Category: "Sport", SortBy: "title", SortDir: "asc", CheckVisibility: true}
+}
+
+func TestExportService_ExportNews(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	mediaID := int64(7)
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &fakeExportRepo{news: []*models.News{
+		{ID: 1, Title: "Match, day 1", Slug: "match-day-1", Category: "Sport", CreatedAt: created, UpdatedAt: created, Content: []models.ContentBlock{
+			{ID: 10, Type: models.TextBlock, Content: "First \"quoted\" line", Position: 0},
+			{ID: 11, Type: models.ImageBlock, MediaID: &mediaID, Position: 1},
+			{ID: 12, Type: models.LinkBlock, Content: "https://example.com", Position: 2},
+		}},
+		{ID: 2, Title: "Match, day 2", Slug: "match-day-2", Category: "Sport", CreatedAt: created, UpdatedAt: created},
+	}}
+	svc := service.NewExportService(repo, fakeTxManager{}, config.ExportConfig{FetchSize: 50})
+
+	t.Run("ndjson nests content blocks", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, svc.ExportNews(ctx, exportRequest(dto.ArchiveFormatNDJSON), &out))
+
+		assert.Equal(t, models.NewsFilter{Category: "Sport", SortBy: "title", SortDir: "asc", CheckVisibility: true}, repo.filter)
+		assert.Equal(t, 50, repo.fetchSize)
+
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+
+		var first dto.NewsResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+		assert.Equal(t, "1", first.ID)
+		require.Len(t, first.Content, 3)
+		assert.Equal(t, "/media/7", first.Content[1].MediaURL)
+
+		var second dto.NewsResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+		assert.Equal(t, "2", second.ID)
+		assert.Empty(t, second.Content)
+	})
+
+	t.Run("csv flattens content blocks", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, svc.ExportNews(ctx, exportRequest(dto.ArchiveFormatCSV), &out))
+
+		records, err := csv.NewReader(&out).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+
+		assert.Equal(t, "id", records[0][0])
+		assert.Equal(t, "content", records[0][9])
+		assert.Equal(t, []string{
+			"1", "Match, day 1", "match-day-1", "Sport", "false",
+			"2025-03-01T12:00:00Z", "2025-03-01T12:00:00Z", "", "",
+			"First \"quoted\" line\n\n/media/7\n\nhttps://example.com",
+		}, records[1])
+		assert.Equal(t, "", records[2][9])
+	})
+}
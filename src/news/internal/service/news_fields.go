@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+const newsListCacheKeys = "news:list:keys"
+
+// selectableNewsFields — поля, которые клиент может перечислить в fields=.
+var selectableNewsFields = []string{
+	"id", "title", "slug", "category", "live", "content",
+	"created_at", "updated_at", "start_time", "end_time",
+}
+
+// newsFieldset разбирает fields= и include= списка новостей. Без fields возвращаются
+// все поля вместе с контентом, как раньше. Иначе поля сортируются и дополняются id,
+// чтобы одинаковые наборы давали один ключ кеша; withContent — нужно ли загружать блоки.
+func newsFieldset(req dto.NewsListRequest) (fields []string, withContent bool, err error) {
+	if req.Fields == "" {
+		return nil, true, nil
+	}
+
+	fields = []string{"id"}
+	for _, field := range strings.Split(req.Fields, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !slices.Contains(selectableNewsFields, field) {
+			return nil, false, fmt.Errorf("%w: %q", ErrInvalidFields, field)
+		}
+		if !slices.Contains(fields, field) {
+			fields = append(fields, field)
+		}
+	}
+	if req.Include == "content" && !slices.Contains(fields, "content") {
+		fields = append(fields, "content")
+	}
+	slices.Sort(fields)
+
+	return fields, slices.Contains(fields, "content"), nil
+}
+
+// newsListCacheKey учитывает все параметры запроса, включая набор полей:
+// урезанный ответ нельзя отдавать клиенту, который просил полный.
+func newsListCacheKey(req dto.NewsListRequest, fields []string) string {
+	fieldset := "*"
+	if fields != nil {
+		fieldset = strings.Join(fields, ",")
+	}
+	return fmt.Sprintf(
+		"news:list:%d:%d:%s:%s:%t:%s:%q:%q",
+		req.Page, req.Limit, req.SortBy, req.SortDir, req.CheckVisibility, fieldset, req.Category, req.Search,
+	)
+}
+
+// invalidateNewsListCache удаляет все закешированные страницы списка новостей.
+func invalidateNewsListCache(ctx context.Context, redis RedisClient) {
+	const op = "service.invalidateNewsListCache"
+
+	keys, err := redis.GetRedis().SMembers(ctx, newsListCacheKeys).Result()
+	if err != nil {
+		logger.Log.Error(op, "Failed to get news list cache keys", err)
+		return
+	}
+
+	keys = append(keys, newsListCacheKeys)
+	if err := redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+		logger.Log.Error(op, "Failed to invalidate news list cache", keys, "error", err)
+	}
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeNewsListRepo реализует только List, остальные методы не вызываются.
+type fakeNewsListRepo struct {
+	service.NewsRepository
+	calls       int
+	fields      []string
+	withContent bool
+}
+
+func (r *fakeNewsListRepo) List(ctx context.Context, offset, limit int, search, category, sortBy, sortDir string, checkVisibility bool, fields []string, withContent bool) ([]*models.News, int64, error) {
+	r.calls++
+	r.fields = fields
+	r.withContent = withContent
+
+	news := &models.News{ID: 1, Title: "Title", StartTime: time.Now().Add(-time.Hour), EndTime: time.Now().Add(time.Hour)}
+	if withContent {
+		news.Content = []models.ContentBlock{{ID: 10, NewsID: 1, Type: models.TextBlock, Content: "text", Position: 1}}
+	}
+	return []*models.News{news}, 1, nil
+}
+
+func listRequest(fields, include string) dto.NewsListRequest {
+	return dto.NewsListRequest{Page: 1, Limit: 10, SortBy: "created_at", SortDir: "desc", CheckVisibility: true, Fields: fields, Include: include}
+}
+
+func itemKeys(t *testing.T, resp *dto.NewsListResponse) []string {
+	body, err := json.Marshal(resp)
+	require.NoError(t, err)
+
+	var decoded struct {
+		Items []map[string]json.RawMessage `json:"items"`
+	}
+	require.NoError(t, json.Unmarshal(body, &decoded))
+	require.Len(t, decoded.Items, 1)
+
+	keys := make([]string, 0, len(decoded.Items[0]))
+	for key := range decoded.Items[0] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestNewsService_ListNewsFields(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	repo := &fakeNewsListRepo{}
+	redis, _ := newFakeRedis(t)
+	news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, fakeTxManager{}, redis, time.Minute)
+
+	t.Run("all fields by default", func(t *testing.T) {
+		resp, err := news.ListNews(ctx, listRequest("", ""))
+		require.NoError(t, err)
+		assert.Nil(t, repo.fields)
+		assert.True(t, repo.withContent)
+		assert.Contains(t, itemKeys(t, resp), "content")
+		assert.Len(t, itemKeys(t, resp), 10)
+	})
+
+	t.Run("headlines skip content", func(t *testing.T) {
+		resp, err := news.ListNews(ctx, listRequest("title, slug", ""))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"id", "slug", "title"}, repo.fields)
+		assert.False(t, repo.withContent)
+		assert.ElementsMatch(t, []string{"id", "slug", "title"}, itemKeys(t, resp))
+	})
+
+	t.Run("include content", func(t *testing.T) {
+		resp, err := news.ListNews(ctx, listRequest("title", "content"))
+		require.NoError(t, err)
+		assert.True(t, repo.withContent)
+		assert.ElementsMatch(t, []string{"id", "title", "content"}, itemKeys(t, resp))
+	})
+
+	t.Run("cache key depends on fieldset", func(t *testing.T) {
+		calls := repo.calls
+
+		resp, err := news.ListNews(ctx, listRequest("slug,title", ""))
+		require.NoError(t, err)
+		assert.Equal(t, calls, repo.calls, "same fieldset in another order must hit the cache")
+		assert.ElementsMatch(t, []string{"id", "slug", "title"}, itemKeys(t, resp))
+
+		_, err = news.ListNews(ctx, listRequest("slug", ""))
+		require.NoError(t, err)
+		assert.Equal(t, calls+1, repo.calls)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := news.ListNews(ctx, listRequest("title,body", ""))
+		assert.ErrorIs(t, err, service.ErrInvalidFields)
+	})
+}
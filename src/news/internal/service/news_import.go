@@ -0,0 +1,575 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+var validate = validator.New()
+
+// errDryRun откатывает транзакцию пачки при пробном импорте.
+var errDryRun = errors.New("dry run")
+
+// JobKindNewsImport — фоновый импорт файла, сохранённого в задаче импорта.
+const JobKindNewsImport = "news.import"
+
+type ImportRepository interface {
+	CreateJob(ctx context.Context, job *models.ImportJob, data io.Reader) error
+	UpdateJob(ctx context.Context, job *models.ImportJob) error
+	AddErrors(ctx context.Context, jobID int64, importErrors []models.ImportError) error
+	ClearErrors(ctx context.Context, jobID int64) error
+	GetJob(ctx context.Context, id int64) (*models.ImportJob, error)
+	GetJobData(ctx context.Context, id int64) (io.Reader, error)
+	ListErrors(ctx context.Context, jobID int64, limit int) ([]models.ImportError, error)
+	NewsIDsByExternalID(ctx context.Context, externalIDs []string) (map[string]int64, error)
+	LinkExternalID(ctx context.Context, externalID string, newsID int64) error
+}
+
+// JobEnqueuer ставит фоновые задачи в очередь в транзакции из контекста.
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (*models.Job, error)
+}
+
+// ImportService загружает новости из NDJSON и CSV. Записи сохраняются пачками
+// через те же операции, что и одиночные запросы, и создаются или обновляются по
+// внешнему id. Прогресс и ошибки записей пишутся в задачу импорта. Фоновые
+// импорты выполняются очередью задач.
+type ImportService struct {
+	news      *NewsService
+	repo      ImportRepository
+	txManager storage.TxManagerInterface
+	jobs      JobEnqueuer
+
+	chunkSize     int
+	maxRecordSize int
+	maxErrors     int
+	maxFileSize   int64
+}
+
+// NewImportService создаёт сервис импорта. jobs может быть nil, если нужен
+// только синхронный ImportNews; обработчик фоновых импортов — RunImportJob.
+func NewImportService(
+	news *NewsService,
+	repo ImportRepository,
+	txManager storage.TxManagerInterface,
+	jobs JobEnqueuer,
+	cfg config.ImportConfig,
+) *ImportService {
+	return &ImportService{
+		news:          news,
+		repo:          repo,
+		txManager:     txManager,
+		jobs:          jobs,
+		chunkSize:     max(cfg.ChunkSize, 1),
+		maxRecordSize: max(cfg.MaxRecordSize, 64*1024),
+		maxErrors:     max(cfg.MaxErrors, 0),
+		maxFileSize:   cfg.MaxFileSize,
+	}
+}
+
+// StartImport godoc
+// @Summary      Import news from NDJSON or CSV
+// @Description  Creates an import job and processes the request body in the background job queue. The body is streamed into the job and may be up to import.max_file_size bytes. Every record is validated with the create news rules and is created or updated by its external_id. With dry_run=true every chunk is executed in a transaction that is rolled back. Progress and per-line errors are available from the job status endpoint.
+// @Tags         news
+// @Accept       application/x-ndjson
+// @Accept       text/csv
+// @Produce      json
+// @Param        format   query     string  true   "File format"  Enums(ndjson, csv)
+// @Param        dry_run  query     bool    false  "Validate only"
+// @Param        file     body      string  true   "NDJSON or CSV file"
+// @Success      202      {object}  dto.ImportJobResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      413      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /news/import [post]
+func (s *ImportService) StartImport(
+	ctx context.Context,
+	req dto.NewsImportRequest,
+	r io.Reader,
+	size int64,
+) (*dto.ImportJobResponse, error) {
+	const op = "service.ImportService.StartImport"
+
+	if s.jobs == nil {
+		return nil, ErrImportQueueUnavailable
+	}
+	// size — размер файла из Content-Length, -1 если он неизвестен; сам файл
+	// читается потоком, поэтому предел проверяется и по мере чтения.
+	if s.maxFileSize > 0 {
+		if size > s.maxFileSize {
+			return nil, ErrImportFileTooLarge
+		}
+		r = &maxSizeReader{r: r, left: s.maxFileSize}
+	}
+
+	job, err := s.createJob(ctx, req, 0, r)
+	if err != nil {
+		if !errors.Is(err, ErrImportFileTooLarge) && !errors.Is(err, ErrEmptyImportFile) {
+			logger.Log.Error(op, "Failed to create import job", err)
+		}
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Import job queued", job.ID, "format", job.Format, "dryRun", job.DryRun, "bytes", job.BytesTotal)
+	return importJobToResponse(job, nil), nil
+}
+
+// maxSizeReader возвращает ErrImportFileTooLarge, как только прочитано больше left байт.
+type maxSizeReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (m *maxSizeReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > m.left+1 {
+		p = p[:m.left+1]
+	}
+	n, err := m.r.Read(p)
+	if int64(n) > m.left {
+		return 0, ErrImportFileTooLarge
+	}
+	m.left -= int64(n)
+	return n, err
+}
+
+type importJobPayload struct {
+	ImportJobID int64 `json:"import_job_id"`
+}
+
+// RunImportJob — обработчик задач news.import. Каждая попытка импортирует файл
+// заново: записи, сохранённые прошлой попыткой, связаны с внешними id и будут
+// обновлены, а не созданы повторно. Ошибка формата файла не повторяется; при
+// ошибке базы или прерывании задача импорта возвращается в pending до следующей
+// попытки и получает статус failed, когда попытки кончились.
+func (s *ImportService) RunImportJob(ctx context.Context, queued *models.Job) error {
+	const op = "service.ImportService.RunImportJob"
+
+	var payload importJobPayload
+	if err := json.Unmarshal(queued.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	job, err := s.repo.GetJob(ctx, payload.ImportJobID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return PermanentJobError(err)
+		}
+		return err
+	}
+	if job.Status == models.ImportSucceeded {
+		return nil
+	}
+
+	data, err := s.repo.GetJobData(ctx, job.ID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return PermanentJobError(err)
+		}
+		return err
+	}
+
+	*job = models.ImportJob{
+		ID:         job.ID,
+		Format:     job.Format,
+		DryRun:     job.DryRun,
+		BytesTotal: job.BytesTotal,
+		CreatedAt:  job.CreatedAt,
+	}
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return s.repo.ClearErrors(ctx, job.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.run(ctx, job, data)
+	if err != nil && !isPermanentJobError(err) && queued.Attempts < queued.MaxAttempts {
+		job.Status = models.ImportPending
+		job.Error = err.Error()
+		if err := s.saveProgress(context.WithoutCancel(ctx), job, nil); err != nil {
+			logger.Log.Error(op, "Failed to return import job to pending", err, "jobID", job.ID)
+		}
+		return err
+	}
+
+	s.finishJob(ctx, job, err)
+	return err
+}
+
+// ImportNews выполняет импорт синхронно и возвращает итоговую задачу с отчётом.
+// size — размер файла для прогресса, 0 если он неизвестен.
+func (s *ImportService) ImportNews(
+	ctx context.Context,
+	req dto.NewsImportRequest,
+	r io.Reader,
+	size int64,
+) (*dto.ImportJobResponse, error) {
+	job, err := s.createJob(ctx, req, size, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.finishJob(ctx, job, s.run(ctx, job, r))
+
+	return s.GetImportJob(context.WithoutCancel(ctx), dto.ImportJobIDRequest{ID: strconv.FormatInt(job.ID, 10)})
+}
+
+// GetImportJob godoc
+// @Summary      Get import job status
+// @Description  Returns progress counters of an import job and up to import.max_errors per-line errors in file order.
+// @Tags         news
+// @Produce      json
+// @Param        id   path      string  true  "Import job ID"
+// @Success      200  {object}  dto.ImportJobResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /news/import/{id} [get]
+func (s *ImportService) GetImportJob(ctx context.Context, req dto.ImportJobIDRequest) (*dto.ImportJobResponse, error) {
+	const op = "service.ImportService.GetImportJob"
+
+	jobID, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		logger.Log.Error(op, "Failed to parse import job ID", err)
+		return nil, err
+	}
+
+	job, err := s.repo.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	importErrors, err := s.repo.ListErrors(ctx, jobID, s.maxErrors)
+	if err != nil {
+		return nil, err
+	}
+
+	return importJobToResponse(job, importErrors), nil
+}
+
+// createJob создаёт задачу импорта. Если передан файл, в той же транзакции
+// ставится задача очереди, которая его импортирует.
+func (s *ImportService) createJob(
+	ctx context.Context,
+	req dto.NewsImportRequest,
+	size int64,
+	data io.Reader,
+) (*models.ImportJob, error) {
+	job := &models.ImportJob{
+		Format:     req.Format,
+		DryRun:     req.DryRun,
+		Status:     models.ImportPending,
+		BytesTotal: size,
+	}
+
+	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateJob(ctx, job, data); err != nil {
+			return err
+		}
+		if data == nil {
+			return nil
+		}
+		if job.BytesTotal == 0 {
+			return ErrEmptyImportFile
+		}
+		_, err := s.jobs.Enqueue(ctx, JobKindNewsImport, importJobPayload{ImportJobID: job.ID}, time.Time{})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// run читает файл пачками по chunkSize записей, сохраняет каждую пачку и после
+// неё записывает прогресс задачи. Итоговый статус записывает вызывающий; ошибки
+// самого файла возвращаются обёрнутыми в PermanentJobError.
+func (s *ImportService) run(ctx context.Context, job *models.ImportJob, r io.Reader) error {
+	const op = "service.ImportService.run"
+
+	job.Status = models.ImportRunning
+	job.Error = ""
+	if err := s.saveProgress(ctx, job, nil); err != nil {
+		logger.Log.Error(op, "Failed to mark import job running", err, "jobID", job.ID)
+		return err
+	}
+
+	counter := &countingReader{r: r}
+	records, err := newRecordReader(job.Format, counter, s.maxRecordSize)
+	if err != nil {
+		return PermanentJobError(err)
+	}
+
+	var (
+		chunk   = make([]importRecord, 0, s.chunkSize)
+		reports []models.ImportError
+		readErr error
+		// dryCreated — внешние id, которые пробный импорт уже «создал» в прошлых
+		// пачках: их транзакции откатились, но повтор в файле — это обновление.
+		dryCreated = make(map[string]bool)
+	)
+	for readErr == nil {
+		record, err := records.Next()
+		var recErr *recordError
+		switch {
+		case err == nil:
+			if err := validate.Struct(record.news); err != nil {
+				reports = append(reports, models.ImportError{Line: record.line, ExternalID: record.news.ExternalID, Error: err.Error()})
+				job.Processed++
+				job.Failed++
+				break
+			}
+			chunk = append(chunk, record)
+		case errors.As(err, &recErr):
+			reports = append(reports, models.ImportError{Line: recErr.line, ExternalID: recErr.externalID, Error: recErr.err.Error()})
+			job.Processed++
+			job.Failed++
+		case errors.Is(err, io.EOF):
+			readErr = err
+		default:
+			readErr = PermanentJobError(err)
+		}
+
+		if len(chunk) < s.chunkSize && readErr == nil {
+			continue
+		}
+		if ctx.Err() != nil {
+			readErr = ctx.Err()
+			break
+		}
+
+		reports = append(reports, s.importChunk(ctx, job, chunk, dryCreated)...)
+		chunk = chunk[:0]
+
+		job.BytesRead = counter.n
+		if err := s.saveProgress(ctx, job, reports); err != nil {
+			logger.Log.Error(op, "Failed to save import progress", err, "jobID", job.ID)
+			readErr = err
+			break
+		}
+		reports = reports[:0]
+	}
+
+	if errors.Is(readErr, io.EOF) {
+		return nil
+	}
+	return readErr
+}
+
+// importChunk сохраняет пачку записей в одной транзакции. Если транзакция
+// откатилась, записи повторяются по одной, чтобы сохранить удачные и найти
+// неудачные. Возвращает ошибки записей.
+func (s *ImportService) importChunk(
+	ctx context.Context,
+	job *models.ImportJob,
+	records []importRecord,
+	dryCreated map[string]bool,
+) []models.ImportError {
+	if len(records) == 0 {
+		return nil
+	}
+
+	results, err := s.runImportTx(ctx, records, job.DryRun)
+	if err != nil && len(records) > 1 {
+		var reports []models.ImportError
+		for i := range records {
+			reports = append(reports, s.importChunk(ctx, job, records[i:i+1], dryCreated)...)
+		}
+		return reports
+	}
+
+	job.Processed += len(records)
+	if err != nil {
+		job.Failed++
+		return []models.ImportError{{Line: records[0].line, ExternalID: records[0].news.ExternalID, Error: err.Error()}}
+	}
+
+	var effects bulkEffects
+	for i, result := range results {
+		externalID := records[i].news.ExternalID
+		switch {
+		case result.created && dryCreated[externalID]:
+			job.Updated++
+		case result.created:
+			job.Created++
+			if job.DryRun {
+				dryCreated[externalID] = true
+			}
+		default:
+			job.Updated++
+		}
+		effects.changed = append(effects.changed, strconv.FormatInt(result.newsID, 10))
+	}
+	if !job.DryRun {
+		s.news.applyBulkEffects(ctx, effects)
+	}
+	return nil
+}
+
+type importResult struct {
+	newsID  int64
+	created bool
+}
+
+// runImportTx создаёт или обновляет записи в одной транзакции. При пробном
+// импорте транзакция всегда откатывается, так что ошибки базы (например,
+// несуществующие медиафайлы) находятся так же, как при настоящем.
+func (s *ImportService) runImportTx(ctx context.Context, records []importRecord, dryRun bool) ([]importResult, error) {
+	results := make([]importResult, len(records))
+
+	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		externalIDs := make([]string, len(records))
+		for i, record := range records {
+			externalIDs[i] = record.news.ExternalID
+		}
+		newsIDs, err := s.repo.NewsIDsByExternalID(ctx, externalIDs)
+		if err != nil {
+			return err
+		}
+
+		for i, record := range records {
+			results[i], err = s.importRecord(ctx, record.news, newsIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if dryRun {
+			return errDryRun
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errDryRun) {
+		return nil, err
+	}
+	return results, nil
+}
+
+// importRecord создаёт новость или обновляет уже связанную с внешним id.
+// Вызывается внутри транзакции; newsIDs пополняется созданными новостями, чтобы
+// повтор внешнего id в том же файле обновил созданную новость.
+func (s *ImportService) importRecord(
+	ctx context.Context,
+	record dto.ImportNewsRecord,
+	newsIDs map[string]int64,
+) (importResult, error) {
+	blocks, err := s.news.buildContentBlocks(ctx, record.Content)
+	if err != nil {
+		return importResult{}, err
+	}
+
+	if newsID, ok := newsIDs[record.ExternalID]; ok {
+		_, err := s.news.updateNews(ctx, newsID, dto.UpdateNewsRequest{
+			ID:        strconv.FormatInt(newsID, 10),
+			Title:     record.Title,
+			Category:  record.Category,
+			Live:      &record.Live,
+			StartTime: &record.StartTime,
+			EndTime:   &record.EndTime,
+		}, blocks)
+		if err != nil {
+			return importResult{}, err
+		}
+		return importResult{newsID: newsID}, nil
+	}
+
+	news, err := s.news.createNews(ctx, record.CreateNewsRequest, blocks)
+	if err != nil {
+		return importResult{}, err
+	}
+	if err := s.repo.LinkExternalID(ctx, record.ExternalID, news.ID); err != nil {
+		return importResult{}, err
+	}
+	newsIDs[record.ExternalID] = news.ID
+
+	return importResult{newsID: news.ID, created: true}, nil
+}
+
+// saveProgress сохраняет счётчики задачи и новые ошибки записей. В отчёт
+// попадают только первые maxErrors ошибок.
+func (s *ImportService) saveProgress(ctx context.Context, job *models.ImportJob, reports []models.ImportError) error {
+	stored := job.Failed - len(reports)
+	if room := s.maxErrors - stored; room < len(reports) {
+		reports = reports[:max(room, 0)]
+	}
+
+	return s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateJob(ctx, job); err != nil {
+			return err
+		}
+		return s.repo.AddErrors(ctx, job.ID, reports)
+	})
+}
+
+// finishJob записывает итоговый статус. Контекст может быть уже отменён при
+// остановке сервиса, поэтому статус сохраняется без его отмены.
+func (s *ImportService) finishJob(ctx context.Context, job *models.ImportJob, err error) {
+	const op = "service.ImportService.finishJob"
+
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = models.ImportSucceeded
+	if err != nil {
+		job.Status = models.ImportFailed
+		job.Error = err.Error()
+	}
+
+	if err := s.saveProgress(context.WithoutCancel(ctx), job, nil); err != nil {
+		logger.Log.Error(op, "Failed to save import job status", err, "jobID", job.ID)
+		return
+	}
+	logger.Log.Info(op, "Import job finished", job.ID,
+		"status", job.Status, "created", job.Created, "updated", job.Updated, "failed", job.Failed)
+}
+
+// countingReader считает прочитанные байты для прогресса задачи.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
+
+func importJobToResponse(job *models.ImportJob, importErrors []models.ImportError) *dto.ImportJobResponse {
+	resp := &dto.ImportJobResponse{
+		ID:         strconv.FormatInt(job.ID, 10),
+		Format:     job.Format,
+		DryRun:     job.DryRun,
+		Status:     string(job.Status),
+		BytesTotal: job.BytesTotal,
+		BytesRead:  job.BytesRead,
+		Processed:  job.Processed,
+		Created:    job.Created,
+		Updated:    job.Updated,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		Errors:     make([]dto.ImportErrorResponse, len(importErrors)),
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+	for i, importErr := range importErrors {
+		resp.Errors[i] = dto.ImportErrorResponse{
+			Line:       importErr.Line,
+			ExternalID: importErr.ExternalID,
+			Error:      importErr.Error,
+		}
+	}
+	return resp
+}
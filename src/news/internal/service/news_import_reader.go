@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// importRecord — запись файла импорта и номер строки, с которой она начинается.
+type importRecord struct {
+	line int
+	news dto.ImportNewsRecord
+}
+
+// recordError — ошибка одной записи: она попадает в отчёт, а чтение файла продолжается.
+type recordError struct {
+	line       int
+	externalID string
+	err        error
+}
+
+func (e *recordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.line, e.err)
+}
+
+// recordReader читает записи файла по одной. Next возвращает *recordError для
+// записи, которую нельзя разобрать, io.EOF в конце файла и любую другую ошибку,
+// если читать файл дальше нельзя.
+type recordReader interface {
+	Next() (importRecord, error)
+}
+
+func newRecordReader(format string, r io.Reader, maxRecordSize int) (recordReader, error) {
+	switch format {
+	case dto.ArchiveFormatNDJSON:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 0, 64*1024), maxRecordSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	case dto.ArchiveFormatCSV:
+		return newCSVReader(r)
+	default:
+		return nil, fmt.Errorf("unknown import format %q", format)
+	}
+}
+
+type ndjsonReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *ndjsonReader) Next() (importRecord, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := r.scanner.Bytes()
+		if len(strings.TrimSpace(string(data))) == 0 {
+			continue
+		}
+
+		record := importRecord{line: r.line}
+		if err := json.Unmarshal(data, &record.news); err != nil {
+			return record, &recordError{line: r.line, err: fmt.Errorf("invalid json: %w", err)}
+		}
+		return record, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return importRecord{}, fmt.Errorf("line %d: record exceeds max record size", r.line+1)
+		}
+		return importRecord{}, err
+	}
+	return importRecord{}, io.EOF
+}
+
+// csvRequiredColumns — колонки, без которых CSV не принимается. live и content
+// необязательны, остальные колонки (например, id из выгрузки) игнорируются.
+var csvRequiredColumns = []string{"external_id", "title", "category", "start_time", "end_time"}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv: missing header")
+		}
+		return nil, fmt.Errorf("csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvRequiredColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("csv header: missing column %q", name)
+		}
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Next() (importRecord, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return importRecord{}, &recordError{line: parseErr.StartLine, err: parseErr.Err}
+		}
+		return importRecord{}, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return fields[i]
+	}
+
+	record := importRecord{line: line}
+	news := &record.news
+	news.ExternalID = field("external_id")
+	news.Title = field("title")
+	news.Category = field("category")
+
+	fail := func(err error) (importRecord, error) {
+		return record, &recordError{line: line, externalID: news.ExternalID, err: err}
+	}
+
+	if value := field("live"); value != "" {
+		if news.Live, err = strconv.ParseBool(value); err != nil {
+			return fail(fmt.Errorf("invalid live %q", value))
+		}
+	}
+	if news.StartTime, err = parseImportTime(field("start_time")); err != nil {
+		return fail(fmt.Errorf("invalid start_time: %w", err))
+	}
+	if news.EndTime, err = parseImportTime(field("end_time")); err != nil {
+		return fail(fmt.Errorf("invalid end_time: %w", err))
+	}
+	if news.Content, err = parseCSVContent(field("content")); err != nil {
+		return fail(err)
+	}
+
+	return record, nil
+}
+
+func parseImportTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// parseCSVContent разбирает колонку content: JSON-массив блоков передаётся как
+// есть, обычный текст делится на блоки по пустым строкам — абзац из одной
+// http(s)-ссылки становится блоком link, остальные — блоками text.
+func parseCSVContent(value string) ([]dto.CreateContentBlock, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return []dto.CreateContentBlock{}, nil
+	}
+
+	if strings.HasPrefix(value, "[") {
+		var blocks []dto.CreateContentBlock
+		if err := json.Unmarshal([]byte(value), &blocks); err != nil {
+			return nil, fmt.Errorf("invalid content json: %w", err)
+		}
+		return blocks, nil
+	}
+
+	paragraphs := strings.Split(strings.ReplaceAll(value, "\r\n", "\n"), "\n\n")
+	blocks := make([]dto.CreateContentBlock, 0, len(paragraphs))
+	for _, paragraph := range paragraphs {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+
+		blockType := string(models.TextBlock)
+		if isLinkParagraph(paragraph) {
+			blockType = string(models.LinkBlock)
+		}
+		blocks = append(blocks, dto.CreateContentBlock{
+			Type:     blockType,
+			Content:  paragraph,
+			Position: len(blocks) + 1,
+		})
+	}
+	return blocks, nil
+}
+
+func isLinkParagraph(paragraph string) bool {
+	return !strings.ContainsAny(paragraph, " \t\n") &&
+		(strings.HasPrefix(paragraph, "http://") || strings.HasPrefix(paragraph, "https://"))
+}
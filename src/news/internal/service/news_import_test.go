@@ -0,0 +1,339 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeImportRepo хранит задачи и связи внешних id в памяти. Задачи защищены
+// мьютексом: их читает тест, пока импорт идёт в фоне.
+type fakeImportRepo struct {
+	mu     sync.Mutex
+	jobs   map[int64]*models.ImportJob
+	data   map[int64][]byte
+	errors map[int64][]models.ImportError
+	links  map[string]int64
+}
+
+func newFakeImportRepo() *fakeImportRepo {
+	return &fakeImportRepo{
+		jobs:   map[int64]*models.ImportJob{},
+		data:   map[int64][]byte{},
+		errors: map[int64][]models.ImportError{},
+		links:  map[string]int64{},
+	}
+}
+
+func (r *fakeImportRepo) CreateJob(ctx context.Context, job *models.ImportJob, data io.Reader) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job.ID = int64(len(r.jobs) + 1)
+	if data != nil {
+		file, err := io.ReadAll(data)
+		if err != nil {
+			return err
+		}
+		r.data[job.ID] = file
+		job.BytesTotal = int64(len(file))
+	}
+	copied := *job
+	r.jobs[job.ID] = &copied
+	return nil
+}
+
+func (r *fakeImportRepo) GetJobData(ctx context.Context, id int64) (io.Reader, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.data[id]
+	if !ok {
+		return nil, postgres.ErrNotFound
+	}
+	return bytes.NewReader(data), nil
+}
+
+func (r *fakeImportRepo) ClearErrors(ctx context.Context, jobID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.errors, jobID)
+	return nil
+}
+
+func (r *fakeImportRepo) UpdateJob(ctx context.Context, job *models.ImportJob) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.jobs[job.ID]; !ok {
+		return postgres.ErrNotFound
+	}
+	copied := *job
+	r.jobs[job.ID] = &copied
+	if job.Status == models.ImportSucceeded {
+		delete(r.data, job.ID)
+	}
+	return nil
+}
+
+func (r *fakeImportRepo) AddErrors(ctx context.Context, jobID int64, importErrors []models.ImportError) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors[jobID] = append(r.errors[jobID], importErrors...)
+	return nil
+}
+
+func (r *fakeImportRepo) GetJob(ctx context.Context, id int64) (*models.ImportJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	job, ok := r.jobs[id]
+	if !ok {
+		return nil, postgres.ErrNotFound
+	}
+	copied := *job
+	return &copied, nil
+}
+
+func (r *fakeImportRepo) ListErrors(ctx context.Context, jobID int64, limit int) ([]models.ImportError, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errors[jobID][:min(limit, len(r.errors[jobID]))], nil
+}
+
+func (r *fakeImportRepo) NewsIDsByExternalID(ctx context.Context, externalIDs []string) (map[string]int64, error) {
+	out := map[string]int64{}
+	for _, id := range externalIDs {
+		if newsID, ok := r.links[id]; ok {
+			out[id] = newsID
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeImportRepo) LinkExternalID(ctx context.Context, externalID string, newsID int64) error {
+	r.links[externalID] = newsID
+	return nil
+}
+
+// importTxManager вдобавок к новостям откатывает связи внешних id.
+type importTxManager struct {
+	snapshotTxManager
+	imports *fakeImportRepo
+}
+
+func (m *importTxManager) RunReadCommited(ctx context.Context, f func(context.Context) error) error {
+	links := maps.Clone(m.imports.links)
+	if err := m.snapshotTxManager.RunReadCommited(ctx, f); err != nil {
+		m.imports.links = links
+		return err
+	}
+	return nil
+}
+
+// fakeEnqueuer запоминает поставленные задачи вместо очереди.
+type fakeEnqueuer struct {
+	jobs []*models.Job
+}
+
+func (e *fakeEnqueuer) Enqueue(ctx context.Context, kind string, payload any, runAt time.Time) (*models.Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	job := &models.Job{ID: int64(len(e.jobs) + 1), Kind: kind, Payload: data, MaxAttempts: 2, RunAt: runAt}
+	e.jobs = append(e.jobs, job)
+	return job, nil
+}
+
+func TestImportService_ImportNews(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*service.ImportService, *fakeBulkRepo, *fakeImportRepo, *fakeEnqueuer) {
+		repo := &fakeBulkRepo{news: map[int64]models.News{
+			1: {ID: 1, Title: "Existing", Slug: "existing", Category: "Tech"},
+		}, nextID: 1}
+		imports := newFakeImportRepo()
+		imports.links["legacy-1"] = 1
+		txManager := &importTxManager{snapshotTxManager: snapshotTxManager{repo: repo}, imports: imports}
+		redis, _ := newFakeRedis(t)
+		news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, txManager, redis, time.Minute)
+		queue := &fakeEnqueuer{}
+		importService := service.NewImportService(news, imports, txManager, queue, config.ImportConfig{ChunkSize: 3, MaxRecordSize: 1 << 20, MaxErrors: 100, MaxFileSize: 4 << 10})
+		return importService, repo, imports, queue
+	}
+
+	ndjson := strings.Join([]string{
+		`{"external_id":"a","title":"First import","category":"Tech","start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z","content":[{"type":"text","content":"Hi","position":1}]}`,
+		`{"external_id":"b",`,
+		``,
+		`{"external_id":"c","title":"No","category":"Tech","start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z","content":[]}`,
+		`{"external_id":"legacy-1","title":"Existing renamed","category":"Sport","start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z","content":[]}`,
+		`{"external_id":"d","title":"Broken image","category":"Tech","start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z","content":[{"type":"image","content":"Caption","position":1}]}`,
+		`{"external_id":"a","title":"First import v2","category":"Tech","start_time":"2025-01-01T00:00:00Z","end_time":"2026-01-01T00:00:00Z","content":[]}`,
+	}, "\n")
+
+	t.Run("ndjson upserts by external id and reports bad lines", func(t *testing.T) {
+		importService, repo, imports, _ := setup(t)
+
+		resp, err := importService.ImportNews(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(ndjson), int64(len(ndjson)))
+		require.NoError(t, err)
+
+		assert.Equal(t, string(models.ImportSucceeded), resp.Status)
+		assert.Equal(t, 6, resp.Processed)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 2, resp.Updated)
+		assert.Equal(t, 3, resp.Failed)
+		assert.Equal(t, int64(len(ndjson)), resp.BytesRead)
+		assert.NotNil(t, resp.FinishedAt)
+
+		lines := make([]int, len(resp.Errors))
+		for i, importErr := range resp.Errors {
+			lines[i] = importErr.Line
+		}
+		assert.Equal(t, []int{2, 4, 6}, lines)
+		assert.Equal(t, "d", resp.Errors[2].ExternalID)
+		assert.Contains(t, resp.Errors[2].Error, service.ErrInvalidMediaReference.Error())
+
+		require.Contains(t, imports.links, "a")
+		assert.NotContains(t, imports.links, "d")
+		assert.Equal(t, "First import v2", repo.news[imports.links["a"]].Title)
+		assert.Equal(t, "Existing renamed", repo.news[1].Title)
+		assert.Equal(t, "Sport", repo.news[1].Category)
+		assert.Len(t, repo.news, 2)
+	})
+
+	t.Run("dry run reports the same and saves nothing", func(t *testing.T) {
+		importService, repo, imports, _ := setup(t)
+
+		resp, err := importService.ImportNews(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON, DryRun: true}, strings.NewReader(ndjson), 0)
+		require.NoError(t, err)
+
+		assert.True(t, resp.DryRun)
+		assert.Equal(t, string(models.ImportSucceeded), resp.Status)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 2, resp.Updated)
+		assert.Equal(t, 3, resp.Failed)
+		assert.Len(t, repo.news, 1)
+		assert.Equal(t, "Existing", repo.news[1].Title)
+		assert.Equal(t, map[string]int64{"legacy-1": 1}, imports.links)
+	})
+
+	t.Run("csv splits content into blocks", func(t *testing.T) {
+		importService, repo, imports, _ := setup(t)
+
+		csv := "id,external_id,title,category,live,start_time,end_time,content\n" +
+			"9,x,CSV news,Tech,true,2025-01-01T00:00:00Z,2026-01-01T00:00:00Z,\"First paragraph\n\nhttps://example.com\"\n" +
+			"10,y,Bad time,Tech,false,yesterday,2026-01-01T00:00:00Z,text\n"
+
+		resp, err := importService.ImportNews(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatCSV}, strings.NewReader(csv), 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 1, resp.Failed)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, 5, resp.Errors[0].Line)
+		assert.Equal(t, "y", resp.Errors[0].ExternalID)
+
+		news := repo.news[imports.links["x"]]
+		assert.True(t, news.Live)
+		require.Len(t, news.Content, 2)
+		assert.Equal(t, models.TextBlock, news.Content[0].Type)
+		assert.Equal(t, models.LinkBlock, news.Content[1].Type)
+		assert.Equal(t, "https://example.com", news.Content[1].Content)
+	})
+
+	t.Run("csv without required columns fails the job", func(t *testing.T) {
+		importService, _, _, _ := setup(t)
+
+		resp, err := importService.ImportNews(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatCSV}, strings.NewReader("title\nHello\n"), 0)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(models.ImportFailed), resp.Status)
+		assert.Contains(t, resp.Error, `missing column "external_id"`)
+	})
+
+	t.Run("background import runs as a queued job", func(t *testing.T) {
+		importService, repo, imports, queue := setup(t)
+
+		resp, err := importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(ndjson), -1)
+		require.NoError(t, err)
+		assert.Equal(t, string(models.ImportPending), resp.Status)
+		assert.Equal(t, int64(len(ndjson)), resp.BytesTotal)
+
+		require.Len(t, queue.jobs, 1)
+		assert.Equal(t, service.JobKindNewsImport, queue.jobs[0].Kind)
+
+		queue.jobs[0].Attempts = 1
+		require.NoError(t, importService.RunImportJob(ctx, queue.jobs[0]))
+		// Повтор уже выполненной задачи ничего не импортирует второй раз.
+		queue.jobs[0].Attempts = 2
+		require.NoError(t, importService.RunImportJob(ctx, queue.jobs[0]))
+
+		job, err := importService.GetImportJob(ctx, dto.ImportJobIDRequest{ID: resp.ID})
+		require.NoError(t, err)
+		assert.Equal(t, string(models.ImportSucceeded), job.Status)
+		assert.Equal(t, 1, job.Created)
+		assert.Equal(t, 2, job.Updated)
+		assert.Equal(t, 3, job.Failed)
+		assert.Len(t, job.Errors, 3)
+		assert.Len(t, repo.news, 2)
+		assert.NotContains(t, imports.data, int64(1), "file is dropped after success")
+	})
+
+	t.Run("broken file fails the queued job without retries", func(t *testing.T) {
+		importService, _, _, queue := setup(t)
+
+		resp, err := importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatCSV}, strings.NewReader("title\nHello\n"), -1)
+		require.NoError(t, err)
+
+		queue.jobs[0].Attempts = 1
+		err = importService.RunImportJob(ctx, queue.jobs[0])
+		require.Error(t, err)
+
+		job, err := importService.GetImportJob(ctx, dto.ImportJobIDRequest{ID: resp.ID})
+		require.NoError(t, err)
+		assert.Equal(t, string(models.ImportFailed), job.Status)
+		assert.Contains(t, job.Error, `missing column "external_id"`)
+	})
+
+	t.Run("background import rejects oversized and empty files", func(t *testing.T) {
+		importService, _, _, queue := setup(t)
+
+		big := strings.Repeat(ndjson+"\n", 10)
+		_, err := importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(big), int64(len(big)))
+		assert.ErrorIs(t, err, service.ErrImportFileTooLarge)
+
+		// Без Content-Length предел проверяется по мере чтения.
+		_, err = importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(big), -1)
+		assert.ErrorIs(t, err, service.ErrImportFileTooLarge)
+
+		_, err = importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(""), -1)
+		assert.ErrorIs(t, err, service.ErrEmptyImportFile)
+		assert.Empty(t, queue.jobs)
+	})
+
+	t.Run("background import needs a job queue", func(t *testing.T) {
+		repo := &fakeBulkRepo{news: map[int64]models.News{}}
+		imports := newFakeImportRepo()
+		txManager := &importTxManager{snapshotTxManager: snapshotTxManager{repo: repo}, imports: imports}
+		redis, _ := newFakeRedis(t)
+		news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, txManager, redis, time.Minute)
+		importService := service.NewImportService(news, imports, txManager, nil, config.ImportConfig{})
+
+		_, err := importService.StartImport(ctx, dto.NewsImportRequest{Format: dto.ArchiveFormatNDJSON}, strings.NewReader(ndjson), -1)
+		assert.ErrorIs(t, err, service.ErrImportQueueUnavailable)
+	})
+}
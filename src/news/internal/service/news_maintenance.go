@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+const (
+	// JobKindNewsPurgeExpired удаляет новости, показ которых закончился больше
+	// older_than назад (по умолчанию — любые закончившиеся).
+	JobKindNewsPurgeExpired = "news.purge_expired"
+
+	// JobKindNewsReindex пересобирает кеш: сбрасывает новости, списки и ленты
+	// и прогревает до limit последних новостей (по умолчанию 200).
+	JobKindNewsReindex = "news.reindex"
+
+	defaultWarmLimit = 200
+)
+
+type purgeExpiredPayload struct {
+	OlderThan string `json:"older_than"`
+}
+
+type reindexPayload struct {
+	Limit int `json:"limit"`
+}
+
+// maintenanceBatchSize — сколько ключей или новостей обрабатывается за один запрос
+// при обслуживании кеша и удалении истёкших новостей.
+const maintenanceBatchSize = 100
+
+// cacheKeyPatterns — ключи кеша новостей, списков и лент. Поток событий outbox
+// тоже называется news:*, поэтому удаляются только строковые ключи.
+var cacheKeyPatterns = []string{"news:*", "feeds:*"}
+
+// FlushCache удаляет из Redis кеш новостей, списков и лент и возвращает число
+// удалённых ключей. Остальные данные в Redis не трогаются.
+func (s *NewsService) FlushCache(ctx context.Context) (int64, error) {
+	const op = "service.NewsService.FlushCache"
+
+	client := s.redis.GetRedis()
+
+	var deleted int64
+	for _, pattern := range cacheKeyPatterns {
+		iter := client.ScanType(ctx, 0, pattern, maintenanceBatchSize, "string").Iterator()
+		keys := make([]string, 0, maintenanceBatchSize)
+		for {
+			more := iter.Next(ctx)
+			if more {
+				keys = append(keys, iter.Val())
+			}
+			if len(keys) == maintenanceBatchSize || (!more && len(keys) > 0) {
+				n, err := client.Unlink(ctx, keys...).Result()
+				if err != nil {
+					return deleted, err
+				}
+				deleted += n
+				keys = keys[:0]
+			}
+			if !more {
+				break
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return deleted, err
+		}
+	}
+
+	n, err := client.Del(ctx, newsListCacheKeys, feedCacheKeys).Result()
+	if err != nil {
+		return deleted, err
+	}
+	deleted += n
+
+	logger.Log.Info(op, "Cache flushed", deleted)
+	return deleted, nil
+}
+
+// WarmCache кладёт в кеш до limit последних видимых новостей, чтобы после сброса
+// кеша или деплоя первые запросы не шли в базу. Возвращает число новостей в кеше.
+func (s *NewsService) WarmCache(ctx context.Context, limit int) (int, error) {
+	const op = "service.NewsService.WarmCache"
+
+	var (
+		warmed int
+		after  string
+	)
+	for warmed < limit {
+		page, err := s.ListNewsConnection(ctx, dto.NewsConnectionRequest{
+			First:           min(maintenanceBatchSize, limit-warmed),
+			After:           after,
+			SortBy:          "created_at",
+			SortDir:         "desc",
+			CheckVisibility: true,
+		})
+		if err != nil {
+			return warmed, err
+		}
+		if len(page.Items) == 0 {
+			break
+		}
+
+		ids := make([]string, len(page.Items))
+		for i, item := range page.Items {
+			ids[i] = item.ID
+		}
+		batch, err := s.GetNewsBatch(ctx, dto.NewsBatchRequest{IDs: ids})
+		if err != nil {
+			return warmed, err
+		}
+		for _, item := range batch.Items {
+			if item.Found {
+				warmed++
+			}
+		}
+
+		if !page.HasNextPage {
+			break
+		}
+		after = page.EndCursor
+	}
+
+	logger.Log.Info(op, "Cache warmed", warmed)
+	return warmed, nil
+}
+
+// PurgeExpired удаляет новости, показ которых закончился раньше before, пачками
+// по одной транзакции. Для каждой новости, как и при обычном удалении, пишется
+// событие news.deleted. С dryRun только считает такие новости.
+func (s *NewsService) PurgeExpired(ctx context.Context, before time.Time, dryRun bool) (int, error) {
+	const op = "service.NewsService.PurgeExpired"
+
+	var (
+		purged  int
+		afterID int64
+	)
+	for {
+		ids, err := s.newsRepo.ExpiredIDs(ctx, before, afterID, maintenanceBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(ids) == 0 {
+			break
+		}
+		afterID = ids[len(ids)-1]
+		if dryRun {
+			purged += len(ids)
+			continue
+		}
+
+		err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+			for _, id := range ids {
+				// Новость могли удалить между выборкой и транзакцией.
+				if err := s.deleteNews(ctx, id); err != nil && !errors.Is(err, postgres.ErrNotFound) {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return purged, err
+		}
+		purged += len(ids)
+
+		keys := make([]string, len(ids))
+		for i, id := range ids {
+			keys[i] = fmt.Sprintf("news:%d", id)
+		}
+		if err := s.redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+			logger.Log.Error(op, "Failed to invalidate cache", keys, "error", err)
+		}
+	}
+
+	if purged > 0 && !dryRun {
+		invalidateFeedCache(ctx, s.redis)
+		invalidateNewsListCache(ctx, s.redis)
+	}
+
+	logger.Log.Info(op, "Expired news purged", purged, "before", before, "dryRun", dryRun)
+	return purged, nil
+}
+
+// RunPurgeExpiredJob обрабатывает задачу news.purge_expired. Повтор после сбоя
+// безопасен: уже удалённые новости в выборку не попадают.
+func (s *NewsService) RunPurgeExpiredJob(ctx context.Context, job *models.Job) error {
+	var payload purgeExpiredPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	var olderThan time.Duration
+	if payload.OlderThan != "" {
+		var err error
+		if olderThan, err = time.ParseDuration(payload.OlderThan); err != nil {
+			return PermanentJobError(err)
+		}
+	}
+	if olderThan < 0 {
+		return PermanentJobError(errors.New("older_than must not be negative"))
+	}
+
+	_, err := s.PurgeExpired(ctx, time.Now().Add(-olderThan), false)
+	return err
+}
+
+// RunReindexJob обрабатывает задачу news.reindex.
+func (s *NewsService) RunReindexJob(ctx context.Context, job *models.Job) error {
+	var payload reindexPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return PermanentJobError(err)
+	}
+
+	limit := payload.Limit
+	if limit <= 0 {
+		limit = defaultWarmLimit
+	}
+
+	if _, err := s.FlushCache(ctx); err != nil {
+		return err
+	}
+	_, err := s.WarmCache(ctx, limit)
+	return err
+}
@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeExpiringRepo дополняет fakeBulkRepo выборкой истёкших новостей.
+type fakeExpiringRepo struct {
+	fakeBulkRepo
+}
+
+func (r *fakeExpiringRepo) ExpiredIDs(ctx context.Context, before time.Time, afterID int64, limit int) ([]int64, error) {
+	var ids []int64
+	for id, news := range r.news {
+		if news.EndTime.Before(before) && id > afterID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids[:min(limit, len(ids))], nil
+}
+
+func TestNewsService_FlushCache(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	redis, mr := newFakeRedis(t)
+	news := service.NewNewsService(&fakeBulkRepo{}, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, fakeTxManager{}, redis, time.Minute)
+
+	require.NoError(t, mr.Set("news:1", "{}"))
+	require.NoError(t, mr.Set("news:list:1:10", "{}"))
+	require.NoError(t, mr.Set("feeds:rss:", "<rss/>"))
+	_, err := mr.SAdd("news:list:keys", "news:list:1:10")
+	require.NoError(t, err)
+	_, err = mr.XAdd("news:events", "*", []string{"type", "news.created"})
+	require.NoError(t, err)
+	require.NoError(t, mr.Set("session:1", "keep"))
+
+	deleted, err := news.FlushCache(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), deleted)
+	assert.Equal(t, []string{"news:events", "session:1"}, mr.Keys(), "event stream and foreign keys survive")
+}
+
+func TestNewsService_PurgeExpired(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+	now := time.Now()
+
+	repo := &fakeExpiringRepo{fakeBulkRepo{news: map[int64]models.News{
+		1: {ID: 1, Title: "old", EndTime: now.Add(-48 * time.Hour)},
+		2: {ID: 2, Title: "recent", EndTime: now.Add(-time.Hour)},
+		3: {ID: 3, Title: "active", EndTime: now.Add(time.Hour)},
+	}, nextID: 3}}
+	outbox := &fakeOutbox{}
+	redis, mr := newFakeRedis(t)
+	news := service.NewNewsService(repo, &fakeMediaRepo{}, outbox, noopJobs{}, fakeTxManager{}, redis, time.Minute)
+	require.NoError(t, mr.Set("news:1", "{}"))
+
+	count, err := news.PurgeExpired(ctx, now.Add(-24*time.Hour), true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Len(t, repo.news, 3, "dry run deletes nothing")
+
+	require.NoError(t, news.RunPurgeExpiredJob(ctx, &models.Job{Payload: []byte(`{"older_than":"24h"}`)}))
+	assert.NotContains(t, repo.news, int64(1))
+	assert.Len(t, repo.news, 2)
+
+	count, err = news.PurgeExpired(ctx, now, false)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Contains(t, repo.news, int64(3))
+	assert.Len(t, repo.news, 1)
+	require.Len(t, outbox.events, 2)
+	assert.Equal(t, models.NewsDeleted, outbox.events[0].Type)
+	assert.False(t, mr.Exists("news:1"))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/zhavkk/news-service/src/news/internal/logger"
 	"github.com/zhavkk/news-service/src/news/internal/models"
 	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/slug"
 	"github.com/zhavkk/news-service/src/news/internal/storage"
 )
 
@@ -27,6 +28,11 @@ type NewsRepository interface {
 		id int64,
 	) (*models.News, error)
 
+	GetByIDs(
+		ctx context.Context,
+		ids []int64,
+	) ([]*models.News, error)
+
 	Update(
 		ctx context.Context,
 		news *models.News,
@@ -46,15 +52,83 @@ type NewsRepository interface {
 		sortBy string,
 		sortDir string,
 		checkVisibility bool,
+		fields []string,
+		withContent bool,
 	) ([]*models.News, int64, error)
+
+	ListAfter(
+		ctx context.Context,
+		filter models.NewsFilter,
+		after *models.NewsCursor,
+		limit int,
+		withContent bool,
+	) ([]*models.News, error)
+
+	Export(
+		ctx context.Context,
+		filter models.NewsFilter,
+		fetchSize int,
+		fn func(news *models.News) error,
+	) error
+
+	Count(
+		ctx context.Context,
+		filter models.NewsFilter,
+	) (int64, error)
+
+	Categories(
+		ctx context.Context,
+		checkVisibility bool,
+	) ([]models.CategoryCount, error)
+
+	ExpiredIDs(
+		ctx context.Context,
+		before time.Time,
+		afterID int64,
+		limit int,
+	) ([]int64, error)
+
+	LinkBlocks(
+		ctx context.Context,
+		afterID int64,
+		limit int,
+	) ([]models.ContentBlock, error)
+
+	SlugTaken(
+		ctx context.Context,
+		slug string,
+		exceptNewsID int64,
+	) (bool, error)
+
+	ResolveSlug(
+		ctx context.Context,
+		slug string,
+	) (int64, string, error)
+
+	MoveSlug(
+		ctx context.Context,
+		newsID int64,
+		oldSlug string,
+		newSlug string,
+	) error
 }
 
+// maxSlugAttempts — сколько суффиксов перебрать, прежде чем сделать slug уникальным по времени.
+const maxSlugAttempts = 100
+
+// maxSlugConflicts — сколько раз подобрать slug заново, если его заняла
+// параллельная транзакция между проверкой и записью.
+const maxSlugConflicts = 3
+
 type RedisClient interface {
 	GetRedis() *redis.Client
 }
 
 type NewsService struct {
 	newsRepo  NewsRepository
+	mediaRepo MediaRepository
+	outbox    OutboxWriter
+	jobs      JobEnqueuer
 	txManager storage.TxManagerInterface
 	redis     RedisClient
 	cacheTTL  time.Duration
@@ -62,12 +136,18 @@ type NewsService struct {
 
 func NewNewsService(
 	newsRepo NewsRepository,
+	mediaRepo MediaRepository,
+	outbox OutboxWriter,
+	jobs JobEnqueuer,
 	txManager storage.TxManagerInterface,
 	redis RedisClient,
 	cacheTTL time.Duration,
 ) *NewsService {
 	return &NewsService{
 		newsRepo:  newsRepo,
+		mediaRepo: mediaRepo,
+		outbox:    outbox,
+		jobs:      jobs,
 		txManager: txManager,
 		redis:     redis,
 		cacheTTL:  cacheTTL,
@@ -91,54 +171,37 @@ func (s *NewsService) CreateNews(
 ) (*dto.NewsResponse, error) {
 	const op = "service.NewsService.CreateNews"
 
-	var resp *dto.NewsResponse
-
-	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
-		news := &models.News{
-			Title:     req.Title,
-			Category:  req.Category,
-			StartTime: req.StartTime,
-			EndTime:   req.EndTime,
-		}
+	blocks, err := s.buildContentBlocks(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, block := range req.Content {
-			contentBlock := models.ContentBlock{
-				Type:     models.BlockType(block.Type),
-				Content:  block.Content,
-				Position: block.Position,
-			}
-			news.Content = append(news.Content, contentBlock)
-		}
+	var resp *dto.NewsResponse
 
-		if err := s.newsRepo.Create(ctx, news); err != nil {
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		news, err := s.createNews(ctx, req, blocks)
+		if err != nil {
 			return err
 		}
 
-		contentDTO := make([]dto.ContentBlockResponse, len(news.Content))
-		for i, block := range news.Content {
-			contentDTO[i] = dto.ContentBlockResponse{
-				ID:       strconv.FormatInt(block.ID, 10),
-				Type:     string(block.Type),
-				Content:  block.Content,
-				Position: block.Position,
-			}
-		}
 		logger.Log.Info(op, "News created successfully", news.ID)
 
-		resp = &dto.NewsResponse{
-			ID:        strconv.FormatInt(news.ID, 10),
-			Title:     news.Title,
-			Category:  news.Category,
-			StartTime: news.StartTime,
-			EndTime:   news.EndTime,
-			Content:   contentDTO,
+		variants, err := s.loadVariants(ctx, news)
+		if err != nil {
+			return err
 		}
 
+		newsResp := newsToResponse(news, variants)
+		resp = &newsResp
+
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	invalidateFeedCache(ctx, s.redis)
+	invalidateNewsListCache(ctx, s.redis)
+
 	return resp, nil
 
 }
@@ -182,62 +245,105 @@ func (s *NewsService) GetNewsByID(
 
 	var resp *dto.NewsResponse
 
-	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
-		news, err := s.newsRepo.GetByID(ctx, newsID)
-		if err != nil {
-			if errors.Is(err, postgres.ErrNotFound) {
-				return postgres.ErrNotFound
-			}
-			return err
-		}
+	err = s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+		resp, err = s.readNews(ctx, newsID, req.CheckVisibility)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		if req.CheckVisibility && !news.IsVisible() {
-			return postgres.ErrNotFound
-		}
+	s.cacheNews(ctx, cacheKey, resp)
+	return resp, nil
+}
 
-		contentDTO := make([]dto.ContentBlockResponse, len(news.Content))
-		for i, block := range news.Content {
-			contentDTO[i] = dto.ContentBlockResponse{
-				ID:       strconv.FormatInt(block.ID, 10),
-				Type:     string(block.Type),
-				Content:  block.Content,
-				Position: block.Position,
-			}
-		}
-		logger.Log.Info(op, "News retrieved successfully", news.ID)
+// readNews читает новость с блоками и вариантами медиа в транзакции из ctx.
+func (s *NewsService) readNews(ctx context.Context, newsID int64, checkVisibility bool) (*dto.NewsResponse, error) {
+	const op = "service.NewsService.readNews"
 
-		resp = &dto.NewsResponse{
-			ID:        strconv.FormatInt(news.ID, 10),
-			Title:     news.Title,
-			Category:  news.Category,
-			CreatedAt: news.CreatedAt,
-			StartTime: news.StartTime,
-			EndTime:   news.EndTime,
-			Content:   contentDTO,
+	news, err := s.newsRepo.GetByID(ctx, newsID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return nil, postgres.ErrNotFound
 		}
+		return nil, err
+	}
+
+	if checkVisibility && !news.IsVisible() {
+		return nil, postgres.ErrNotFound
+	}
+
+	logger.Log.Info(op, "News retrieved successfully", news.ID)
+
+	variants, err := s.loadVariants(ctx, news)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := newsToResponse(news, variants)
+	return &resp, nil
+}
+
+// cacheNews кладёт ответ в кеш уже после транзакции, чтобы повтор транзакции
+// не трогал Redis.
+func (s *NewsService) cacheNews(ctx context.Context, cacheKey string, resp *dto.NewsResponse) {
+	const op = "service.NewsService.cacheNews"
 
-		toCache, err := json.Marshal(resp)
+	toCache, err := json.Marshal(resp)
+	if err != nil {
+		logger.Log.Error(op, "Failed to marshal news for caching", err)
+		return
+	}
+	if err := s.redis.GetRedis().Set(ctx, cacheKey, toCache, s.cacheTTL).Err(); err != nil {
+		logger.Log.Error(op, "Failed to set cache", cacheKey, "error", err)
+	}
+}
+
+// GetNewsBySlug godoc
+// @Summary      Get a news item by slug
+// @Description  Retrieves a news item by its human-readable slug. Old slugs redirect to the current one with 301.
+// @Tags         news
+// @Produce      json
+// @Param        slug              path      string  true  "News slug"
+// @Param        check_visibility  query     bool    false "Check visibility (start/end time)" default(true)
+// @Success      200               {object}  dto.NewsResponse
+// @Success      301               "Slug has changed, see Location header"
+// @Failure      400               {object}  dto.ErrorResponse
+// @Failure      404               {object}  dto.ErrorResponse
+// @Failure      500               {object}  dto.ErrorResponse
+// @Router       /news/by-slug/{slug} [get]
+func (s *NewsService) GetNewsBySlug(
+	ctx context.Context,
+	req dto.GetNewsBySlugRequest,
+) (*dto.NewsResponse, error) {
+	var resp *dto.NewsResponse
+
+	// Slug и новость читаются из одного снимка: между ними новость не могут
+	// переименовать или удалить.
+	err := s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
+		newsID, currentSlug, err := s.newsRepo.ResolveSlug(ctx, req.Slug)
 		if err != nil {
-			logger.Log.Error(op, "Failed to marshal news for caching", err)
-		} else {
-			if err := s.redis.GetRedis().Set(ctx, cacheKey, toCache, s.cacheTTL).Err(); err != nil {
-				logger.Log.Error(op, "Failed to set cache", cacheKey, "error", err)
-			}
+			return err
 		}
 
-		return nil
-	})
+		if currentSlug != req.Slug {
+			return &SlugMovedError{CurrentSlug: currentSlug}
+		}
 
+		resp, err = s.readNews(ctx, newsID, req.CheckVisibility)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	s.cacheNews(ctx, fmt.Sprintf("news:%s", resp.ID), resp)
 	return resp, nil
 }
 
 // ListNews godoc
 // @Summary      Get a list of news
-// @Description  Retrieves a list of news items with pagination, filtering, and sorting
+// @Description  Retrieves a list of news items with pagination, filtering, and sorting. With fields= only the listed fields are returned and content blocks are loaded only if requested.
 // @Tags         news
 // @Produce      json
 // @Param        page              query     int     false "Page number for pagination" default(1)
@@ -247,6 +353,8 @@ func (s *NewsService) GetNewsByID(
 // @Param        sort_by           query     string  false "Field to sort by" Enums(created_at, start_time, end_time, title, category) default(created_at)
 // @Param        sort_dir          query     string  false "Sort direction" Enums(asc, desc) default(desc)
 // @Param        check_visibility  query     bool    false "Check visibility (start/end time)" default(true)
+// @Param        fields            query     string  false "Comma-separated fields to return (id, title, slug, category, live, content, created_at, updated_at, start_time, end_time), all by default"
+// @Param        include           query     string  false "Add content blocks to the selected fields" Enums(content)
 // @Success      200               {object}  dto.NewsListResponse
 // @Failure      400               {object}  dto.ErrorResponse
 // @Failure      500               {object}  dto.ErrorResponse
@@ -257,11 +365,29 @@ func (s *NewsService) ListNews(
 ) (*dto.NewsListResponse, error) {
 	const op = "service.NewsService.ListNews"
 
+	fields, withContent, err := newsFieldset(req)
+	if err != nil {
+		return nil, err
+	}
+
+	cacheKey := newsListCacheKey(req, fields)
+
+	cached, err := s.redis.GetRedis().Get(ctx, cacheKey).Bytes()
+	if err == nil {
+		var resp dto.NewsListResponse
+		if err := json.Unmarshal(cached, &resp); err == nil {
+			logger.Log.Debug(op, "Cache hit for news list", cacheKey)
+			resp.Fields = fields
+			return &resp, nil
+		}
+		logger.Log.Error(op, "Failed to unmarshal cached news list", err)
+	}
+
 	offset := (req.Page - 1) * req.Limit
 
 	var resp *dto.NewsListResponse
 
-	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+	err = s.txManager.RunReadOnly(ctx, func(ctx context.Context) error {
 		newsList, totalCount, err := s.newsRepo.List(
 			ctx,
 			offset,
@@ -271,37 +397,31 @@ func (s *NewsService) ListNews(
 			req.SortBy,
 			req.SortDir,
 			req.CheckVisibility,
+			fields,
+			withContent,
 		)
 		if err != nil {
 			return err
 		}
 
+		var variants map[int64][]models.MediaVariant
+		if withContent {
+			variants, err = s.loadVariants(ctx, newsList...)
+			if err != nil {
+				return err
+			}
+		}
+
 		items := make([]dto.NewsResponse, 0, len(newsList))
 
 		for _, news := range newsList {
-			if req.CheckVisibility && !news.IsVisible() {
+			// Урезанный набор полей может не содержать start_time/end_time,
+			// видимость в этом случае уже проверена условием запроса.
+			if req.CheckVisibility && fields == nil && !news.IsVisible() {
 				continue
 			}
 
-			contentDTO := make([]dto.ContentBlockResponse, len(news.Content))
-			for i, block := range news.Content {
-				contentDTO[i] = dto.ContentBlockResponse{
-					ID:       strconv.FormatInt(block.ID, 10),
-					Type:     string(block.Type),
-					Content:  block.Content,
-					Position: block.Position,
-				}
-			}
-
-			items = append(items, dto.NewsResponse{
-				ID:        strconv.FormatInt(news.ID, 10),
-				Title:     news.Title,
-				Category:  news.Category,
-				CreatedAt: news.CreatedAt,
-				StartTime: news.StartTime,
-				EndTime:   news.EndTime,
-				Content:   contentDTO,
-			})
+			items = append(items, newsToResponse(news, variants))
 		}
 		logger.Log.Info(op, "News list retrieved successfully, total count: ", totalCount)
 		resp = &dto.NewsListResponse{
@@ -309,6 +429,7 @@ func (s *NewsService) ListNews(
 			TotalCount: totalCount,
 			Page:       req.Page,
 			Limit:      req.Limit,
+			Fields:     fields,
 		}
 
 		return nil
@@ -318,6 +439,19 @@ func (s *NewsService) ListNews(
 		return nil, err
 	}
 
+	toCache, err := json.Marshal(resp)
+	if err != nil {
+		logger.Log.Error(op, "Failed to marshal news list for caching", err)
+		return resp, nil
+	}
+
+	pipe := s.redis.GetRedis().TxPipeline()
+	pipe.Set(ctx, cacheKey, toCache, s.cacheTTL)
+	pipe.SAdd(ctx, newsListCacheKeys, cacheKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Log.Error(op, "Failed to set cache", cacheKey, "error", err)
+	}
+
 	return resp, nil
 }
 
@@ -346,43 +480,16 @@ func (s *NewsService) UpdateNews(
 		return nil, err
 	}
 
+	blocks, err := s.buildContentBlocks(ctx, req.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp *dto.UpdateNewsResponse
 
 	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
-		news, err := s.newsRepo.GetByID(ctx, newsID)
+		news, err := s.updateNews(ctx, newsID, req, blocks)
 		if err != nil {
-			if errors.Is(err, postgres.ErrNotFound) {
-				return postgres.ErrNotFound
-			}
-			return err
-		}
-
-		if req.Title != "" {
-			news.Title = req.Title
-		}
-		if req.Category != "" {
-			news.Category = req.Category
-		}
-		if req.StartTime != nil {
-			news.StartTime = *req.StartTime
-		}
-		if req.EndTime != nil {
-			news.EndTime = *req.EndTime
-		}
-
-		if len(req.Content) > 0 {
-			news.Content = make([]models.ContentBlock, len(req.Content))
-			for i, block := range req.Content {
-				contentBlock := models.ContentBlock{
-					Type:     models.BlockType(block.Type),
-					Content:  block.Content,
-					Position: block.Position,
-				}
-				news.Content[i] = contentBlock
-			}
-		}
-
-		if err := s.newsRepo.Update(ctx, news); err != nil {
 			return err
 		}
 
@@ -406,6 +513,8 @@ func (s *NewsService) UpdateNews(
 	if err := s.redis.GetRedis().Del(ctx, cacheKey).Err(); err != nil {
 		logger.Log.Error(op, "Failed to invalidate cache", cacheKey, "error", err)
 	}
+	invalidateFeedCache(ctx, s.redis)
+	invalidateNewsListCache(ctx, s.redis)
 
 	return resp, nil
 }
@@ -436,10 +545,7 @@ func (s *NewsService) DeleteNews(
 	var resp *dto.DeleteNewsResponse
 
 	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
-		if err := s.newsRepo.Delete(ctx, newsID); err != nil {
-			if errors.Is(err, postgres.ErrNotFound) {
-				return postgres.ErrNotFound
-			}
+		if err := s.deleteNews(ctx, newsID); err != nil {
 			return err
 		}
 
@@ -459,6 +565,303 @@ func (s *NewsService) DeleteNews(
 	if err := s.redis.GetRedis().Del(ctx, cacheKey).Err(); err != nil {
 		logger.Log.Error(op, "Failed to invalidate cache", cacheKey, "error", err)
 	}
+	invalidateFeedCache(ctx, s.redis)
+	invalidateNewsListCache(ctx, s.redis)
 
 	return resp, nil
 }
+
+// createNews создаёт новость с уникальным slug, пишет событие в outbox и ставит
+// генерацию вариантов изображений. Вызывается внутри транзакции.
+func (s *NewsService) createNews(
+	ctx context.Context,
+	req dto.CreateNewsRequest,
+	blocks []models.ContentBlock,
+) (*models.News, error) {
+	news := &models.News{
+		Title:     req.Title,
+		Category:  req.Category,
+		Live:      req.Live,
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+		Content:   blocks,
+	}
+
+	err := s.saveWithSlug(ctx, req.Title, 0, func(ctx context.Context, newsSlug string) error {
+		news.Slug = newsSlug
+		return s.newsRepo.Create(ctx, news)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	event := models.NewNewsEvent(models.NewsCreated, news)
+	if err := s.outbox.Add(ctx, &event); err != nil {
+		return nil, err
+	}
+	if err := enqueueVariants(ctx, s.jobs, blocks); err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+// updateNews применяет заполненные поля запроса к новости, переносит slug при смене
+// заголовка, пишет событие в outbox и ставит генерацию вариантов изображений.
+// Вызывается внутри транзакции.
+func (s *NewsService) updateNews(
+	ctx context.Context,
+	newsID int64,
+	req dto.UpdateNewsRequest,
+	blocks []models.ContentBlock,
+) (*models.News, error) {
+	news, err := s.newsRepo.GetByID(ctx, newsID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return nil, postgres.ErrNotFound
+		}
+		return nil, err
+	}
+
+	titleChanged := req.Title != "" && req.Title != news.Title
+	if titleChanged {
+		news.Title = req.Title
+	}
+	if req.Category != "" {
+		news.Category = req.Category
+	}
+	if req.Live != nil {
+		news.Live = *req.Live
+	}
+	if req.StartTime != nil {
+		news.StartTime = *req.StartTime
+	}
+	if req.EndTime != nil {
+		news.EndTime = *req.EndTime
+	}
+
+	if len(blocks) > 0 {
+		news.Content = blocks
+	}
+
+	if titleChanged {
+		oldSlug := news.Slug
+		err = s.saveWithSlug(ctx, news.Title, news.ID, func(ctx context.Context, newSlug string) error {
+			if newSlug != oldSlug {
+				if err := s.newsRepo.MoveSlug(ctx, news.ID, oldSlug, newSlug); err != nil {
+					return err
+				}
+			}
+			news.Slug = newSlug
+			return s.newsRepo.Update(ctx, news)
+		})
+	} else {
+		err = s.newsRepo.Update(ctx, news)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	event := models.NewNewsEvent(models.NewsUpdated, news)
+	if err := s.outbox.Add(ctx, &event); err != nil {
+		return nil, err
+	}
+	if err := enqueueVariants(ctx, s.jobs, blocks); err != nil {
+		return nil, err
+	}
+
+	return news, nil
+}
+
+// deleteNews удаляет новость и пишет событие в outbox. Вызывается внутри транзакции.
+func (s *NewsService) deleteNews(ctx context.Context, newsID int64) error {
+	news, err := s.newsRepo.GetByID(ctx, newsID)
+	if err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return postgres.ErrNotFound
+		}
+		return err
+	}
+
+	if err := s.newsRepo.Delete(ctx, newsID); err != nil {
+		if errors.Is(err, postgres.ErrNotFound) {
+			return postgres.ErrNotFound
+		}
+		return err
+	}
+
+	event := models.NewNewsEvent(models.NewsDeleted, news)
+	return s.outbox.Add(ctx, &event)
+}
+
+// buildContentBlocks переводит блоки из запроса в модели и проверяет,
+// что блоки-изображения ссылаются на существующие медиафайлы.
+func (s *NewsService) buildContentBlocks(
+	ctx context.Context,
+	reqBlocks []dto.CreateContentBlock,
+) ([]models.ContentBlock, error) {
+	return buildContentBlocks(ctx, s.mediaRepo, reqBlocks)
+}
+
+// buildContentBlocks переводит блоки запроса в модели и проверяет, что блоки
+// изображений ссылаются на существующие медиафайлы.
+func buildContentBlocks(
+	ctx context.Context,
+	mediaRepo MediaRepository,
+	reqBlocks []dto.CreateContentBlock,
+) ([]models.ContentBlock, error) {
+	blocks := make([]models.ContentBlock, 0, len(reqBlocks))
+	mediaIDs := make([]int64, 0)
+
+	for _, block := range reqBlocks {
+		contentBlock := models.ContentBlock{
+			Type:     models.BlockType(block.Type),
+			Content:  block.Content,
+			Position: block.Position,
+		}
+
+		switch {
+		case contentBlock.Type == models.ImageBlock && block.MediaID == "":
+			return nil, fmt.Errorf("%w: image block at position %d has no media_id", ErrInvalidMediaReference, block.Position)
+		case contentBlock.Type != models.ImageBlock && block.MediaID != "":
+			return nil, fmt.Errorf("%w: %s block at position %d cannot reference media", ErrInvalidMediaReference, block.Type, block.Position)
+		case block.MediaID != "":
+			mediaID, err := strconv.ParseInt(block.MediaID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidMediaReference, block.MediaID)
+			}
+			contentBlock.MediaID = &mediaID
+			mediaIDs = append(mediaIDs, mediaID)
+		}
+
+		blocks = append(blocks, contentBlock)
+	}
+
+	if len(mediaIDs) == 0 {
+		return blocks, nil
+	}
+
+	existing, err := mediaRepo.ExistingIDs(ctx, mediaIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range mediaIDs {
+		if _, ok := existing[id]; !ok {
+			return nil, fmt.Errorf("%w: media %d does not exist", ErrInvalidMediaReference, id)
+		}
+	}
+
+	return blocks, nil
+}
+
+// uniqueSlug строит slug из заголовка и при конфликте добавляет числовой суффикс.
+// Должен вызываться внутри транзакции.
+func (s *NewsService) uniqueSlug(ctx context.Context, title string, newsID int64) (string, error) {
+	base := slug.Make(title)
+
+	candidate := base
+	for i := 2; i <= maxSlugAttempts; i++ {
+		taken, err := s.newsRepo.SlugTaken(ctx, candidate, newsID)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	return fmt.Sprintf("%s-%d", base, time.Now().UnixNano()), nil
+}
+
+// saveWithSlug подбирает свободный slug и выполняет save под SAVEPOINT. Между
+// проверкой и записью slug может занять параллельная транзакция — тогда запись
+// упирается в уникальный индекс, savepoint откатывается и slug подбирается
+// заново: после коммита соперника проверка уже видит его slug.
+func (s *NewsService) saveWithSlug(
+	ctx context.Context,
+	title string,
+	newsID int64,
+	save func(ctx context.Context, newsSlug string) error,
+) error {
+	const op = "service.NewsService.saveWithSlug"
+
+	for attempt := 1; ; attempt++ {
+		newsSlug, err := s.uniqueSlug(ctx, title, newsID)
+		if err != nil {
+			return err
+		}
+
+		err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+			return save(ctx, newsSlug)
+		})
+		if !errors.Is(err, postgres.ErrSlugTaken) || attempt >= maxSlugConflicts {
+			return err
+		}
+		logger.Log.Debug(op, "Slug taken concurrently, picking another", newsSlug, "attempt", attempt)
+	}
+}
+
+// loadVariants загружает варианты изображений для всех блоков-картинок новостей.
+func (s *NewsService) loadVariants(
+	ctx context.Context,
+	newsList ...*models.News,
+) (map[int64][]models.MediaVariant, error) {
+	blocks := make([]models.ContentBlock, 0)
+	for _, news := range newsList {
+		blocks = append(blocks, news.Content...)
+	}
+
+	return loadBlockVariants(ctx, s.mediaRepo, blocks)
+}
+
+// loadBlockVariants загружает варианты изображений для блоков одним запросом.
+func loadBlockVariants(
+	ctx context.Context,
+	mediaRepo MediaRepository,
+	blocks []models.ContentBlock,
+) (map[int64][]models.MediaVariant, error) {
+	mediaIDs := make([]int64, 0)
+	for _, block := range blocks {
+		if block.MediaID != nil {
+			mediaIDs = append(mediaIDs, *block.MediaID)
+		}
+	}
+
+	return mediaRepo.ListVariants(ctx, mediaIDs)
+}
+
+func newsToResponse(news *models.News, variants map[int64][]models.MediaVariant) dto.NewsResponse {
+	return dto.NewsResponse{
+		ID:        strconv.FormatInt(news.ID, 10),
+		Title:     news.Title,
+		Slug:      news.Slug,
+		Category:  news.Category,
+		Live:      news.Live,
+		CreatedAt: news.CreatedAt,
+		UpdatedAt: news.UpdatedAt,
+		StartTime: news.StartTime,
+		EndTime:   news.EndTime,
+		Content:   blocksToResponse(news.Content, variants),
+	}
+}
+
+func blocksToResponse(blocks []models.ContentBlock, variants map[int64][]models.MediaVariant) []dto.ContentBlockResponse {
+	contentDTO := make([]dto.ContentBlockResponse, len(blocks))
+	for i, block := range blocks {
+		contentDTO[i] = dto.ContentBlockResponse{
+			ID:       strconv.FormatInt(block.ID, 10),
+			Type:     string(block.Type),
+			Content:  block.Content,
+			Position: block.Position,
+		}
+		if block.MediaID != nil {
+			contentDTO[i].MediaID = strconv.FormatInt(*block.MediaID, 10)
+			contentDTO[i].MediaURL = mediaURL(*block.MediaID)
+			contentDTO[i].Variants = variantsToResponse(variants[*block.MediaID])
+			contentDTO[i].Srcset = srcset(variants[*block.MediaID], "jpeg")
+		}
+	}
+
+	return contentDTO
+}
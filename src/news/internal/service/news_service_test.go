@@ -0,0 +1,149 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type snapshotKey struct{}
+
+// readOnlyTxManager нумерует читающие транзакции, чтобы репозиторий видел, в
+// каком снимке выполняется запрос.
+type readOnlyTxManager struct {
+	fakeTxManager
+	snapshots int
+}
+
+func (m *readOnlyTxManager) RunReadOnly(ctx context.Context, f func(context.Context) error) error {
+	m.snapshots++
+	return f(context.WithValue(ctx, snapshotKey{}, m.snapshots))
+}
+
+// fakeSlugRepo запоминает, в каком снимке разрешён slug и прочитана новость.
+type fakeSlugRepo struct {
+	fakeBulkRepo
+	slugs    map[string]int64
+	resolved any
+	read     any
+}
+
+func (r *fakeSlugRepo) ResolveSlug(ctx context.Context, slug string) (int64, string, error) {
+	r.resolved = ctx.Value(snapshotKey{})
+	id, ok := r.slugs[slug]
+	if !ok {
+		return 0, "", postgres.ErrNotFound
+	}
+	return id, r.news[id].Slug, nil
+}
+
+func (r *fakeSlugRepo) GetByID(ctx context.Context, id int64) (*models.News, error) {
+	r.read = ctx.Value(snapshotKey{})
+	return r.fakeBulkRepo.GetByID(ctx, id)
+}
+
+func TestNewsService_GetNewsBySlug(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	repo := &fakeSlugRepo{
+		fakeBulkRepo: fakeBulkRepo{news: map[int64]models.News{
+			1: {ID: 1, Title: "Renamed", Slug: "renamed", StartTime: time.Now().Add(-time.Hour), EndTime: time.Now().Add(time.Hour)},
+		}},
+		slugs: map[string]int64{"renamed": 1, "original": 1},
+	}
+	txManager := &readOnlyTxManager{}
+	redis, mr := newFakeRedis(t)
+	news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, txManager, redis, time.Minute)
+
+	resp, err := news.GetNewsBySlug(ctx, dto.GetNewsBySlugRequest{Slug: "renamed", CheckVisibility: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", resp.Title)
+
+	// Slug и новость читаются в одной читающей транзакции.
+	assert.Equal(t, 1, txManager.snapshots)
+	assert.Equal(t, 1, repo.resolved)
+	assert.Equal(t, 1, repo.read)
+	assert.True(t, mr.Exists("news:1"))
+
+	_, err = news.GetNewsBySlug(ctx, dto.GetNewsBySlugRequest{Slug: "original"})
+	var moved *service.SlugMovedError
+	require.ErrorAs(t, err, &moved)
+	assert.Equal(t, "renamed", moved.CurrentSlug)
+}
+
+// fakeRacingRepo эмулирует параллельную транзакцию, которая занимает slug rival
+// между проверкой SlugTaken и записью. Занятые ею slug хранятся отдельно: откат
+// savepoint не отменяет чужую закоммиченную запись.
+type fakeRacingRepo struct {
+	fakeBulkRepo
+	rival  string
+	rivals map[string]bool
+}
+
+func (r *fakeRacingRepo) race(slug string) error {
+	if slug != r.rival {
+		return nil
+	}
+	r.rival = ""
+	r.rivals[slug] = true
+	return fmt.Errorf("%w: duplicate key value violates unique constraint \"news_slug_key\"", postgres.ErrSlugTaken)
+}
+
+func (r *fakeRacingRepo) SlugTaken(ctx context.Context, slug string, exceptNewsID int64) (bool, error) {
+	if r.rivals[slug] {
+		return true, nil
+	}
+	return r.fakeBulkRepo.SlugTaken(ctx, slug, exceptNewsID)
+}
+
+func (r *fakeRacingRepo) Create(ctx context.Context, news *models.News) error {
+	if err := r.race(news.Slug); err != nil {
+		return err
+	}
+	return r.fakeBulkRepo.Create(ctx, news)
+}
+
+func (r *fakeRacingRepo) Update(ctx context.Context, news *models.News) error {
+	if err := r.race(news.Slug); err != nil {
+		return err
+	}
+	return r.fakeBulkRepo.Update(ctx, news)
+}
+
+func TestNewsService_SlugTakenConcurrently(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	repo := &fakeRacingRepo{fakeBulkRepo: fakeBulkRepo{news: map[int64]models.News{
+		1: {ID: 1, Title: "Draft", Slug: "draft"},
+	}, nextID: 1}, rivals: map[string]bool{}}
+	txManager := &snapshotTxManager{repo: &repo.fakeBulkRepo}
+	redis, _ := newFakeRedis(t)
+	news := service.NewNewsService(repo, &fakeMediaRepo{}, &fakeOutbox{}, noopJobs{}, txManager, redis, time.Minute)
+
+	repo.rival = "breaking"
+	created, err := news.CreateNews(ctx, dto.CreateNewsRequest{
+		Title:     "Breaking",
+		Category:  "Tech",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "breaking-2", created.Slug)
+
+	repo.rival = "final"
+	_, err = news.UpdateNews(ctx, dto.UpdateNewsRequest{ID: "1", Title: "Final"})
+	require.NoError(t, err)
+	assert.Equal(t, "final-2", repo.news[1].Slug)
+	assert.Equal(t, 2, txManager.transactions)
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// EventFeed — источник событий для живой ленты: Redis Stream или шина в памяти.
+type EventFeed interface {
+	Follow(ctx context.Context, backlog int64, emit func(events.Envelope)) error
+}
+
+// NewsStream читает события из EventFeed и раздаёт их подключённым клиентам
+// через Hub. Каждая реплика читает источник сама, поэтому клиент получает
+// все события независимо от того, к какой реплике он подключён.
+type NewsStream struct {
+	feed       EventFeed
+	hub        *events.Hub
+	replaySize int
+	heartbeat  time.Duration
+	retry      time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewNewsStream(feed EventFeed, cfg config.StreamConfig) *NewsStream {
+	return &NewsStream{
+		feed:       feed,
+		hub:        events.NewHub(cfg.ReplaySize, cfg.BufferSize),
+		replaySize: cfg.ReplaySize,
+		heartbeat:  cfg.Heartbeat,
+		retry:      cfg.Retry,
+	}
+}
+
+func (s *NewsStream) Start(ctx context.Context) {
+	const op = "service.NewsStream.Start"
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := s.feed.Follow(ctx, int64(s.replaySize), s.hub.Broadcast); err != nil {
+			logger.Log.Error(op, "News stream stopped", err)
+		}
+	}()
+}
+
+// Stop прекращает чтение источника и отключает всех клиентов. Вызывается до
+// остановки HTTP-сервера: иначе тот будет ждать завершения открытых потоков.
+func (s *NewsStream) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	s.hub.Close()
+}
+
+// Subscribe godoc
+// @Summary      Live news updates
+// @Description  Server-Sent Events stream of created, updated, deleted, published and expired events,
+// @Description  plus entry_added, entry_updated and entry_deleted for live blogs.
+// @Description  Each message has the event type in the `event` field and the event JSON in `data`.
+// @Description  Reconnect with the `Last-Event-ID` header (or `last_event_id` query parameter) to receive missed events;
+// @Description  if they are no longer buffered, a `reset` event is sent first and the client should reload the news list.
+// @Tags         news
+// @Produce      text/event-stream
+// @Param        category       query   string  false  "Only events of this category"
+// @Param        last_event_id  query   string  false  "ID of the last received event"
+// @Param        Last-Event-ID  header  string  false  "ID of the last received event"
+// @Success      200  {string}  string  "event stream"
+// @Failure      503  {object}  dto.ErrorResponse
+// @Router       /news/stream [get]
+func (s *NewsStream) Subscribe(lastEventID, category string) (*events.Subscription, []events.Envelope, bool) {
+	var filter events.Filter
+	if category != "" {
+		filter = func(event models.NewsEvent) bool {
+			return event.Category == category
+		}
+	}
+	return s.hub.Subscribe(lastEventID, filter)
+}
+
+// Watch подписывает клиента WebSocket на события из набора тем. Набор можно
+// менять после подписки. При dropSlow события, на которые у клиента нет места
+// в буфере, пропускаются, иначе клиент отключается.
+func (s *NewsStream) Watch(topics *events.Topics, dropSlow bool) *events.Subscription {
+	if dropSlow {
+		return s.hub.SubscribeLossy(topics.Match)
+	}
+	sub, _, _ := s.hub.Subscribe("", topics.Match)
+	return sub
+}
+
+// Heartbeat — интервал комментариев-пингов, которые держат соединение открытым.
+func (s *NewsStream) Heartbeat() time.Duration {
+	return s.heartbeat
+}
+
+// Retry — через сколько клиенту переподключаться после обрыва.
+func (s *NewsStream) Retry() time.Duration {
+	return s.retry
+}
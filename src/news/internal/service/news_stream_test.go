@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// waitFollowing ждёт, пока NewsStream подпишется на шину.
+func waitFollowing(t *testing.T, bus *events.MemoryBus, stream *service.NewsStream) {
+	t.Helper()
+
+	probe, _, _ := stream.Subscribe("", "")
+	defer probe.Close()
+	require.Eventually(t, func() bool {
+		_ = bus.Publish(context.Background(), models.NewsEvent{Type: models.NewsUpdated})
+		select {
+		case <-probe.Events():
+			return true
+		default:
+			return false
+		}
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewsStream_CategoryAndResume(t *testing.T) {
+	bus := events.NewMemoryBus()
+	stream := service.NewNewsStream(bus, config.StreamConfig{ReplaySize: 10, BufferSize: 10})
+	stream.Start(context.Background())
+	defer stream.Stop()
+	waitFollowing(t, bus, stream)
+
+	sub, _, _ := stream.Subscribe("", "sport")
+	require.NotNil(t, sub)
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, models.NewsEvent{ID: 1, Type: models.NewsCreated, Category: "tech"}))
+	require.NoError(t, bus.Publish(ctx, models.NewsEvent{ID: 2, Type: models.NewsPublished, Category: "sport"}))
+	require.NoError(t, bus.Publish(ctx, models.NewsEvent{ID: 3, Type: models.NewsUpdated, Category: "sport"}))
+
+	var got []string
+	for len(got) < 2 {
+		select {
+		case env := <-sub.Events():
+			got = append(got, env.ID)
+		case <-time.After(time.Second):
+			t.Fatal("events were not delivered")
+		}
+	}
+	assert.Equal(t, []string{"2", "3"}, got)
+	sub.Close()
+
+	// Переподключение после события 1: догоняются пропущенные события категории.
+	resumedSub, backlog, resumed := stream.Subscribe("1", "sport")
+	defer resumedSub.Close()
+	assert.True(t, resumed)
+	require.Len(t, backlog, 2)
+	assert.Equal(t, models.NewsPublished, backlog[0].Event.Type)
+}
+
+func TestNewsStream_StopDisconnectsClients(t *testing.T) {
+	stream := service.NewNewsStream(events.NewMemoryBus(), config.StreamConfig{ReplaySize: 10, BufferSize: 10})
+	stream.Start(context.Background())
+
+	sub, _, _ := stream.Subscribe("", "")
+	require.NotNil(t, sub)
+
+	stream.Stop()
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+
+	sub, _, _ = stream.Subscribe("", "")
+	assert.Nil(t, sub)
+}
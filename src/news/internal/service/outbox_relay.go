@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// outboxPurgeInterval — как часто relay удаляет старые доставленные события.
+const outboxPurgeInterval = time.Hour
+
+// OutboxWriter записывает событие в outbox внутри текущей транзакции.
+type OutboxWriter interface {
+	Add(ctx context.Context, event *models.NewsEvent) error
+}
+
+type OutboxRepository interface {
+	OutboxWriter
+	ClaimPending(ctx context.Context, limit int) ([]models.OutboxEntry, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkRetry(ctx context.Context, id int64, attempts int, lastError string, availableAt time.Time) error
+	MarkDead(ctx context.Context, id int64, attempts int, lastError string) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// EventSink — приёмник, в который relay публикует события.
+type EventSink interface {
+	Publish(ctx context.Context, event models.NewsEvent) error
+}
+
+// OutboxRelay переносит события из outbox в EventSink с доставкой at-least-once.
+// Неудачные отправки повторяются с экспоненциальной задержкой, после maxAttempts
+// событие переводится в dead-letter.
+type OutboxRelay struct {
+	repo      OutboxRepository
+	txManager storage.TxManagerInterface
+	sink      EventSink
+
+	batchSize    int
+	pollInterval time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+	retention    time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewOutboxRelay(
+	repo OutboxRepository,
+	txManager storage.TxManagerInterface,
+	sink EventSink,
+	cfg config.OutboxConfig,
+) *OutboxRelay {
+	return &OutboxRelay{
+		repo:         repo,
+		txManager:    txManager,
+		sink:         sink,
+		batchSize:    max(cfg.BatchSize, 1),
+		pollInterval: cfg.PollInterval,
+		maxAttempts:  max(cfg.MaxAttempts, 1),
+		retryBackoff: cfg.RetryBackoff,
+		maxBackoff:   cfg.MaxBackoff,
+		retention:    cfg.Retention,
+	}
+}
+
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(ctx)
+	}()
+}
+
+// Stop останавливает relay. Если пачка событий была отправлена, но транзакция
+// не успела закоммититься, события будут отправлены повторно после перезапуска.
+func (r *OutboxRelay) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+func (r *OutboxRelay) run(ctx context.Context) {
+	const op = "service.OutboxRelay.run"
+
+	var lastPurge time.Time
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		relayed, err := r.RelayBatch(ctx)
+		if err != nil {
+			logger.Log.Error(op, "Failed to relay outbox events", err)
+		}
+
+		if r.retention > 0 && time.Since(lastPurge) >= outboxPurgeInterval {
+			r.purge(ctx)
+			lastPurge = time.Now()
+		}
+
+		// Полная пачка — скорее всего, есть ещё события, забираем их сразу.
+		if err == nil && relayed == r.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(r.pollInterval)
+		}
+	}
+}
+
+// RelayBatch отправляет одну пачку готовых событий и возвращает её размер.
+func (r *OutboxRelay) RelayBatch(ctx context.Context) (int, error) {
+	const op = "service.OutboxRelay.RelayBatch"
+
+	var relayed int
+	// Опубликованное не отзывается, поэтому пачка не повторяется целиком:
+	// неотмеченные события заберёт следующий проход.
+	err := r.txManager.RunReadCommited(storage.WithRetryPolicy(ctx, storage.NoRetry), func(ctx context.Context) error {
+		entries, err := r.repo.ClaimPending(ctx, r.batchSize)
+		if err != nil {
+			return err
+		}
+		relayed = len(entries)
+
+		for _, entry := range entries {
+			// Приёмник может писать в базу (доставки вебхуков). Вложенная транзакция
+			// откатывает только его записи, и отметка о неудаче сохраняется в пачке.
+			err := r.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+				return r.sink.Publish(ctx, entry.Event)
+			})
+			if err != nil {
+				if err := r.fail(ctx, entry, err); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := r.repo.MarkPublished(ctx, entry.Event.ID); err != nil {
+				return err
+			}
+			logger.Log.Debug(op, "Outbox event published", entry.Event.ID, "type", entry.Event.Type)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return relayed, nil
+}
+
+func (r *OutboxRelay) fail(ctx context.Context, entry models.OutboxEntry, cause error) error {
+	const op = "service.OutboxRelay.fail"
+
+	attempts := entry.Attempts + 1
+	if attempts >= r.maxAttempts {
+		logger.Log.Error(op, "Outbox event moved to dead letter", cause, "id", entry.Event.ID, "attempts", attempts)
+		return r.repo.MarkDead(ctx, entry.Event.ID, attempts, cause.Error())
+	}
+
+	logger.Log.Warn(op, "Failed to publish outbox event", cause, "id", entry.Event.ID, "attempts", attempts)
+	retryAt := time.Now().Add(exponentialBackoff(r.retryBackoff, r.maxBackoff, attempts))
+	return r.repo.MarkRetry(ctx, entry.Event.ID, attempts, cause.Error(), retryAt)
+}
+
+func (r *OutboxRelay) purge(ctx context.Context) {
+	const op = "service.OutboxRelay.purge"
+
+	err := r.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		deleted, err := r.repo.DeletePublishedBefore(ctx, time.Now().Add(-r.retention))
+		if err != nil {
+			return err
+		}
+		if deleted > 0 {
+			logger.Log.Info(op, "Published outbox events purged", deleted)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to purge outbox", err)
+	}
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/events"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type fakeOutbox struct {
+	events  []models.NewsEvent
+	entries []*models.OutboxEntry
+}
+
+func (o *fakeOutbox) Add(ctx context.Context, event *models.NewsEvent) error {
+	event.ID = int64(len(o.events) + 1)
+	o.events = append(o.events, *event)
+	o.entries = append(o.entries, &models.OutboxEntry{Event: *event, Status: models.OutboxPending})
+	return nil
+}
+
+// ClaimPending повторяет правило репозитория: только самое раннее ожидающее
+// событие каждой новости.
+func (o *fakeOutbox) ClaimPending(ctx context.Context, limit int) ([]models.OutboxEntry, error) {
+	claimed := make([]models.OutboxEntry, 0)
+	blocked := make(map[int64]bool)
+	for _, entry := range o.entries {
+		if entry.Status != models.OutboxPending || blocked[entry.Event.NewsID] {
+			continue
+		}
+		blocked[entry.Event.NewsID] = true
+		if !entry.AvailableAt.After(time.Now()) && len(claimed) < limit {
+			claimed = append(claimed, *entry)
+		}
+	}
+	return claimed, nil
+}
+
+func (o *fakeOutbox) entry(id int64) (*models.OutboxEntry, error) {
+	for _, entry := range o.entries {
+		if entry.Event.ID == id {
+			return entry, nil
+		}
+	}
+	return nil, postgres.ErrNotFound
+}
+
+func (o *fakeOutbox) MarkPublished(ctx context.Context, id int64) error {
+	entry, err := o.entry(id)
+	if err != nil {
+		return err
+	}
+	entry.Status = models.OutboxPublished
+	entry.Attempts++
+	return nil
+}
+
+func (o *fakeOutbox) MarkRetry(ctx context.Context, id int64, attempts int, lastError string, availableAt time.Time) error {
+	entry, err := o.entry(id)
+	if err != nil {
+		return err
+	}
+	entry.Attempts, entry.LastError, entry.AvailableAt = attempts, lastError, availableAt
+	return nil
+}
+
+func (o *fakeOutbox) MarkDead(ctx context.Context, id int64, attempts int, lastError string) error {
+	entry, err := o.entry(id)
+	if err != nil {
+		return err
+	}
+	entry.Status, entry.Attempts, entry.LastError = models.OutboxDead, attempts, lastError
+	return nil
+}
+
+func (o *fakeOutbox) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// flakySink отклоняет события новостей из failing.
+type flakySink struct {
+	failing   map[int64]bool
+	published []models.NewsEvent
+}
+
+func (s *flakySink) Publish(ctx context.Context, event models.NewsEvent) error {
+	if s.failing[event.NewsID] {
+		return errors.New("sink unavailable")
+	}
+	s.published = append(s.published, event)
+	return nil
+}
+
+func TestOutboxRelay_OrdersEventsPerNews(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	outbox := &fakeOutbox{}
+	for _, event := range []models.NewsEvent{
+		{Type: models.NewsCreated, NewsID: 1},
+		{Type: models.NewsCreated, NewsID: 2},
+		{Type: models.NewsUpdated, NewsID: 1},
+		{Type: models.NewsDeleted, NewsID: 1},
+	} {
+		require.NoError(t, outbox.Add(ctx, &event))
+	}
+
+	bus := events.NewMemoryBus()
+	received, unsubscribe := bus.Subscribe(10)
+	defer unsubscribe()
+
+	relay := service.NewOutboxRelay(outbox, fakeTxManager{}, bus, config.OutboxConfig{BatchSize: 10, MaxAttempts: 3})
+
+	for {
+		relayed, err := relay.RelayBatch(ctx)
+		require.NoError(t, err)
+		if relayed == 0 {
+			break
+		}
+	}
+
+	order := make([]int64, 0)
+	for range 4 {
+		order = append(order, (<-received).ID)
+	}
+	assert.Equal(t, []int64{1, 2, 3, 4}, order)
+}
+
+func TestOutboxRelay_RetriesThenDeadLetters(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	outbox := &fakeOutbox{}
+	first := models.NewsEvent{Type: models.NewsCreated, NewsID: 1}
+	second := models.NewsEvent{Type: models.NewsUpdated, NewsID: 1}
+	require.NoError(t, outbox.Add(ctx, &first))
+	require.NoError(t, outbox.Add(ctx, &second))
+
+	sink := &flakySink{failing: map[int64]bool{1: true}}
+	relay := service.NewOutboxRelay(outbox, fakeTxManager{}, sink, config.OutboxConfig{BatchSize: 10, MaxAttempts: 3})
+
+	for attempt := 1; attempt <= 3; attempt++ {
+		_, err := relay.RelayBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, attempt, outbox.entries[0].Attempts)
+		assert.Equal(t, "sink unavailable", outbox.entries[0].LastError)
+		assert.Empty(t, sink.published, "later events of the same news must wait")
+	}
+	assert.Equal(t, models.OutboxDead, outbox.entries[0].Status)
+
+	sink.failing = nil
+	_, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	require.Len(t, sink.published, 1)
+	assert.Equal(t, second.ID, sink.published[0].ID)
+	assert.Equal(t, models.OutboxPublished, outbox.entries[1].Status)
+}
+
+// Ошибка приёмника под savepoint помечает только своё событие, остальные
+// события пачки публикуются один раз.
+func TestOutboxRelay_SinkFailureMarksOnlyItsEntry(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	outbox := &fakeOutbox{}
+	for newsID := int64(1); newsID <= 3; newsID++ {
+		require.NoError(t, outbox.Add(ctx, &models.NewsEvent{Type: models.NewsCreated, NewsID: newsID}))
+	}
+
+	sink := &flakySink{failing: map[int64]bool{2: true}}
+	relay := service.NewOutboxRelay(outbox, fakeTxManager{}, sink, config.OutboxConfig{BatchSize: 10, MaxAttempts: 3, RetryBackoff: time.Minute})
+
+	relayed, err := relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, relayed)
+
+	require.Len(t, sink.published, 2)
+	assert.Equal(t, []int64{1, 3}, []int64{sink.published[0].NewsID, sink.published[1].NewsID})
+	assert.Equal(t, models.OutboxPublished, outbox.entries[0].Status)
+	assert.Equal(t, models.OutboxPending, outbox.entries[1].Status)
+	assert.Equal(t, 1, outbox.entries[1].Attempts)
+	assert.Equal(t, "sink unavailable", outbox.entries[1].LastError)
+	assert.Equal(t, models.OutboxPublished, outbox.entries[2].Status)
+
+	// Событие ждёт своей задержки, опубликованные повторно не отправляются.
+	relayed, err = relay.RelayBatch(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, relayed)
+	assert.Len(t, sink.published, 2)
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+// renderContentHTML собирает HTML из блоков контента для внешних представлений (ленты и т.п.).
+// Ссылки рендерятся только для http(s), всё остальное выводится как текст.
+func renderContentHTML(blocks []models.ContentBlock, baseURL string) string {
+	var sb strings.Builder
+
+	for _, block := range blocks {
+		switch block.Type {
+		case models.TextBlock:
+			fmt.Fprintf(&sb, "<p>%s</p>", html.EscapeString(block.Content))
+		case models.LinkBlock:
+			escaped := html.EscapeString(block.Content)
+			if isHTTPURL(block.Content) {
+				fmt.Fprintf(&sb, `<p><a href="%s">%s</a></p>`, escaped, escaped)
+			} else {
+				fmt.Fprintf(&sb, "<p>%s</p>", escaped)
+			}
+		case models.ImageBlock:
+			if block.MediaID == nil {
+				continue
+			}
+			caption := html.EscapeString(block.Content)
+			fmt.Fprintf(&sb, `<figure><img src="%s" alt="%s"><figcaption>%s</figcaption></figure>`,
+				html.EscapeString(absoluteURL(baseURL, mediaURL(*block.MediaID))), caption, caption)
+		}
+	}
+
+	return sb.String()
+}
+
+// renderContentText возвращает текстовое содержимое блоков, например для описаний в лентах.
+func renderContentText(blocks []models.ContentBlock) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		if block.Type == models.TextBlock {
+			parts = append(parts, block.Content)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// newsURL возвращает публичный адрес новости по шаблону из конфига.
+func newsURL(site config.SiteConfig, id int64, slug string) string {
+	path := strings.NewReplacer(
+		"{id}", strconv.FormatInt(id, 10),
+		"{slug}", url.PathEscape(slug),
+	).Replace(site.NewsPath)
+	return absoluteURL(site.BaseURL, path)
+}
+
+func absoluteURL(baseURL string, path string) string {
+	return strings.TrimRight(baseURL, "/") + path
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+)
+
+func TestRenderContentHTML(t *testing.T) {
+	mediaID := int64(7)
+	blocks := []models.ContentBlock{
+		{Type: models.TextBlock, Content: "Tom & <Jerry>"},
+		{Type: models.LinkBlock, Content: "https://example.com/?a=1&b=2"},
+		{Type: models.LinkBlock, Content: "javascript:alert(1)"},
+		{Type: models.ImageBlock, Content: "Caption", MediaID: &mediaID},
+	}
+
+	got := renderContentHTML(blocks, "https://news.example.com/")
+
+	assert.Equal(t,
+		`<p>Tom &amp; &lt;Jerry&gt;</p>`+
+			`<p><a href="https://example.com/?a=1&amp;b=2">https://example.com/?a=1&amp;b=2</a></p>`+
+			`<p>javascript:alert(1)</p>`+
+			`<figure><img src="https://news.example.com/media/7" alt="Caption"><figcaption>Caption</figcaption></figure>`,
+		got,
+	)
+}
+
+func TestNewsURL(t *testing.T) {
+	site := config.SiteConfig{BaseURL: "https://news.example.com", NewsPath: "/news/{id}"}
+	assert.Equal(t, "https://news.example.com/news/42", newsURL(site, 42, "title"))
+
+	site.NewsPath = "/news/{slug}"
+	assert.Equal(t, "https://news.example.com/news/title", newsURL(site, 42, "title"))
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+// schedulerLockKey — ключ advisory-блокировки, которую держит активный планировщик.
+const schedulerLockKey int64 = 0x6e657773_0001
+
+type ScheduleRepository interface {
+	Transitions(ctx context.Context, from, to time.Time) ([]models.NewsEvent, error)
+	NextBoundary(ctx context.Context, after time.Time) (time.Time, bool, error)
+	Watermark(ctx context.Context) (time.Time, bool, error)
+	SaveWatermark(ctx context.Context, processedUntil time.Time) error
+}
+
+type AdvisoryLocker interface {
+	TryAdvisoryLock(ctx context.Context, key int64) (*storage.AdvisoryLock, error)
+}
+
+// EventHook получает события публикации и снятия новостей.
+type EventHook func(ctx context.Context, event models.NewsEvent)
+
+// Scheduler просыпается в моменты start_time/end_time новостей, сбрасывает кеш
+// затронутых новостей и лент и рассылает события published/expired.
+// Работает только на реплике, получившей advisory-блокировку.
+type Scheduler struct {
+	repo      ScheduleRepository
+	outbox    OutboxWriter
+	txManager storage.TxManagerInterface
+	locker    AdvisoryLocker
+	redis     RedisClient
+
+	maxSleep   time.Duration
+	lockRetry  time.Duration
+	maxCatchUp time.Duration
+
+	mu    sync.RWMutex
+	hooks []EventHook
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(
+	repo ScheduleRepository,
+	outbox OutboxWriter,
+	txManager storage.TxManagerInterface,
+	locker AdvisoryLocker,
+	redis RedisClient,
+	cfg config.SchedulerConfig,
+) *Scheduler {
+	return &Scheduler{
+		repo:       repo,
+		outbox:     outbox,
+		txManager:  txManager,
+		locker:     locker,
+		redis:      redis,
+		maxSleep:   cfg.MaxSleep,
+		lockRetry:  cfg.LockRetry,
+		maxCatchUp: cfg.MaxCatchUp,
+	}
+}
+
+// OnEvent регистрирует обработчик событий. Обработчики вызываются синхронно,
+// в порядке наступления событий.
+func (s *Scheduler) OnEvent(hook EventHook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks = append(s.hooks, hook)
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(ctx)
+	}()
+}
+
+// Stop останавливает планировщик и отпускает блокировку.
+func (s *Scheduler) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) run(ctx context.Context) {
+	const op = "service.Scheduler.run"
+
+	var lock *storage.AdvisoryLock
+	defer func() {
+		if lock != nil {
+			lock.Release(context.Background())
+		}
+	}()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if lock != nil {
+			if err := lock.Alive(ctx); err != nil {
+				logger.Log.Warn(op, "Scheduler lock connection lost", err)
+				lock.Release(ctx)
+				lock = nil
+			}
+		}
+
+		if lock == nil {
+			acquired, err := s.locker.TryAdvisoryLock(ctx, schedulerLockKey)
+			switch {
+			case err == nil:
+				logger.Log.Info(op, "Scheduler lock acquired", schedulerLockKey)
+				lock = acquired
+			case errors.Is(err, storage.ErrLockNotAcquired):
+			default:
+				logger.Log.Error(op, "Failed to acquire scheduler lock", err)
+			}
+		}
+
+		wait := s.lockRetry
+		if lock != nil {
+			next, err := s.step(ctx)
+			if err != nil {
+				logger.Log.Error(op, "Scheduler step failed", err)
+			} else {
+				wait = next
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// step обрабатывает наступившие границы и возвращает время до следующей.
+func (s *Scheduler) step(ctx context.Context) (time.Duration, error) {
+	now := time.Now()
+	if err := s.Dispatch(ctx, now); err != nil {
+		return 0, err
+	}
+
+	wait := s.maxSleep
+	next, ok, err := s.repo.NextBoundary(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		wait = min(wait, max(next.Sub(now), 0))
+	}
+
+	return wait, nil
+}
+
+// Dispatch обрабатывает все публикации и снятия новостей с прошлой отметки до now.
+// События пишутся в outbox в одной транзакции с новой отметкой, поэтому каждое
+// попадает туда ровно один раз. При первом запуске история не воспроизводится,
+// после долгого простоя — не глубже maxCatchUp.
+func (s *Scheduler) Dispatch(ctx context.Context, now time.Time) error {
+	const op = "service.Scheduler.Dispatch"
+
+	from, ok, err := s.repo.Watermark(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		from = now
+	}
+	if s.maxCatchUp > 0 && now.Sub(from) > s.maxCatchUp {
+		logger.Log.Warn(op, "Schedule watermark is too old, skipping events before", now.Add(-s.maxCatchUp))
+		from = now.Add(-s.maxCatchUp)
+	}
+
+	events := make([]models.NewsEvent, 0)
+	if from.Before(now) {
+		events, err = s.repo.Transitions(ctx, from, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		for i := range events {
+			if err := s.outbox.Add(ctx, &events[i]); err != nil {
+				return err
+			}
+		}
+		return s.repo.SaveWatermark(ctx, now)
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	s.invalidate(ctx, events)
+
+	s.mu.RLock()
+	hooks := s.hooks
+	s.mu.RUnlock()
+
+	for _, event := range events {
+		logger.Log.Info(op, "News schedule event", event.Type, "newsID", event.NewsID)
+		for _, hook := range hooks {
+			hook(ctx, event)
+		}
+	}
+
+	return nil
+}
+
+// invalidate сбрасывает кеш новостей, у которых сменилась видимость, и все ленты.
+func (s *Scheduler) invalidate(ctx context.Context, events []models.NewsEvent) {
+	const op = "service.Scheduler.invalidate"
+
+	keys := make([]string, len(events))
+	for i, event := range events {
+		keys[i] = fmt.Sprintf("news:%d", event.NewsID)
+	}
+	if err := s.redis.GetRedis().Del(ctx, keys...).Err(); err != nil {
+		logger.Log.Error(op, "Failed to invalidate cache", keys, "error", err)
+	}
+
+	invalidateFeedCache(ctx, s.redis)
+	invalidateNewsListCache(ctx, s.redis)
+}
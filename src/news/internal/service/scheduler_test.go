@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type fakeRedis struct {
+	client *redis.Client
+}
+
+func (r fakeRedis) GetRedis() *redis.Client {
+	return r.client
+}
+
+func newFakeRedis(t *testing.T) (fakeRedis, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return fakeRedis{client: client}, mr
+}
+
+type fakeScheduleRepo struct {
+	news      []models.News
+	watermark *time.Time
+}
+
+func (r *fakeScheduleRepo) Transitions(ctx context.Context, from, to time.Time) ([]models.NewsEvent, error) {
+	events := make([]models.NewsEvent, 0)
+	for _, n := range r.news {
+		if n.StartTime.After(from) && !n.StartTime.After(to) {
+			events = append(events, models.NewsEvent{Type: models.NewsPublished, NewsID: n.ID, OccurredAt: n.StartTime})
+		}
+		if n.EndTime.After(from) && !n.EndTime.After(to) {
+			events = append(events, models.NewsEvent{Type: models.NewsExpired, NewsID: n.ID, OccurredAt: n.EndTime})
+		}
+	}
+	return events, nil
+}
+
+func (r *fakeScheduleRepo) NextBoundary(ctx context.Context, after time.Time) (time.Time, bool, error) {
+	var next time.Time
+	for _, n := range r.news {
+		for _, t := range []time.Time{n.StartTime, n.EndTime} {
+			if t.After(after) && (next.IsZero() || t.Before(next)) {
+				next = t
+			}
+		}
+	}
+	return next, !next.IsZero(), nil
+}
+
+func (r *fakeScheduleRepo) Watermark(ctx context.Context) (time.Time, bool, error) {
+	if r.watermark == nil {
+		return time.Time{}, false, nil
+	}
+	return *r.watermark, true, nil
+}
+
+func (r *fakeScheduleRepo) SaveWatermark(ctx context.Context, processedUntil time.Time) error {
+	r.watermark = &processedUntil
+	return nil
+}
+
+func TestScheduler_Dispatch(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+
+	base := time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC)
+	repo := &fakeScheduleRepo{news: []models.News{
+		{ID: 1, StartTime: base.Add(time.Minute), EndTime: base.Add(time.Hour)},
+		{ID: 2, StartTime: base.Add(-time.Hour), EndTime: base.Add(2 * time.Minute)},
+	}}
+
+	rdb, mr := newFakeRedis(t)
+	require.NoError(t, mr.Set("news:1", "cached"))
+	require.NoError(t, mr.Set("news:2", "cached"))
+	require.NoError(t, mr.Set("news:3", "cached"))
+
+	outbox := &fakeOutbox{}
+	scheduler := service.NewScheduler(repo, outbox, fakeTxManager{}, nil, rdb, config.SchedulerConfig{MaxCatchUp: 24 * time.Hour})
+
+	var events []models.NewsEvent
+	scheduler.OnEvent(func(ctx context.Context, event models.NewsEvent) {
+		events = append(events, event)
+	})
+
+	require.NoError(t, scheduler.Dispatch(ctx, base))
+	assert.Empty(t, events, "first run must not replay history")
+
+	require.NoError(t, scheduler.Dispatch(ctx, base.Add(5*time.Minute)))
+	require.Len(t, events, 2)
+	assert.Equal(t, models.NewsEvent{ID: 1, Type: models.NewsPublished, NewsID: 1, OccurredAt: base.Add(time.Minute)}, events[0])
+	assert.Equal(t, models.NewsEvent{ID: 2, Type: models.NewsExpired, NewsID: 2, OccurredAt: base.Add(2 * time.Minute)}, events[1])
+	assert.Equal(t, events, outbox.events)
+
+	assert.False(t, mr.Exists("news:1"))
+	assert.False(t, mr.Exists("news:2"))
+	assert.True(t, mr.Exists("news:3"))
+
+	require.NoError(t, scheduler.Dispatch(ctx, base.Add(10*time.Minute)))
+	assert.Len(t, events, 2, "processed boundaries must not fire twice")
+	assert.Equal(t, base.Add(10*time.Minute), *repo.watermark)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+)
+
+const (
+	// SitemapChunkSize — ограничение протокола sitemaps.org на число URL в одном файле.
+	SitemapChunkSize = 50000
+	// googleNewsWindow и googleNewsLimit — требования Google News к новостной карте сайта.
+	googleNewsWindow = 48 * time.Hour
+	googleNewsLimit  = 1000
+
+	sitemapPageSize = 1000
+	sitemapNS       = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	googleNewsNS    = "http://www.google.com/schemas/sitemap-news/0.9"
+)
+
+type SitemapRepository interface {
+	Chunks(ctx context.Context, chunkSize int) ([]models.SitemapChunk, error)
+	ListVisible(ctx context.Context, afterID int64, maxID int64, limit int) ([]models.SitemapEntry, error)
+	ListPublishedSince(ctx context.Context, since time.Time, limit int) ([]models.SitemapEntry, error)
+}
+
+type SitemapService struct {
+	sitemapRepo SitemapRepository
+	site        config.SiteConfig
+}
+
+func NewSitemapService(sitemapRepo SitemapRepository, site config.SiteConfig) *SitemapService {
+	return &SitemapService{
+		sitemapRepo: sitemapRepo,
+		site:        site,
+	}
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name         `xml:"sitemapindex"`
+	XMLNS    string           `xml:"xmlns,attr"`
+	Sitemaps []sitemapPointer `xml:"sitemap"`
+}
+
+type sitemapPointer struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURL struct {
+	XMLName xml.Name        `xml:"url"`
+	Loc     string          `xml:"loc"`
+	LastMod string          `xml:"lastmod,omitempty"`
+	News    *googleNewsItem `xml:"news:news,omitempty"`
+}
+
+type googleNewsItem struct {
+	Publication     googleNewsPublication `xml:"news:publication"`
+	PublicationDate string                `xml:"news:publication_date"`
+	Title           string                `xml:"news:title"`
+}
+
+type googleNewsPublication struct {
+	Name     string `xml:"news:name"`
+	Language string `xml:"news:language"`
+}
+
+// WriteIndex пишет индекс карт сайта: по файлу на каждые SitemapChunkSize id
+// и отдельную карту для Google News.
+func (s *SitemapService) WriteIndex(ctx context.Context, w io.Writer) error {
+	const op = "service.SitemapService.WriteIndex"
+
+	chunks, err := s.sitemapRepo.Chunks(ctx, SitemapChunkSize)
+	if err != nil {
+		return err
+	}
+
+	index := sitemapIndex{XMLNS: sitemapNS}
+	for _, chunk := range chunks {
+		index.Sitemaps = append(index.Sitemaps, sitemapPointer{
+			Loc:     absoluteURL(s.site.BaseURL, fmt.Sprintf("/sitemaps/news-%d.xml", chunk.Number)),
+			LastMod: chunk.LastMod.UTC().Format(time.RFC3339),
+		})
+	}
+	index.Sitemaps = append(index.Sitemaps, sitemapPointer{
+		Loc: absoluteURL(s.site.BaseURL, "/sitemaps/google-news.xml"),
+	})
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	if err := xml.NewEncoder(w).Encode(index); err != nil {
+		logger.Log.Error(op, "Failed to write sitemap index", err)
+		return err
+	}
+	return nil
+}
+
+// WriteChunk потоково пишет одну часть карты сайта, читая новости из базы страницами по id.
+func (s *SitemapService) WriteChunk(ctx context.Context, w io.Writer, chunk int) error {
+	const op = "service.SitemapService.WriteChunk"
+
+	if chunk < 1 {
+		return postgres.ErrNotFound
+	}
+
+	afterID := int64(chunk-1) * SitemapChunkSize
+	maxID := int64(chunk) * SitemapChunkSize
+
+	entries, err := s.sitemapRepo.ListVisible(ctx, afterID, maxID, sitemapPageSize)
+	if err != nil {
+		logger.Log.Error(op, "Failed to load sitemap page", err, "afterID", afterID)
+		return err
+	}
+	if len(entries) == 0 {
+		return postgres.ErrNotFound
+	}
+
+	if _, err := fmt.Fprintf(w, "%s<urlset xmlns=%q>", xml.Header, sitemapNS); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	for {
+		for _, entry := range entries {
+			err := enc.Encode(sitemapURL{
+				Loc:     newsURL(s.site, entry.ID, entry.Slug),
+				LastMod: entry.LastMod.UTC().Format(time.RFC3339),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if err := enc.Flush(); err != nil {
+			return err
+		}
+
+		if len(entries) < sitemapPageSize {
+			break
+		}
+		afterID = entries[len(entries)-1].ID
+
+		entries, err = s.sitemapRepo.ListVisible(ctx, afterID, maxID, sitemapPageSize)
+		if err != nil {
+			logger.Log.Error(op, "Failed to load sitemap page", err, "afterID", afterID)
+			return err
+		}
+	}
+
+	_, err = io.WriteString(w, "</urlset>")
+	return err
+}
+
+// WriteGoogleNews пишет карту Google News с новостями за последние 48 часов.
+func (s *SitemapService) WriteGoogleNews(ctx context.Context, w io.Writer) error {
+	const op = "service.SitemapService.WriteGoogleNews"
+
+	entries, err := s.sitemapRepo.ListPublishedSince(ctx, time.Now().Add(-googleNewsWindow), googleNewsLimit)
+	if err != nil {
+		logger.Log.Error(op, "Failed to load recent news", err)
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s<urlset xmlns=%q xmlns:news=%q>", xml.Header, sitemapNS, googleNewsNS)
+	if err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	for _, entry := range entries {
+		err := enc.Encode(sitemapURL{
+			Loc:     newsURL(s.site, entry.ID, entry.Slug),
+			LastMod: entry.LastMod.UTC().Format(time.RFC3339),
+			News: &googleNewsItem{
+				Publication: googleNewsPublication{
+					Name:     s.site.Title,
+					Language: s.site.Language,
+				},
+				PublicationDate: entry.StartTime.UTC().Format(time.RFC3339),
+				Title:           entry.Title,
+			},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "</urlset>")
+	return err
+}
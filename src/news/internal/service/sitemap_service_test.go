@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/repository/postgres"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+type fakeSitemapRepo struct {
+	entries []models.SitemapEntry
+	calls   int
+}
+
+func (r *fakeSitemapRepo) Chunks(ctx context.Context, chunkSize int) ([]models.SitemapChunk, error) {
+	chunks := make([]models.SitemapChunk, 0)
+	for _, e := range r.entries {
+		number := int((e.ID-1)/int64(chunkSize)) + 1
+		if len(chunks) == 0 || chunks[len(chunks)-1].Number != number {
+			chunks = append(chunks, models.SitemapChunk{Number: number, LastMod: e.LastMod})
+		}
+	}
+	return chunks, nil
+}
+
+func (r *fakeSitemapRepo) ListVisible(ctx context.Context, afterID int64, maxID int64, limit int) ([]models.SitemapEntry, error) {
+	r.calls++
+	result := make([]models.SitemapEntry, 0)
+	for _, e := range r.entries {
+		if e.ID > afterID && e.ID <= maxID && len(result) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeSitemapRepo) ListPublishedSince(ctx context.Context, since time.Time, limit int) ([]models.SitemapEntry, error) {
+	result := make([]models.SitemapEntry, 0)
+	for _, e := range r.entries {
+		if !e.StartTime.Before(since) {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func TestSitemapService(t *testing.T) {
+	now := time.Now()
+	repo := &fakeSitemapRepo{}
+	for id := int64(1); id <= 2500; id++ {
+		repo.entries = append(repo.entries, models.SitemapEntry{ID: id, Title: "News", StartTime: now.Add(-72 * time.Hour), LastMod: now})
+	}
+	repo.entries = append(repo.entries, models.SitemapEntry{ID: 50001, Title: "Fresh & new", StartTime: now, LastMod: now})
+
+	svc := service.NewSitemapService(repo, config.SiteConfig{
+		Title:    "News",
+		BaseURL:  "https://news.example.com",
+		NewsPath: "/news/{id}",
+		Language: "ru",
+	})
+	ctx := context.Background()
+
+	var index bytes.Buffer
+	require.NoError(t, svc.WriteIndex(ctx, &index))
+	assert.Contains(t, index.String(), "https://news.example.com/sitemaps/news-1.xml")
+	assert.Contains(t, index.String(), "https://news.example.com/sitemaps/news-2.xml")
+	assert.Contains(t, index.String(), "https://news.example.com/sitemaps/google-news.xml")
+
+	var chunk bytes.Buffer
+	require.NoError(t, svc.WriteChunk(ctx, &chunk, 1))
+	var urlset struct {
+		URLs []struct {
+			Loc string `xml:"loc"`
+		} `xml:"url"`
+	}
+	require.NoError(t, xml.Unmarshal(chunk.Bytes(), &urlset))
+	assert.Len(t, urlset.URLs, 2500)
+	assert.Equal(t, "https://news.example.com/news/1", urlset.URLs[0].Loc)
+	assert.Equal(t, 3, repo.calls, "chunk is read in keyset pages")
+
+	assert.ErrorIs(t, svc.WriteChunk(ctx, &bytes.Buffer{}, 3), postgres.ErrNotFound)
+
+	var googleNews bytes.Buffer
+	require.NoError(t, svc.WriteGoogleNews(ctx, &googleNews))
+	assert.Equal(t, 1, strings.Count(googleNews.String(), "<url>"))
+	assert.Contains(t, googleNews.String(), "<news:title>Fresh &amp; new</news:title>")
+}
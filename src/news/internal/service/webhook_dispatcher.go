@@ -0,0 +1,240 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	// webhookErrorBodyLimit — сколько байт ответа получателя сохранять в журнал при ошибке.
+	webhookErrorBodyLimit = 512
+)
+
+// SignWebhook возвращает подпись тела запроса: "sha256=" и hex HMAC-SHA256
+// от строки "<timestamp>.<body>" на секрете подписки. Метка времени входит
+// в подпись, чтобы получатель мог отбрасывать переотправленные старые запросы.
+func SignWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookDispatcher отправляет поставленные в очередь доставки вебхуков.
+// Неудачные попытки повторяются с экспоненциальной задержкой, каждая попытка
+// записывается в журнал.
+type WebhookDispatcher struct {
+	repo      WebhookRepository
+	txManager storage.TxManagerInterface
+	client    *http.Client
+
+	workers      int
+	batchSize    int
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	retryBackoff time.Duration
+	maxBackoff   time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewWebhookDispatcher(
+	repo WebhookRepository,
+	txManager storage.TxManagerInterface,
+	cfg config.WebhooksConfig,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		repo:      repo,
+		txManager: txManager,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			// Редирект считается неудачной доставкой: подпись выдана для исходного URL.
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		workers:      max(cfg.Workers, 1),
+		batchSize:    max(cfg.BatchSize, 1),
+		pollInterval: cfg.PollInterval,
+		lease:        max(cfg.Lease, cfg.Timeout),
+		maxAttempts:  max(cfg.MaxAttempts, 1),
+		retryBackoff: cfg.RetryBackoff,
+		maxBackoff:   cfg.MaxBackoff,
+	}
+}
+
+func (d *WebhookDispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(ctx)
+	}()
+}
+
+// Stop останавливает отправку. Доставки, прерванные на середине,
+// вернутся в очередь по истечении lease.
+func (d *WebhookDispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *WebhookDispatcher) run(ctx context.Context) {
+	const op = "service.WebhookDispatcher.run"
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		sent, err := d.DispatchBatch(ctx)
+		if err != nil {
+			logger.Log.Error(op, "Failed to dispatch webhooks", err)
+		}
+
+		if err == nil && sent == d.batchSize {
+			timer.Reset(0)
+		} else {
+			timer.Reset(d.pollInterval)
+		}
+	}
+}
+
+// DispatchBatch отправляет до batchSize доставок, которым пора уходить, и
+// возвращает их число. Доставки забираются по workers штук и отправляются
+// параллельно: забранная доставка сразу уходит получателю, поэтому lease
+// не истекает, пока она ждёт своей очереди.
+func (d *WebhookDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	sent := 0
+	for sent < d.batchSize && ctx.Err() == nil {
+		limit := min(d.workers, d.batchSize-sent)
+
+		var deliveries []models.WebhookDelivery
+		err := d.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+			var err error
+			deliveries, err = d.repo.ClaimDueDeliveries(ctx, limit, d.lease)
+			return err
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		var wg sync.WaitGroup
+		for i := range deliveries {
+			wg.Add(1)
+			go func(delivery *models.WebhookDelivery) {
+				defer wg.Done()
+				d.deliver(ctx, delivery)
+			}(&deliveries[i])
+		}
+		wg.Wait()
+
+		sent += len(deliveries)
+		if len(deliveries) < limit {
+			break
+		}
+	}
+	return sent, nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery) {
+	const op = "service.WebhookDispatcher.deliver"
+
+	started := time.Now()
+	statusCode, sendErr := d.send(ctx, delivery)
+
+	delivery.Attempts++
+	delivery.LastStatusCode = statusCode
+	delivery.LastError = ""
+
+	attempt := &models.WebhookAttempt{
+		DeliveryID: delivery.ID,
+		Attempt:    delivery.Attempts,
+		StatusCode: statusCode,
+		Duration:   time.Since(started),
+	}
+
+	switch {
+	case sendErr == nil:
+		delivery.Status = models.WebhookSucceeded
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = models.WebhookFailed
+		delivery.LastError = sendErr.Error()
+		attempt.Error = sendErr.Error()
+		logger.Log.Error(op, "Webhook delivery failed permanently", sendErr, "deliveryID", delivery.ID)
+	default:
+		delivery.Status = models.WebhookPending
+		delivery.LastError = sendErr.Error()
+		delivery.NextAttemptAt = time.Now().Add(exponentialBackoff(d.retryBackoff, d.maxBackoff, delivery.Attempts))
+		attempt.Error = sendErr.Error()
+		logger.Log.Warn(op, "Webhook delivery failed, will retry", sendErr, "deliveryID", delivery.ID)
+	}
+
+	// Результат попытки нужно сохранить, даже если сервис уже останавливается.
+	err := d.txManager.RunReadCommited(context.WithoutCancel(ctx), func(ctx context.Context) error {
+		return d.repo.RecordAttempt(ctx, delivery, attempt)
+	})
+	if err != nil {
+		logger.Log.Error(op, "Failed to record webhook attempt", err, "deliveryID", delivery.ID)
+	}
+}
+
+// send выполняет один подписанный POST. Успехом считается любой ответ 2xx.
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "news-service-webhooks/1.0")
+	req.Header.Set(WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhook(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, webhookErrorBodyLimit))
+		return resp.StatusCode, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodyLimit))
+	return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+}
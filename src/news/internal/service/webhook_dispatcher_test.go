@@ -0,0 +1,190 @@
+package service_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/service"
+)
+
+// fakeWebhookRepo реализует только методы, нужные очереди доставок.
+type fakeWebhookRepo struct {
+	service.WebhookRepository
+
+	mu         sync.Mutex
+	subs       []*models.WebhookSubscription
+	deliveries []*models.WebhookDelivery
+	attempts   []models.WebhookAttempt
+	limits     []int
+}
+
+func (r *fakeWebhookRepo) MatchingSubscriptions(
+	ctx context.Context,
+	eventType models.NewsEventType,
+	category string,
+) ([]*models.WebhookSubscription, error) {
+	return r.subs, nil
+}
+
+func (r *fakeWebhookRepo) CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error {
+	for _, delivery := range deliveries {
+		delivery.ID = int64(len(r.deliveries) + 1)
+		delivery.Status = models.WebhookPending
+		r.deliveries = append(r.deliveries, delivery)
+	}
+	return nil
+}
+
+func (r *fakeWebhookRepo) ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error) {
+	r.limits = append(r.limits, limit)
+	claimed := make([]models.WebhookDelivery, 0)
+	for _, delivery := range r.deliveries {
+		if delivery.Status != models.WebhookPending || delivery.NextAttemptAt.After(time.Now()) || len(claimed) == limit {
+			continue
+		}
+		delivery.NextAttemptAt = time.Now().Add(lease)
+		claimed = append(claimed, *delivery)
+		claimed[len(claimed)-1].URL = r.subs[0].URL
+		claimed[len(claimed)-1].Secret = r.subs[0].Secret
+	}
+	return claimed, nil
+}
+
+func (r *fakeWebhookRepo) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := r.deliveries[delivery.ID-1]
+	stored.Status, stored.Attempts = delivery.Status, delivery.Attempts
+	stored.NextAttemptAt, stored.LastStatusCode, stored.LastError = delivery.NextAttemptAt, delivery.LastStatusCode, delivery.LastError
+	r.attempts = append(r.attempts, *attempt)
+	return nil
+}
+
+func newWebhookFixture(t *testing.T, handler http.HandlerFunc) (*fakeWebhookRepo, *service.WebhookService, *service.WebhookDispatcher) {
+	t.Helper()
+	logger.Init("local")
+
+	receiver := httptest.NewServer(handler)
+	t.Cleanup(receiver.Close)
+
+	repo := &fakeWebhookRepo{subs: []*models.WebhookSubscription{
+		{ID: 1, URL: receiver.URL, Secret: "s3cret", Active: true},
+	}}
+	dispatcher := service.NewWebhookDispatcher(repo, fakeTxManager{}, config.WebhooksConfig{
+		Workers:     2,
+		BatchSize:   10,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+	})
+	return repo, service.NewWebhookService(repo, fakeTxManager{}), dispatcher
+}
+
+func TestWebhookDispatcher_SignsAndDelivers(t *testing.T) {
+	var gotBody []byte
+	var gotHeader http.Header
+	repo, webhooks, dispatcher := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header.Clone()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+
+	event := models.NewsEvent{ID: 7, Type: models.NewsPublished, NewsID: 42, Category: "tech", OccurredAt: time.Now()}
+	require.NoError(t, webhooks.Publish(ctx, event))
+
+	sent, err := dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	ts, err := strconv.ParseInt(gotHeader.Get(service.WebhookTimestampHeader), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, service.SignWebhook("s3cret", ts, gotBody), gotHeader.Get(service.WebhookSignatureHeader))
+	assert.Equal(t, string(models.NewsPublished), gotHeader.Get(service.WebhookEventHeader))
+	assert.JSONEq(t, string(repo.deliveries[0].Payload), string(gotBody))
+
+	assert.Equal(t, models.WebhookSucceeded, repo.deliveries[0].Status)
+	require.Len(t, repo.attempts, 1)
+	assert.Equal(t, http.StatusNoContent, repo.attempts[0].StatusCode)
+}
+
+func TestWebhookDispatcher_RetriesThenSucceeds(t *testing.T) {
+	var calls atomic.Int32
+	repo, webhooks, dispatcher := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "boom", http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	ctx := context.Background()
+
+	require.NoError(t, webhooks.Publish(ctx, models.NewsEvent{ID: 1, Type: models.NewsCreated, NewsID: 1}))
+
+	_, err := dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+
+	delivery := repo.deliveries[0]
+	assert.Equal(t, models.WebhookPending, delivery.Status)
+	assert.Equal(t, http.StatusInternalServerError, delivery.LastStatusCode)
+	assert.Contains(t, delivery.LastError, "boom")
+
+	_, err = dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, models.WebhookSucceeded, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Len(t, repo.attempts, 2)
+}
+
+func TestWebhookDispatcher_FailsAfterMaxAttempts(t *testing.T) {
+	repo, webhooks, dispatcher := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	ctx := context.Background()
+
+	require.NoError(t, webhooks.Publish(ctx, models.NewsEvent{ID: 1, Type: models.NewsDeleted, NewsID: 1}))
+
+	for range 5 {
+		_, err := dispatcher.DispatchBatch(ctx)
+		require.NoError(t, err)
+	}
+
+	delivery := repo.deliveries[0]
+	assert.Equal(t, models.WebhookFailed, delivery.Status)
+	assert.Equal(t, 3, delivery.Attempts)
+	assert.Len(t, repo.attempts, 3)
+}
+
+// Доставки забираются не больше чем по числу воркеров, чтобы lease забранной
+// доставки не истёк, пока она ждёт свободного воркера.
+func TestWebhookDispatcher_ClaimsPerWorkers(t *testing.T) {
+	repo, webhooks, dispatcher := newWebhookFixture(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	ctx := context.Background()
+
+	for i := range 5 {
+		require.NoError(t, webhooks.Publish(ctx, models.NewsEvent{ID: int64(i + 1), Type: models.NewsCreated, NewsID: 1}))
+	}
+
+	sent, err := dispatcher.DispatchBatch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, sent)
+	assert.Equal(t, []int{2, 2, 2}, repo.limits)
+	for _, delivery := range repo.deliveries {
+		assert.Equal(t, models.WebhookSucceeded, delivery.Status)
+	}
+}
@@ -0,0 +1,445 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/zhavkk/news-service/src/news/internal/dto"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/models"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+	MatchingSubscriptions(ctx context.Context, eventType models.NewsEventType, category string) ([]*models.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *models.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id int64) error
+	CreateDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) error
+	ClaimDueDeliveries(ctx context.Context, limit int, lease time.Duration) ([]models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+	ListDeliveries(
+		ctx context.Context,
+		subscriptionID int64,
+		status models.WebhookDeliveryStatus,
+		offset int,
+		limit int,
+	) ([]models.WebhookDelivery, int64, error)
+	GetDelivery(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	ListAttempts(ctx context.Context, deliveryID int64) ([]models.WebhookAttempt, error)
+	Redeliver(ctx context.Context, id int64) error
+}
+
+// webhookSecretBytes — длина сгенерированного секрета подписки.
+const webhookSecretBytes = 32
+
+// WebhookService управляет подписками и журналом доставок. Он же является приёмником
+// событий для outbox relay: Publish ставит событие в очередь доставки каждой
+// подходящей подписке.
+type WebhookService struct {
+	repo      WebhookRepository
+	txManager storage.TxManagerInterface
+}
+
+func NewWebhookService(repo WebhookRepository, txManager storage.TxManagerInterface) *WebhookService {
+	return &WebhookService{
+		repo:      repo,
+		txManager: txManager,
+	}
+}
+
+// Publish создаёт доставки события для подходящих подписок. Вызывается relay
+// внутри его транзакции, поэтому доставки появляются атомарно с отметкой
+// о публикации события.
+func (s *WebhookService) Publish(ctx context.Context, event models.NewsEvent) error {
+	subs, err := s.repo.MatchingSubscriptions(ctx, event.Type, event.Category)
+	if err != nil {
+		return err
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*models.WebhookDelivery, len(subs))
+	for i, sub := range subs {
+		deliveries[i] = &models.WebhookDelivery{
+			SubscriptionID: sub.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+		}
+	}
+
+	return s.repo.CreateDeliveries(ctx, deliveries)
+}
+
+// CreateWebhook godoc
+// @Summary      Create a webhook subscription
+// @Description  Subscribes a URL to news events. The signing secret is returned only in this response.
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        webhook  body      dto.CreateWebhookRequest  true  "Subscription"
+// @Success      201      {object}  dto.WebhookResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      401      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /admin/webhooks [post]
+func (s *WebhookService) CreateWebhook(
+	ctx context.Context,
+	req dto.CreateWebhookRequest,
+) (*dto.WebhookResponse, error) {
+	const op = "service.WebhookService.CreateWebhook"
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	sub := &models.WebhookSubscription{
+		URL:        req.URL,
+		EventTypes: toEventTypes(req.EventTypes),
+		Category:   req.Category,
+		Secret:     secret,
+		Active:     req.Active == nil || *req.Active,
+	}
+
+	err := s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return s.repo.CreateSubscription(ctx, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Webhook subscription created", sub.ID, "url", sub.URL)
+
+	resp := webhookToResponse(sub)
+	resp.Secret = sub.Secret
+	return &resp, nil
+}
+
+// ListWebhooks godoc
+// @Summary      List webhook subscriptions
+// @Tags         webhooks
+// @Produce      json
+// @Success      200  {object}  dto.WebhookListResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/webhooks [get]
+func (s *WebhookService) ListWebhooks(ctx context.Context) (*dto.WebhookListResponse, error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.WebhookListResponse{Items: make([]dto.WebhookResponse, len(subs))}
+	for i, sub := range subs {
+		resp.Items[i] = webhookToResponse(sub)
+	}
+	return resp, nil
+}
+
+// GetWebhook godoc
+// @Summary      Get a webhook subscription
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Subscription ID"
+// @Success      200  {object}  dto.WebhookResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/{id} [get]
+func (s *WebhookService) GetWebhook(ctx context.Context, req dto.WebhookIDRequest) (*dto.WebhookResponse, error) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := webhookToResponse(sub)
+	return &resp, nil
+}
+
+// UpdateWebhook godoc
+// @Summary      Update a webhook subscription
+// @Description  Updates only the fields present in the request body
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                    true  "Subscription ID"
+// @Param        webhook  body      dto.UpdateWebhookRequest  true  "Fields to update"
+// @Success      200      {object}  dto.WebhookResponse
+// @Failure      400      {object}  dto.ErrorResponse
+// @Failure      401      {object}  dto.ErrorResponse
+// @Failure      404      {object}  dto.ErrorResponse
+// @Failure      500      {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/{id} [put]
+func (s *WebhookService) UpdateWebhook(
+	ctx context.Context,
+	req dto.UpdateWebhookRequest,
+) (*dto.WebhookResponse, error) {
+	const op = "service.WebhookService.UpdateWebhook"
+
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var sub *models.WebhookSubscription
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		sub, err = s.repo.GetSubscription(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if req.URL != nil {
+			sub.URL = *req.URL
+		}
+		if req.EventTypes != nil {
+			sub.EventTypes = toEventTypes(*req.EventTypes)
+		}
+		if req.Category != nil {
+			sub.Category = *req.Category
+		}
+		if req.Secret != nil {
+			sub.Secret = *req.Secret
+		}
+		if req.Active != nil {
+			sub.Active = *req.Active
+		}
+
+		return s.repo.UpdateSubscription(ctx, sub)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Webhook subscription updated", sub.ID)
+
+	resp := webhookToResponse(sub)
+	return &resp, nil
+}
+
+// DeleteWebhook godoc
+// @Summary      Delete a webhook subscription
+// @Description  Deletes the subscription together with its delivery log
+// @Tags         webhooks
+// @Param        id   path  string  true  "Subscription ID"
+// @Success      204
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/{id} [delete]
+func (s *WebhookService) DeleteWebhook(ctx context.Context, req dto.WebhookIDRequest) error {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	return s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return s.repo.DeleteSubscription(ctx, id)
+	})
+}
+
+// ListDeliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Returns the delivery log of a subscription, newest first
+// @Tags         webhooks
+// @Produce      json
+// @Param        id      path      string  true   "Subscription ID"
+// @Param        status  query     string  false  "Delivery status" Enums(pending, succeeded, failed)
+// @Param        page    query     int     false  "Page number" default(1)
+// @Param        limit   query     int     false  "Items per page" default(20)
+// @Success      200     {object}  dto.WebhookDeliveryListResponse
+// @Failure      400     {object}  dto.ErrorResponse
+// @Failure      401     {object}  dto.ErrorResponse
+// @Failure      404     {object}  dto.ErrorResponse
+// @Failure      500     {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/{id}/deliveries [get]
+func (s *WebhookService) ListDeliveries(
+	ctx context.Context,
+	req dto.WebhookDeliveryListRequest,
+) (*dto.WebhookDeliveryListResponse, error) {
+	subscriptionID, err := strconv.ParseInt(req.SubscriptionID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+
+	deliveries, total, err := s.repo.ListDeliveries(
+		ctx,
+		subscriptionID,
+		models.WebhookDeliveryStatus(req.Status),
+		(req.Page-1)*req.Limit,
+		req.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.WebhookDeliveryListResponse{
+		Items:      make([]dto.WebhookDeliveryResponse, len(deliveries)),
+		TotalCount: total,
+		Page:       req.Page,
+		Limit:      req.Limit,
+	}
+	for i := range deliveries {
+		resp.Items[i] = deliveryToResponse(&deliveries[i], nil)
+	}
+	return resp, nil
+}
+
+// GetDelivery godoc
+// @Summary      Get a webhook delivery
+// @Description  Returns a delivery with the log of all its attempts
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Delivery ID"
+// @Success      200  {object}  dto.WebhookDeliveryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/deliveries/{id} [get]
+func (s *WebhookService) GetDelivery(
+	ctx context.Context,
+	req dto.WebhookIDRequest,
+) (*dto.WebhookDeliveryResponse, error) {
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.repo.ListAttempts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := deliveryToResponse(delivery, attempts)
+	return &resp, nil
+}
+
+// Redeliver godoc
+// @Summary      Redeliver a webhook
+// @Description  Puts the delivery back into the queue with a full retry budget
+// @Tags         webhooks
+// @Produce      json
+// @Param        id   path      string  true  "Delivery ID"
+// @Success      202  {object}  dto.WebhookDeliveryResponse
+// @Failure      400  {object}  dto.ErrorResponse
+// @Failure      401  {object}  dto.ErrorResponse
+// @Failure      404  {object}  dto.ErrorResponse
+// @Failure      500  {object}  dto.ErrorResponse
+// @Router       /admin/webhooks/deliveries/{id}/redeliver [post]
+func (s *WebhookService) Redeliver(
+	ctx context.Context,
+	req dto.WebhookIDRequest,
+) (*dto.WebhookDeliveryResponse, error) {
+	const op = "service.WebhookService.Redeliver"
+
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.txManager.RunReadCommited(ctx, func(ctx context.Context) error {
+		return s.repo.Redeliver(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info(op, "Webhook delivery requeued", id)
+
+	return s.GetDelivery(ctx, req)
+}
+
+func generateWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+func toEventTypes(values []string) []models.NewsEventType {
+	eventTypes := make([]models.NewsEventType, len(values))
+	for i, value := range values {
+		eventTypes[i] = models.NewsEventType(value)
+	}
+	return eventTypes
+}
+
+func webhookToResponse(sub *models.WebhookSubscription) dto.WebhookResponse {
+	eventTypes := make([]string, len(sub.EventTypes))
+	for i, eventType := range sub.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	return dto.WebhookResponse{
+		ID:         strconv.FormatInt(sub.ID, 10),
+		URL:        sub.URL,
+		EventTypes: eventTypes,
+		Category:   sub.Category,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+}
+
+func deliveryToResponse(delivery *models.WebhookDelivery, attempts []models.WebhookAttempt) dto.WebhookDeliveryResponse {
+	resp := dto.WebhookDeliveryResponse{
+		ID:             strconv.FormatInt(delivery.ID, 10),
+		SubscriptionID: strconv.FormatInt(delivery.SubscriptionID, 10),
+		EventID:        strconv.FormatInt(delivery.EventID, 10),
+		EventType:      string(delivery.EventType),
+		Payload:        delivery.Payload,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+		UpdatedAt:      delivery.UpdatedAt,
+	}
+
+	for _, attempt := range attempts {
+		resp.AttemptLog = append(resp.AttemptLog, dto.WebhookAttemptResponse{
+			Attempt:    attempt.Attempt,
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMS: attempt.Duration.Milliseconds(),
+			CreatedAt:  attempt.CreatedAt,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,75 @@
+// Package slug строит человекочитаемые идентификаторы для URL из заголовков,
+// с транслитерацией кириллицы.
+package slug
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// MaxLength — максимальная длина slug без суффикса уникальности.
+const MaxLength = 80
+
+// Fallback используется, если в заголовке нет ни одного подходящего символа.
+const Fallback = "news"
+
+var translit = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "yo",
+	'ж': "zh", 'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m",
+	'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u",
+	'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "",
+	'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+}
+
+// Make возвращает slug для заголовка: латиница в нижнем регистре, цифры и дефисы.
+func Make(title string) string {
+	var sb strings.Builder
+	dash := false
+
+	for _, r := range strings.ToLower(title) {
+		part, ok := translit[r]
+		if !ok {
+			part = asciiBase(r)
+		}
+		if part == "" {
+			// Твёрдый и мягкий знаки просто пропускаем, остальное — разделитель слов.
+			if !ok {
+				dash = sb.Len() > 0
+			}
+			continue
+		}
+
+		if dash {
+			sb.WriteByte('-')
+			dash = false
+		}
+		sb.WriteString(part)
+	}
+
+	s := sb.String()
+	if len(s) > MaxLength {
+		s = s[:MaxLength]
+		if i := strings.LastIndexByte(s, '-'); i > MaxLength/2 {
+			s = s[:i]
+		}
+		s = strings.TrimRight(s, "-")
+	}
+	if s == "" {
+		return Fallback
+	}
+	return s
+}
+
+// asciiBase возвращает латинскую букву или цифру без диакритики ("é" -> "e")
+// либо пустую строку для остальных символов.
+func asciiBase(r rune) string {
+	var sb strings.Builder
+	for _, c := range norm.NFD.String(string(r)) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,39 @@
+package slug_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zhavkk/news-service/src/news/internal/slug"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Hello, World!", "hello-world"},
+		{"Новости спорта: «Зенит» выиграл", "novosti-sporta-zenit-vyigral"},
+		{"Ёжик в тумане", "yozhik-v-tumane"},
+		{"Подъезд и объём", "podezd-i-obyom"},
+		{"Щука и цапля", "shchuka-i-tsaplya"},
+		{"Café déjà vu", "cafe-deja-vu"},
+		{"  2025 год — итоги  ", "2025-god-itogi"},
+		{"!!!", slug.Fallback},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			assert.Equal(t, tt.want, slug.Make(tt.title))
+		})
+	}
+}
+
+func TestMake_TruncatesOnWordBoundary(t *testing.T) {
+	got := slug.Make(strings.Repeat("длинный заголовок ", 20))
+
+	assert.LessOrEqual(t, len(got), slug.MaxLength)
+	assert.False(t, strings.HasSuffix(got, "-"))
+	assert.True(t, strings.HasSuffix(got, "zagolovok") || strings.HasSuffix(got, "dlinnyy"))
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrLockNotAcquired = errors.New("advisory lock is held by another session")
+
+// AdvisoryLock — сессионная advisory-блокировка Postgres. Она живёт, пока открыто
+// выделенное под неё соединение, поэтому соединение не возвращается в пул до Release.
+type AdvisoryLock struct {
+	conn *pgxpool.Conn
+	key  int64
+}
+
+// TryAdvisoryLock пытается взять блокировку без ожидания.
+// Если её держит другая сессия, возвращается ErrLockNotAcquired.
+func (s *Storage) TryAdvisoryLock(ctx context.Context, key int64) (*AdvisoryLock, error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("acquire connection for advisory lock: %w", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, fmt.Errorf("try advisory lock %d: %w", key, err)
+	}
+	if !locked {
+		conn.Release()
+		return nil, ErrLockNotAcquired
+	}
+
+	return &AdvisoryLock{conn: conn, key: key}, nil
+}
+
+// Alive проверяет, что соединение с блокировкой ещё живо.
+func (l *AdvisoryLock) Alive(ctx context.Context) error {
+	return l.conn.Ping(ctx)
+}
+
+// Release снимает блокировку и возвращает соединение в пул.
+func (l *AdvisoryLock) Release(ctx context.Context) {
+	if _, err := l.conn.Exec(ctx, "SELECT pg_advisory_unlock($1)", l.key); err != nil {
+		// Соединение в неизвестном состоянии: закрываем его, вместе с ним уйдёт и блокировка.
+		_ = l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+}
@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+)
+
+// S3MediaStorage работает с любым S3-совместимым хранилищем (AWS S3, MinIO).
+type S3MediaStorage struct {
+	client *minio.Client
+	bucket string
+}
+
+func NewS3MediaStorage(ctx context.Context, cfg *config.MediaS3Config) (*S3MediaStorage, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMedia, err)
+	}
+
+	exists, err := client.BucketExists(ctx, cfg.Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMedia, err)
+	}
+	if !exists {
+		err := client.MakeBucket(ctx, cfg.Bucket, minio.MakeBucketOptions{Region: cfg.Region})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrFailedToConnectToMedia, err)
+		}
+	}
+
+	return &S3MediaStorage{client: client, bucket: cfg.Bucket}, nil
+}
+
+func (s *S3MediaStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, r, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *S3MediaStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, s.mapError(err)
+	}
+
+	// GetObject ленивый: ошибка "нет такого ключа" приходит только на первом обращении.
+	if _, err := obj.Stat(); err != nil {
+		_ = obj.Close()
+		return nil, s.mapError(err)
+	}
+	return obj, nil
+}
+
+func (s *S3MediaStorage) Delete(ctx context.Context, key string) error {
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3MediaStorage) mapError(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/zhavkk/news-service/src/news/internal/config"
+)
+
+var (
+	ErrObjectNotFound         = errors.New("object not found")
+	ErrUnknownMediaBackend    = errors.New("unknown media storage backend")
+	ErrFailedToConnectToMedia = errors.New("failed to connect to media storage")
+)
+
+const (
+	MediaBackendLocal = "local"
+	MediaBackendS3    = "s3"
+)
+
+// MediaStorage хранит бинарное содержимое медиафайлов по ключу.
+// Метаданные файлов хранятся отдельно, в Postgres.
+type MediaStorage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+func NewMediaStorage(ctx context.Context, cfg *config.MediaConfig) (MediaStorage, error) {
+	switch cfg.Backend {
+	case MediaBackendLocal, "":
+		return NewLocalMediaStorage(cfg.LocalDir)
+	case MediaBackendS3:
+		return NewS3MediaStorage(ctx, &cfg.S3)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownMediaBackend, cfg.Backend)
+	}
+}
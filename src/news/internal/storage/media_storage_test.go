@@ -0,0 +1,63 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/storage"
+)
+
+func testMediaStorage(t *testing.T, files storage.MediaStorage) {
+	ctx := context.Background()
+	data := []byte("not really an image")
+
+	err := files.Put(ctx, "originals/ab/abcdef.png", bytes.NewReader(data), int64(len(data)), "image/png")
+	require.NoError(t, err)
+
+	r, err := files.Get(ctx, "originals/ab/abcdef.png")
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, data, got)
+
+	require.NoError(t, files.Delete(ctx, "originals/ab/abcdef.png"))
+
+	_, err = files.Get(ctx, "originals/ab/abcdef.png")
+	assert.ErrorIs(t, err, storage.ErrObjectNotFound)
+}
+
+func TestLocalMediaStorage(t *testing.T) {
+	files, err := storage.NewLocalMediaStorage(t.TempDir())
+	require.NoError(t, err)
+
+	testMediaStorage(t, files)
+
+	err = files.Put(context.Background(), "../escape", bytes.NewReader(nil), 0, "image/png")
+	assert.Error(t, err)
+}
+
+// Для запуска нужен MinIO: docker-compose up -d minio
+// и MEDIA_S3_TEST_ENDPOINT=localhost:9000.
+func TestS3MediaStorage(t *testing.T) {
+	endpoint := os.Getenv("MEDIA_S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("MEDIA_S3_TEST_ENDPOINT is not set")
+	}
+
+	files, err := storage.NewS3MediaStorage(context.Background(), &config.MediaS3Config{
+		Endpoint:  endpoint,
+		Bucket:    "news-media-test",
+		AccessKey: "minioadmin",
+		SecretKey: "minioadmin",
+	})
+	require.NoError(t, err)
+
+	testMediaStorage(t, files)
+}
@@ -24,6 +24,7 @@ func NewRedisClient(ctx context.Context, cfg *config.RedisConfig) (*RedisClient,
 
 	if err := redisClient.Ping(ctx).Err(); err != nil {
 		logger.Log.Error("failed to ping redis", "error", err)
+		_ = redisClient.Close()
 		return nil, err
 	}
 
@@ -33,3 +34,7 @@ func NewRedisClient(ctx context.Context, cfg *config.RedisConfig) (*RedisClient,
 func (r *RedisClient) GetRedis() *redis.Client {
 	return r.redis
 }
+
+func (r *RedisClient) Close() error {
+	return r.redis.Close()
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+)
+
+// replicaCheckTimeout ограничивает одну проверку отставания реплики.
+const replicaCheckTimeout = 2 * time.Second
+
+// replicaStatusQuery читает состояние репликации: идёт ли потоковый приём WAL,
+// проиграно ли всё полученное и сколько секунд прошло с последней проигранной
+// транзакции. Статус приёмника виден только ролям с pg_read_all_stats.
+const replicaStatusQuery = `
+    SELECT pg_is_in_recovery(),
+        COALESCE((SELECT status = 'streaming' FROM pg_stat_wal_receiver), false),
+        COALESCE(pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn(), false),
+        EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::FLOAT8`
+
+// replicaStatus — результат replicaStatusQuery.
+type replicaStatus struct {
+	inRecovery bool
+	streaming  bool
+	caughtUp   bool
+	// replayAge — секунды с последней проигранной транзакции; nil, если реплика
+	// ещё ничего не проиграла.
+	replayAge *float64
+}
+
+// lag возвращает отставание реплики; false, если его нельзя оценить. Реплика,
+// которая проиграла весь полученный WAL, не отстаёт, даже если основная база
+// давно ничего не писала, — но только пока приёмник WAL подключён. Иначе она
+// проиграет всё, что успела получить, и будет казаться догнавшей, хотя основная
+// база ушла вперёд, поэтому отставание считается по времени последней транзакции.
+func (s replicaStatus) lag() (time.Duration, bool) {
+	switch {
+	case !s.inRecovery:
+		return 0, true
+	case s.streaming && s.caughtUp:
+		return 0, true
+	case s.replayAge == nil:
+		return 0, false
+	default:
+		return time.Duration(*s.replayAge * float64(time.Second)), true
+	}
+}
+
+// replica — пул одной реплики и результат последней проверки.
+type replica struct {
+	index   int
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// replicaSet раздаёт исправные реплики по кругу.
+type replicaSet struct {
+	replicas []*replica
+	maxLag   time.Duration
+	next     atomic.Uint64
+}
+
+// newReplicaSet открывает пулы реплик и сразу проверяет их, чтобы чтение
+// не ушло на отставшую реплику до первой периодической проверки.
+func newReplicaSet(ctx context.Context, cfg config.ReplicasConfig) (*replicaSet, error) {
+	set := &replicaSet{maxLag: cfg.MaxLag}
+	for i, url := range cfg.URLs {
+		pool, err := newPool(ctx, url)
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("%w: replica %d", ErrFailedToConnectToDB, i)
+		}
+		set.replicas = append(set.replicas, &replica{index: i, pool: pool})
+	}
+	set.check(ctx)
+	return set, nil
+}
+
+func (s *replicaSet) watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.check(ctx)
+		}
+	}
+}
+
+func (s *replicaSet) check(ctx context.Context) {
+	const op = "storage.replicaSet.check"
+
+	for _, r := range s.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, replicaCheckTimeout)
+		var status replicaStatus
+		err := r.pool.QueryRow(checkCtx, replicaStatusQuery).Scan(&status.inRecovery, &status.streaming, &status.caughtUp, &status.replayAge)
+		cancel()
+
+		lag, known := status.lag()
+		healthy := err == nil && known && lag <= s.maxLag
+		if r.healthy.Swap(healthy) != healthy {
+			if healthy {
+				logger.Log.Info(op, "Replica is back in rotation", r.index, "lag", lag)
+			} else {
+				logger.Log.Warn(op, "Replica is out of rotation", r.index, "lag", lag, "error", err)
+			}
+		}
+	}
+}
+
+// pick возвращает следующую исправную реплику или nil, если таких нет.
+func (s *replicaSet) pick() *replica {
+	n := uint64(len(s.replicas))
+	start := s.next.Add(1)
+	for i := range n {
+		if r := s.replicas[(start+i)%n]; r.healthy.Load() {
+			return r
+		}
+	}
+	return nil
+}
+
+// markFailed выводит реплику из ротации до следующей успешной проверки.
+func (s *replicaSet) markFailed(r *replica, err error) {
+	const op = "storage.replicaSet.markFailed"
+
+	if r.healthy.Swap(false) {
+		logger.Log.Warn(op, "Replica is out of rotation", r.index, "error", err)
+	}
+}
+
+func (s *replicaSet) close() {
+	for _, r := range s.replicas {
+		r.pool.Close()
+	}
+}
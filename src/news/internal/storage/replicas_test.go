@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicaStatus_lag(t *testing.T) {
+	age := func(seconds float64) *float64 { return &seconds }
+
+	tests := []struct {
+		name   string
+		status replicaStatus
+		lag    time.Duration
+		known  bool
+	}{
+		{"primary", replicaStatus{}, 0, true},
+		{"streaming and caught up on an idle primary", replicaStatus{inRecovery: true, streaming: true, caughtUp: true, replayAge: age(3600)}, 0, true},
+		{"streaming and replaying", replicaStatus{inRecovery: true, streaming: true, replayAge: age(2)}, 2 * time.Second, true},
+		// Приёмник отключился: реплика проиграла всё полученное, но основная база ушла вперёд.
+		{"receiver not streaming", replicaStatus{inRecovery: true, caughtUp: true, replayAge: age(120)}, 120 * time.Second, true},
+		{"nothing replayed yet", replicaStatus{inRecovery: true, caughtUp: true}, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lag, known := tt.status.lag()
+			assert.Equal(t, tt.known, known)
+			assert.Equal(t, tt.lag, lag)
+		})
+	}
+}
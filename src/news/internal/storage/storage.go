@@ -4,13 +4,17 @@ package storage
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zhavkk/news-service/src/news/internal/config"
 )
 
 type Storage struct {
-	db *pgxpool.Pool
+	db       *pgxpool.Pool
+	replicas *replicaSet
+	stop     context.CancelFunc
+	wg       sync.WaitGroup
 }
 
 var (
@@ -21,12 +25,32 @@ var (
 func NewStorage(ctx context.Context, cfg *config.Config) (*Storage, error) {
 	dsn := cfg.DBURL
 
-	pool, err := pgxpool.New(ctx, dsn)
+	pool, err := newPool(ctx, dsn)
 	if err != nil {
 		return nil, ErrFailedToConnectToDB
 	}
 
-	return &Storage{db: pool}, nil
+	s := &Storage{db: pool}
+	if len(cfg.Replicas.URLs) == 0 {
+		return s, nil
+	}
+
+	s.replicas, err = newReplicaSet(ctx, cfg.Replicas)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
+
+	// Проверки отставания живут, пока открыто хранилище, а не пока жив ctx запуска.
+	checkCtx, stop := context.WithCancel(context.Background())
+	s.stop = stop
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.replicas.watch(checkCtx, cfg.Replicas.CheckInterval)
+	}()
+
+	return s, nil
 }
 
 func (s *Storage) Close() error {
@@ -34,6 +58,11 @@ func (s *Storage) Close() error {
 		return ErrDBNotConnected
 	}
 
+	if s.replicas != nil {
+		s.stop()
+		s.wg.Wait()
+		s.replicas.close()
+	}
 	s.db.Close()
 	return nil
 }
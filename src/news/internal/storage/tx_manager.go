@@ -2,9 +2,13 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zhavkk/news-service/src/news/internal/config"
 )
 
@@ -13,10 +17,12 @@ type TxManagerInterface interface {
 	RunReadUncommited(ctx context.Context, f func(context.Context) error) error
 	RunReadCommited(ctx context.Context, f func(context.Context) error) error
 	RunRepeatableRead(ctx context.Context, f func(context.Context) error) error
+	RunReadOnly(ctx context.Context, f func(context.Context) error) error
 }
 
 type TxManager struct {
-	db *Storage
+	db          *Storage
+	retryPolicy RetryPolicy
 }
 
 func NewTxManager(ctx context.Context, cfg *config.Config) (*TxManager, error) {
@@ -24,7 +30,7 @@ func NewTxManager(ctx context.Context, cfg *config.Config) (*TxManager, error) {
 	if err != nil {
 		return nil, fmt.Errorf("NewTxManager: %w", ErrFailedToConnectToDB)
 	}
-	return &TxManager{db: db}, nil
+	return &TxManager{db: db, retryPolicy: retryPolicyFromConfig(cfg.Tx)}, nil
 }
 
 func (m *TxManager) GetDatabase() *Storage {
@@ -62,9 +68,86 @@ func (m *TxManager) RunRepeatableRead(ctx context.Context, f func(context.Contex
 	}
 	return m.beginFunc(ctx, opts, f)
 }
+
+// RunReadOnly выполняет f в читающей транзакции (AccessMode: pgx.ReadOnly) на
+// исправной реплике, а без реплик — на основной базе. Если соединение с репликой
+// оборвалось, реплика выводится из ротации, а f повторяется на основной базе.
+// Данные на реплике могут отставать не больше чем на replicas.max_lag. Внутри
+// другой транзакции f выполняется в ней, под SAVEPOINT.
+func (m *TxManager) RunReadOnly(ctx context.Context, f func(context.Context) error) error {
+	if tx, ok := GetTxFromContext(ctx); ok {
+		return runNested(ctx, tx, f)
+	}
+
+	opts := pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	}
+
+	return m.retry(ctx, func(ctx context.Context) error {
+		if m.db.replicas != nil {
+			if r := m.db.replicas.pick(); r != nil {
+				err := runTx(ctx, r.pool, opts, f)
+				var lost *connLostError
+				if !errors.As(err, &lost) || ctx.Err() != nil {
+					return err
+				}
+				m.db.replicas.markFailed(r, lost.err)
+			}
+		}
+		return runTx(ctx, m.db.GetPool(), opts, f)
+	})
+}
+
+// beginFunc выполняет f в транзакции на основной базе, повторяя её по политике
+// повторов. Если в ctx уже есть транзакция, f выполняется в ней под SAVEPOINT.
 func (m *TxManager) beginFunc(ctx context.Context, opts pgx.TxOptions, f func(context.Context) error) error {
-	tx, err := m.db.GetPool().BeginTx(ctx, opts)
+	if tx, ok := GetTxFromContext(ctx); ok {
+		return runNested(ctx, tx, f)
+	}
+
+	return m.retry(ctx, func(ctx context.Context) error {
+		return runTx(ctx, m.db.GetPool(), opts, f)
+	})
+}
+
+// connLostError — соединение оборвалось до COMMIT, то есть транзакция точно не
+// зафиксирована. Обрыв во время COMMIT так не помечается: исход неизвестен.
+type connLostError struct {
+	err error
+}
+
+func (e *connLostError) Error() string { return e.err.Error() }
+
+func (e *connLostError) Unwrap() error { return e.err }
+
+// isConnLost сообщает, что запрос не дошёл до базы из-за соединения: pgconn
+// считает его безопасным для повтора или это сетевая ошибка. Отмена ctx и
+// ошибки самой базы обрывом не считаются.
+func isConnLost(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if pgconn.SafeToRetry(err) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// runTx выполняет f в транзакции на пуле pool. Обрыв соединения помечается
+// connLostError, чтобы транзакцию повторили: при BEGIN f ещё не выполнялась, а
+// после ошибки f — только в читающей транзакции. Пишущая f могла успеть сделать
+// что-то вне базы (записать в Redis, опубликовать событие), и повторять её нельзя.
+func runTx(ctx context.Context, pool *pgxpool.Pool, opts pgx.TxOptions, f func(context.Context) error) error {
+	tx, err := pool.BeginTx(ctx, opts)
 	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if isConnLost(err) {
+			return &connLostError{err: err}
+		}
 		return err
 	}
 
@@ -75,12 +158,42 @@ func (m *TxManager) beginFunc(ctx context.Context, opts pgx.TxOptions, f func(co
 	ctx = context.WithValue(ctx, txKey{}, tx)
 
 	if err := f(ctx); err != nil {
+		if opts.AccessMode == pgx.ReadOnly && tx.Conn().IsClosed() && ctx.Err() == nil {
+			return &connLostError{err: err}
+		}
 		return err
 	}
 
 	return tx.Commit(ctx)
 }
 
+// runNested выполняет f во внешней транзакции под SAVEPOINT: ошибка f откатывает
+// только сделанное в f, и внешняя транзакция может продолжаться. Уровень изоляции
+// и режим доступа остаются от внешней транзакции. Вложенный вызов не повторяется.
+// Код ошибки из f не записывается в txState внешней транзакции: вызывающий мог
+// обработать ошибку и продолжить (так outbox relay помечает одно событие), и
+// повтор внешней транзакции из-за неё выполнил бы уже сделанное заново. Ошибка,
+// возвращённая наружу, повторяет внешнюю транзакцию, только если pgconn.PgError
+// виден в ней через errors.As.
+func runNested(ctx context.Context, tx pgx.Tx, f func(context.Context) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = savepoint.Rollback(ctx)
+	}()
+
+	nested := context.WithValue(ctx, txKey{}, savepoint)
+	nested = context.WithValue(nested, txStateKey{}, &txState{})
+	if err := f(nested); err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
 type txKey struct{}
 
 func GetTxFromContext(ctx context.Context) (pgx.Tx, bool) {
@@ -89,5 +202,20 @@ func GetTxFromContext(ctx context.Context) (pgx.Tx, bool) {
 }
 
 func NewTxManagerForTest(db *Storage) *TxManager {
-	return &TxManager{db: db}
+	return &TxManager{db: db, retryPolicy: NoRetry}
+}
+
+// Querier — методы чтения, общие для пула и транзакции.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Reader возвращает транзакцию из контекста, чтобы чтение шло в ней (и на той же
+// реплике, если это RunReadOnly), а без транзакции — пул основной базы.
+func (s *Storage) Reader(ctx context.Context) Querier {
+	if tx, ok := GetTxFromContext(ctx); ok {
+		return tx
+	}
+	return s.db
 }
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zhavkk/news-service/src/news/internal/config"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/metrics"
+)
+
+// Причины повтора транзакции, они же значения метки reason в метриках.
+const (
+	retrySerializationFailure = "serialization_failure"
+	retryDeadlock             = "deadlock"
+	retryConnectionLost       = "connection_lost"
+)
+
+// retryableSQLStates — коды ошибок, после которых транзакцию можно выполнить заново.
+var retryableSQLStates = map[string]string{
+	"40001": retrySerializationFailure,
+	"40P01": retryDeadlock,
+}
+
+// RetryPolicy задаёт повторы транзакции: не больше MaxAttempts попыток с
+// экспоненциальной задержкой от RetryBackoff до MaxBackoff со случайным разбросом.
+type RetryPolicy struct {
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	MaxBackoff   time.Duration
+}
+
+// NoRetry — для транзакций, которые нельзя выполнять повторно, например если f
+// делает что-то необратимое вне базы.
+var NoRetry = RetryPolicy{MaxAttempts: 1}
+
+func retryPolicyFromConfig(cfg config.TxConfig) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  max(cfg.MaxAttempts, 1),
+		RetryBackoff: cfg.RetryBackoff,
+		MaxBackoff:   cfg.MaxBackoff,
+	}
+}
+
+type retryPolicyKey struct{}
+
+// WithRetryPolicy заменяет политику повторов из конфига для транзакций,
+// начатых с этим контекстом.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+// backoff возвращает задержку перед попыткой attempt+1: половина
+// экспоненциальной задержки плюс случайная добавка до второй половины, чтобы
+// столкнувшиеся транзакции не повторялись одновременно.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.RetryBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retry выполняет run, пока он падает с повторяемой ошибкой и позволяют политика
+// и дедлайн ctx. Повторяется вся транзакция целиком, поэтому f должна быть готова
+// к повторному выполнению.
+func (m *TxManager) retry(ctx context.Context, run func(context.Context) error) error {
+	const op = "storage.TxManager.retry"
+
+	policy := m.retryPolicy
+	if p, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		policy = p
+	}
+
+	for attempt := 1; ; attempt++ {
+		state := &txState{}
+		err := run(context.WithValue(ctx, txStateKey{}, state))
+		if err == nil {
+			return nil
+		}
+
+		reason := retryReason(err, state)
+		if reason == "" || ctx.Err() != nil {
+			return unwrapConnLost(err)
+		}
+
+		delay := policy.backoff(attempt)
+		deadline, hasDeadline := ctx.Deadline()
+		if attempt >= policy.MaxAttempts || (hasDeadline && time.Until(deadline) < delay) {
+			metrics.TxRetriesExhausted.WithLabelValues(reason).Inc()
+			return unwrapConnLost(err)
+		}
+
+		metrics.TxRetries.WithLabelValues(reason).Inc()
+		logger.Log.Debug(op, "Retrying transaction", reason, "attempt", attempt, "delay", delay)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return unwrapConnLost(err)
+		case <-timer.C:
+		}
+	}
+}
+
+// retryReason возвращает причину, по которой транзакцию стоит повторить, или "".
+// Репозитории оборачивают ошибки pgx без %w, поэтому код ошибки берётся из
+// txState, куда его записал sqlStateTracer.
+func retryReason(err error, state *txState) string {
+	var lost *connLostError
+	if errors.As(err, &lost) {
+		return retryConnectionLost
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		if reason, ok := retryableSQLStates[pgErr.Code]; ok {
+			return reason
+		}
+	}
+	return retryableSQLStates[state.sqlState]
+}
+
+func unwrapConnLost(err error) error {
+	var lost *connLostError
+	if errors.As(err, &lost) {
+		return lost.err
+	}
+	return err
+}
+
+// txState — то, что sqlStateTracer узнал о текущей попытке транзакции.
+type txState struct {
+	sqlState string
+}
+
+type txStateKey struct{}
+
+// sqlStateTracer запоминает в txState из контекста запроса первый повторяемый
+// код ошибки. Транзакция выполняется в одной горутине, поэтому без блокировок.
+type sqlStateTracer struct{}
+
+func (sqlStateTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	return ctx
+}
+
+func (sqlStateTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	recordSQLState(ctx, data.Err)
+}
+
+func (sqlStateTracer) TraceBatchStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceBatchStartData) context.Context {
+	return ctx
+}
+
+func (sqlStateTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	recordSQLState(ctx, data.Err)
+}
+
+func (sqlStateTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	recordSQLState(ctx, data.Err)
+}
+
+func recordSQLState(ctx context.Context, err error) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return
+	}
+	if _, ok := retryableSQLStates[pgErr.Code]; !ok {
+		return
+	}
+	if state, ok := ctx.Value(txStateKey{}).(*txState); ok && state.sqlState == "" {
+		state.sqlState = pgErr.Code
+	}
+}
+
+// newPool открывает пул с sqlStateTracer.
+func newPool(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ConnConfig.Tracer = sqlStateTracer{}
+	return pgxpool.NewWithConfig(ctx, cfg)
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/zhavkk/news-service/src/news/internal/logger"
+	"github.com/zhavkk/news-service/src/news/internal/metrics"
+)
+
+func TestTxManager_retry(t *testing.T) {
+	logger.Init("local")
+	ctx := context.Background()
+	m := &TxManager{retryPolicy: RetryPolicy{MaxAttempts: 3, RetryBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}}
+
+	t.Run("serialization failure is retried until success", func(t *testing.T) {
+		before := testutil.ToFloat64(metrics.TxRetries.WithLabelValues(retrySerializationFailure))
+		attempts := 0
+		err := m.retry(ctx, func(ctx context.Context) error {
+			attempts++
+			if attempts < 3 {
+				return &pgconn.PgError{Code: "40001"}
+			}
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, attempts)
+		assert.Equal(t, before+2, testutil.ToFloat64(metrics.TxRetries.WithLabelValues(retrySerializationFailure)))
+	})
+
+	t.Run("code hidden by repository wrapping is taken from the tracer", func(t *testing.T) {
+		attempts := 0
+		err := m.retry(ctx, func(ctx context.Context) error {
+			attempts++
+			pgErr := &pgconn.PgError{Code: "40P01"}
+			recordSQLState(ctx, pgErr)
+			return fmt.Errorf("failed to save news: %v", pgErr)
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("other errors are returned at once", func(t *testing.T) {
+		attempts := 0
+		boom := errors.New("boom")
+		err := m.retry(ctx, func(ctx context.Context) error {
+			attempts++
+			recordSQLState(ctx, &pgconn.PgError{Code: "23505"})
+			return boom
+		})
+		assert.ErrorIs(t, err, boom)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("lost connection is retried and unwrapped", func(t *testing.T) {
+		attempts := 0
+		reset := errors.New("connection reset by peer")
+		err := m.retry(ctx, func(ctx context.Context) error {
+			attempts++
+			return &connLostError{err: reset}
+		})
+		assert.Equal(t, reset, err)
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("no retry past the context deadline or with NoRetry", func(t *testing.T) {
+		slow := &TxManager{retryPolicy: RetryPolicy{MaxAttempts: 10, RetryBackoff: time.Second}}
+		deadlineCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+		defer cancel()
+
+		attempts := 0
+		run := func(ctx context.Context) error {
+			attempts++
+			return &pgconn.PgError{Code: "40001"}
+		}
+		assert.Error(t, slow.retry(deadlineCtx, run))
+		assert.Equal(t, 1, attempts)
+
+		attempts = 0
+		assert.Error(t, m.retry(WithRetryPolicy(ctx, NoRetry), run))
+		assert.Equal(t, 1, attempts)
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{RetryBackoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 10 * time.Millisecond, 2: 20 * time.Millisecond, 5: 40 * time.Millisecond} {
+		for range 20 {
+			delay := policy.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, want/2)
+			assert.LessOrEqual(t, delay, want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package migrations встраивает SQL-миграции в бинарники сервиса, чтобы
+// применять их без goose CLI и без исходников рядом.
+package migrations
+
+import "embed"
+
+// Postgres — миграции в формате goose, каталог postgres.
+//
+//go:embed postgres/*.sql
+var Postgres embed.FS
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE media (
+    id BIGSERIAL PRIMARY KEY,
+    storage_key TEXT NOT NULL UNIQUE,
+    file_name TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    checksum TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE content_blocks DROP CONSTRAINT content_blocks_type_check;
+ALTER TABLE content_blocks ADD CONSTRAINT content_blocks_type_check CHECK (type IN ('text','link','image'));
+ALTER TABLE content_blocks ADD COLUMN media_id BIGINT REFERENCES media(id);
+ALTER TABLE content_blocks ADD CONSTRAINT content_blocks_image_media_check CHECK (type <> 'image' OR media_id IS NOT NULL);
+
+CREATE INDEX idx_content_media_id ON content_blocks(media_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM content_blocks WHERE type = 'image';
+ALTER TABLE content_blocks DROP CONSTRAINT content_blocks_image_media_check;
+ALTER TABLE content_blocks DROP COLUMN media_id;
+ALTER TABLE content_blocks DROP CONSTRAINT content_blocks_type_check;
+ALTER TABLE content_blocks ADD CONSTRAINT content_blocks_type_check CHECK (type IN ('text','link'));
+DROP TABLE IF EXISTS media;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE media_variants (
+    id BIGSERIAL PRIMARY KEY,
+    media_id BIGINT NOT NULL REFERENCES media(id) ON DELETE CASCADE,
+    source_checksum TEXT NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    format TEXT NOT NULL,
+    mime_type TEXT NOT NULL,
+    storage_key TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (source_checksum, width, format)
+);
+
+CREATE INDEX idx_media_variants_media_id ON media_variants(media_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS media_variants;
+-- +goose StatementEnd
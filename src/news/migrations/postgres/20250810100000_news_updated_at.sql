@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE news ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+UPDATE news SET updated_at = created_at;
+
+CREATE INDEX idx_news_start_time ON news(start_time);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_news_start_time;
+ALTER TABLE news DROP COLUMN updated_at;
+-- +goose StatementEnd
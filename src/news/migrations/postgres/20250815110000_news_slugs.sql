@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE news ADD COLUMN slug TEXT;
+UPDATE news SET slug = 'news-' || id;
+ALTER TABLE news ALTER COLUMN slug SET NOT NULL;
+ALTER TABLE news ADD CONSTRAINT news_slug_key UNIQUE (slug);
+
+CREATE TABLE news_slug_history (
+    slug TEXT PRIMARY KEY,
+    news_id BIGINT NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_news_slug_history_news_id ON news_slug_history(news_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS news_slug_history;
+ALTER TABLE news DROP COLUMN slug;
+-- +goose StatementEnd
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX idx_news_end_time ON news(end_time);
+
+CREATE TABLE news_schedule_state (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    processed_until TIMESTAMPTZ NOT NULL
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS news_schedule_state;
+DROP INDEX IF EXISTS idx_news_end_time;
+-- +goose StatementEnd
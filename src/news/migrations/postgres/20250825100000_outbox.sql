@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+-- news_id без внешнего ключа: события об удалённых новостях тоже должны быть доставлены.
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    news_id BIGINT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','published','dead')),
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    available_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(news_id, id) WHERE status = 'pending';
+CREATE INDEX idx_outbox_published_at ON outbox(published_at) WHERE status = 'published';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS outbox;
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE news ADD COLUMN live BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE live_entries (
+    id BIGSERIAL PRIMARY KEY,
+    news_id BIGINT NOT NULL REFERENCES news(id) ON DELETE CASCADE,
+    pinned BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_live_entries_news_id ON live_entries(news_id, id);
+CREATE INDEX idx_live_entries_pinned ON live_entries(news_id, id) WHERE pinned;
+
+CREATE TABLE live_entry_blocks (
+    id BIGSERIAL PRIMARY KEY,
+    entry_id BIGINT NOT NULL REFERENCES live_entries(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('text','link','image')),
+    content TEXT NOT NULL,
+    media_id BIGINT REFERENCES media(id),
+    position INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (entry_id, position),
+    CHECK (type <> 'image' OR media_id IS NOT NULL)
+);
+
+CREATE INDEX idx_live_entry_blocks_media_id ON live_entry_blocks(media_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS live_entry_blocks;
+DROP TABLE IF EXISTS live_entries;
+ALTER TABLE news DROP COLUMN live;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE news_external_ids (
+    external_id TEXT PRIMARY KEY,
+    news_id BIGINT NOT NULL UNIQUE REFERENCES news(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE import_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    format TEXT NOT NULL CHECK (format IN ('ndjson','csv')),
+    dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','running','succeeded','failed')),
+    bytes_total BIGINT NOT NULL DEFAULT 0,
+    bytes_read BIGINT NOT NULL DEFAULT 0,
+    processed INT NOT NULL DEFAULT 0,
+    created INT NOT NULL DEFAULT 0,
+    updated INT NOT NULL DEFAULT 0,
+    failed INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE TABLE import_job_errors (
+    id BIGSERIAL PRIMARY KEY,
+    job_id BIGINT NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    line INT NOT NULL,
+    external_id TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL
+);
+
+CREATE INDEX idx_import_job_errors_job_id ON import_job_errors(job_id, line);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS import_job_errors;
+DROP TABLE IF EXISTS import_jobs;
+DROP TABLE IF EXISTS news_external_ids;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','running','succeeded','failed','canceled')),
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_until TIMESTAMPTZ,
+    last_error TEXT NOT NULL DEFAULT '',
+    schedule_name TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX idx_jobs_due ON jobs(run_at) WHERE status = 'pending';
+CREATE INDEX idx_jobs_lease ON jobs(locked_until) WHERE status = 'running';
+CREATE INDEX idx_jobs_status_kind ON jobs(status, kind, id);
+
+CREATE TABLE job_schedules (
+    name TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    schedule TEXT NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    next_run_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Файл импорта хранится до завершения, чтобы задачу можно было повторить
+-- на любой реплике.
+ALTER TABLE import_jobs ADD COLUMN data BYTEA;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE import_jobs DROP COLUMN IF EXISTS data;
+DROP TABLE IF EXISTS job_schedules;
+DROP TABLE IF EXISTS jobs;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Файл импорта хранится частями: его можно записать из потока запроса и
+-- прочитать обратно, не держа целиком в памяти.
+CREATE TABLE import_job_chunks (
+    job_id BIGINT NOT NULL REFERENCES import_jobs(id) ON DELETE CASCADE,
+    seq INT NOT NULL,
+    data BYTEA NOT NULL,
+    PRIMARY KEY (job_id, seq)
+);
+
+INSERT INTO import_job_chunks (job_id, seq, data)
+SELECT id, 0, data FROM import_jobs WHERE data IS NOT NULL AND length(data) > 0;
+
+ALTER TABLE import_jobs DROP COLUMN data;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE import_jobs ADD COLUMN data BYTEA;
+
+UPDATE import_jobs j
+SET data = (SELECT string_agg(c.data, ''::bytea ORDER BY c.seq) FROM import_job_chunks c WHERE c.job_id = j.id)
+WHERE EXISTS (SELECT 1 FROM import_job_chunks c WHERE c.job_id = j.id);
+
+DROP TABLE IF EXISTS import_job_chunks;
+-- +goose StatementEnd